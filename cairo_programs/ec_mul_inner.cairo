@@ -0,0 +1,26 @@
+%builtins range_check
+
+from starkware.cairo.common.cairo_secp.bigint import BigInt3
+from starkware.cairo.common.cairo_secp.ec import EcPoint, ec_mul
+from starkware.cairo.common.cairo_secp.field import is_zero
+
+func main{range_check_ptr: felt}() {
+    // The generator of secp256k1
+    let g = EcPoint(
+        BigInt3(17117865558768631194064792, 12501176021340589225372855, 9198697782662356105779718),
+        BigInt3(6441780312434748884571320, 57953919405111227542741658, 5457536640262350763842127),
+    );
+
+    let (res) = ec_mul(g, BigInt3(5, 0, 0));
+    assert res = EcPoint(
+        BigInt3(40625186915024212701802468, 34549597667009688130221937, 3592501449746983888968522),
+        BigInt3(16208358260925515074134742, 47708208270201726367985324, 16371303375922060984143290),
+    );
+
+    let (zero) = is_zero(BigInt3(0, 0, 0));
+    assert zero = 1;
+    let (non_zero) = is_zero(BigInt3(7, 0, 0));
+    assert non_zero = 0;
+
+    return ();
+}
@@ -0,0 +1,40 @@
+%builtins range_check
+
+from starkware.cairo.common.cairo_secp.bigint import BigInt3
+
+from cairo_programs.ed25519_field import is_zero, div_mod_n
+
+func test_is_zero{range_check_ptr}() {
+    let (res) = is_zero(BigInt3(0, 0, 0));
+    assert res = 1;
+
+    // p = 2**255 - 19
+    let (res) = is_zero(
+        BigInt3(77371252455336267181195245, 77371252455336267181195263, 9671406556917033397649407)
+    );
+    assert res = 1;
+
+    let (res) = is_zero(BigInt3(1, 2, 3));
+    assert res = 0;
+
+    return ();
+}
+
+func test_div_mod_n{range_check_ptr}() {
+    let a = BigInt3(100, 99, 98);
+    let b = BigInt3(10, 9, 8);
+
+    let (res) = div_mod_n(a, b);
+    assert res = BigInt3(
+        58896577246868184801841192, 5947265610841176150681100, 697826471513448188639326
+    );
+
+    return ();
+}
+
+func main{range_check_ptr}() {
+    test_is_zero();
+    test_div_mod_n();
+
+    return ();
+}
@@ -0,0 +1,135 @@
+// Arithmetic over the field of the Ed25519 curve, with elements represented as Uint256.
+from starkware.cairo.common.uint256 import Uint256, uint256_add, uint256_lt, uint256_mul
+
+from cairo_programs.uint512 import Uint512, u512_unsigned_div_rem
+
+// p = 2**255 - 19
+const P_LOW = 340282366920938463463374607431768211437;
+const P_HIGH = 170141183460469231731687303715884105727;
+
+namespace fq {
+    // Reduces a 512-bit integer modulo p.
+    func reduce{range_check_ptr}(x: Uint512) -> (res: Uint256) {
+        let (quotient: Uint512, res: Uint256) = u512_unsigned_div_rem(x, Uint256(P_LOW, P_HIGH));
+        return (res=res);
+    }
+
+    // Checks that res == a - b (mod p), with res reduced.
+    func _verify_sub{range_check_ptr}(a: Uint256, b: Uint256, res: Uint256) {
+        alloc_locals;
+        let (sum: Uint256, carry: felt) = uint256_add(res, b);
+        let (local sum_mod_p: Uint256) = reduce(Uint512(sum.low, sum.high, carry, 0));
+        let (a_mod_p: Uint256) = reduce(Uint512(a.low, a.high, 0, 0));
+        assert sum_mod_p = a_mod_p;
+        let (is_valid) = uint256_lt(res, Uint256(P_LOW, P_HIGH));
+        assert is_valid = 1;
+        return ();
+    }
+
+    // Computes a - b (mod p).
+    func sub{range_check_ptr}(a: Uint256, b: Uint256) -> (res: Uint256) {
+        alloc_locals;
+        local p: Uint256 = Uint256(P_LOW, P_HIGH);
+        local res: Uint256;
+
+        %{
+            def split(num: int, num_bits_shift: int = 128, length: int = 2):
+                a = []
+                for _ in range(length):
+                    a.append( num & ((1 << num_bits_shift) - 1) )
+                    num = num >> num_bits_shift
+                return tuple(a)
+
+            def pack(z, num_bits_shift: int = 128) -> int:
+                limbs = (z.low, z.high)
+                return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+            a = pack(ids.a)
+            b = pack(ids.b)
+            p = pack(ids.p)
+
+            res = (a - b) % p
+
+            res_split = split(res)
+            ids.res.low = res_split[0]
+            ids.res.high = res_split[1]
+        %}
+
+        _verify_sub(a, b, res);
+        return (res=res);
+    }
+
+    // Computes a - b (mod p), reducing both operands first.
+    func sub_reduced{range_check_ptr}(a: Uint256, b: Uint256) -> (res: Uint256) {
+        alloc_locals;
+        local p: Uint256 = Uint256(P_LOW, P_HIGH);
+        local res: Uint256;
+
+        %{
+            def split(num: int, num_bits_shift: int = 128, length: int = 2):
+                a = []
+                for _ in range(length):
+                    a.append( num & ((1 << num_bits_shift) - 1) )
+                    num = num >> num_bits_shift
+                return tuple(a)
+
+            def pack(z, num_bits_shift: int = 128) -> int:
+                limbs = (z.low, z.high)
+                return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+            p = pack(ids.p)
+            a = pack(ids.a) % p
+            b = pack(ids.b) % p
+
+            res = (a - b) % p
+
+            res_split = split(res)
+            ids.res.low = res_split[0]
+            ids.res.high = res_split[1]
+        %}
+
+        _verify_sub(a, b, res);
+        return (res=res);
+    }
+
+    // Computes the inverse of b modulo p.
+    func inv_mod_p{range_check_ptr}(b: Uint256) -> (res: Uint256) {
+        alloc_locals;
+        local a: Uint256 = Uint256(1, 0);
+        local p: Uint256 = Uint256(P_LOW, P_HIGH);
+        local b_inverse_mod_p: Uint256;
+
+        %{
+            from starkware.python.math_utils import div_mod
+
+            def split(a: int):
+                return (a & ((1 << 128) - 1), a >> 128)
+
+            def pack(z, num_bits_shift: int) -> int:
+                limbs = (z.low, z.high)
+                return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+            a = pack(ids.a, 128)
+            b = pack(ids.b, 128)
+            p = pack(ids.p, 128)
+            # For python3.8 and above the modular inverse can be computed as follows:
+            # b_inverse_mod_p = pow(b, -1, p)
+            # Instead we use the python3.7-friendly function div_mod from starkware.python.math_utils
+            b_inverse_mod_p = div_mod(1, b, p)
+
+            b_inverse_mod_p_split = split(b_inverse_mod_p)
+
+            ids.b_inverse_mod_p.low = b_inverse_mod_p_split[0]
+            ids.b_inverse_mod_p.high = b_inverse_mod_p_split[1]
+        %}
+
+        // Check that b * b_inverse_mod_p == a (mod p).
+        let (low: Uint256, high: Uint256) = uint256_mul(b, b_inverse_mod_p);
+        let (res: Uint256) = reduce(Uint512(low.low, low.high, high.low, high.high));
+        assert res = a;
+        let (is_valid) = uint256_lt(b_inverse_mod_p, p);
+        assert is_valid = 1;
+
+        return (res=b_inverse_mod_p);
+    }
+}
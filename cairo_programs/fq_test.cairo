@@ -0,0 +1,41 @@
+%builtins range_check
+
+from starkware.cairo.common.uint256 import Uint256
+
+from cairo_programs.fq import fq
+
+func test_sub{range_check_ptr}() {
+    let a = Uint256(5, 7);
+    let b = Uint256(3, 9);
+
+    // a - b is negative, so the result wraps around p
+    let (res) = fq.sub(a, b);
+    assert res = Uint256(
+        340282366920938463463374607431768211439, 170141183460469231731687303715884105725
+    );
+
+    // a = p + 10 isn't reduced
+    let a = Uint256(340282366920938463463374607431768211447, 170141183460469231731687303715884105727);
+    let (res) = fq.sub_reduced(a, Uint256(3, 0));
+    assert res = Uint256(7, 0);
+
+    return ();
+}
+
+func test_inv_mod_p{range_check_ptr}() {
+    let b = Uint256(3, 9);
+
+    let (res) = fq.inv_mod_p(b);
+    assert res = Uint256(
+        211221215048676367838947092589611744141, 160328544336160644564366139571957260920
+    );
+
+    return ();
+}
+
+func main{range_check_ptr}() {
+    test_sub();
+    test_inv_mod_p();
+
+    return ();
+}
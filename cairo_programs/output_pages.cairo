@@ -0,0 +1,49 @@
+%builtins output
+
+from starkware.cairo.common.serialize import serialize_word
+
+func main{output_ptr: felt*}() {
+    alloc_locals;
+    // Main part of the output
+    serialize_word(1);
+    serialize_word(2);
+
+    // Onchain data part, which is split into its own pages
+    local da_start: felt* = output_ptr;
+    serialize_word(3);
+    serialize_word(4);
+    serialize_word(5);
+
+    %{
+        from starkware.python.math_utils import div_ceil
+        onchain_data_start = ids.da_start
+        onchain_data_size = ids.output_ptr - onchain_data_start
+
+        max_page_size = 3800
+        n_pages = div_ceil(onchain_data_size, max_page_size)
+        for i in range(n_pages):
+            start_offset = i * max_page_size
+            output_builtin.add_page(
+                page_id=1 + i,
+                page_start=onchain_data_start + start_offset,
+                page_size=min(onchain_data_size - start_offset, max_page_size),
+            )
+        # Set the tree structure to a root with two children:
+        # * A leaf which represents the main part
+        # * An inner node for the onchain data part (which contains n_pages children).
+        #
+        # This is encoded using the following sequence:
+        output_builtin.add_attribute('gps_fact_topology', [
+            # Push 1 + n_pages pages (all of the pages).
+            1 + n_pages,
+            # Create a parent node for the last n_pages.
+            n_pages,
+            # Don't push additional pages.
+            0,
+            # Take the first page (the main part) and the node that was created (onchain data)
+            # and use them to construct the root of the fact tree.
+            2,
+        ])
+    %}
+    return ();
+}
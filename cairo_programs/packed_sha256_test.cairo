@@ -0,0 +1,31 @@
+%builtins range_check bitwise
+
+from starkware.cairo.common.alloc import alloc
+from starkware.cairo.common.cairo_builtins import BitwiseBuiltin
+from cairo_programs.packed_sha256 import sha256, finalize_sha256
+
+func main{range_check_ptr, bitwise_ptr: BitwiseBuiltin*}() {
+    alloc_locals;
+
+    let (local sha256_ptr_start: felt*) = alloc();
+    let sha256_ptr = sha256_ptr_start;
+
+    // sha256('Hello world')
+    let (input: felt*) = alloc();
+    assert input[0] = 1214606444;
+    assert input[1] = 1864398703;
+    assert input[2] = 1919706112;
+    let (output) = sha256{sha256_ptr=sha256_ptr}(input, 11);
+
+    assert output[0] = 0x64EC88CA;
+    assert output[1] = 0x00B268E5;
+    assert output[2] = 0xBA1A3567;
+    assert output[3] = 0x8A1B5316;
+    assert output[4] = 0xD212F4F3;
+    assert output[5] = 0x66B24772;
+    assert output[6] = 0x32534A8A;
+    assert output[7] = 0xECA37F3C;
+
+    finalize_sha256(sha256_ptr_start=sha256_ptr_start, sha256_ptr_end=sha256_ptr);
+    return ();
+}
@@ -0,0 +1,55 @@
+%builtins output range_check
+
+from starkware.cairo.common.alloc import alloc
+from starkware.cairo.common.default_dict import default_dict_new
+from starkware.cairo.common.dict import dict_write
+from starkware.cairo.common.serialize import serialize_word
+
+func print_felt() {
+    let x = 1234;
+    %{ print(ids.x) %}
+    return ();
+}
+
+func print_array() {
+    alloc_locals;
+    let name = 'ARRAY';
+    let (local arr: felt*) = alloc();
+    assert arr[0] = 1;
+    assert arr[1] = 2;
+    assert arr[2] = 3;
+    let arr_len = 3;
+    %{
+        print(bytes.fromhex(f"{ids.name:062x}").decode().replace('\x00',''))
+        arr = [memory[ids.arr + i] for i in range(ids.arr_len)]
+        print(arr)
+    %}
+    return ();
+}
+
+func print_dict{range_check_ptr}() {
+    alloc_locals;
+    let name = 'DICT';
+    let (local dict_ptr) = default_dict_new(default_value=0);
+    dict_write{dict_ptr=dict_ptr}(key=1, new_value=2);
+    dict_write{dict_ptr=dict_ptr}(key=3, new_value=4);
+    let pointer_size = 1;
+    %{
+        print(bytes.fromhex(f"{ids.name:062x}").decode().replace('\x00',''))
+        data = __dict_manager.get_dict(ids.dict_ptr)
+        print(
+            {k: v if isinstance(v, int) else [memory[v + i] for i in range(ids.pointer_size)] for k, v in data.items()}
+        )
+    %}
+    return ();
+}
+
+func main{output_ptr: felt*, range_check_ptr}() {
+    serialize_word(1234);
+
+    print_felt();
+    print_array();
+    print_dict();
+
+    return ();
+}
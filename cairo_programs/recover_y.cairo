@@ -0,0 +1,13 @@
+%builtins output
+
+from starkware.cairo.common.ec import recover_y
+from starkware.cairo.common.serialize import serialize_word
+
+func main{output_ptr: felt*}() {
+    // The x coordinate of the stark curve's generator
+    let x = 0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca;
+    let (p) = recover_y(x);
+    assert p.y = 0x5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f;
+    serialize_word(p.y);
+    return ();
+}
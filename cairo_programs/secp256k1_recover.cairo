@@ -0,0 +1,21 @@
+%builtins range_check
+
+from starkware.cairo.common.cairo_secp.bigint import BigInt3
+from starkware.cairo.common.cairo_secp.ec import EcPoint
+from starkware.cairo.common.cairo_secp.signature import recover_public_key
+
+func main{range_check_ptr: felt}() {
+    let msg_hash = BigInt3(
+        26478143432297498221047423, 49477146014575543527853843, 16972760103949900119572882
+    );
+    let r = BigInt3(8328202403972024966912303, 13336153345300640203574794, 291375123026168705037016);
+    let s = BigInt3(963221645073479033002803, 14208656096592890716538381, 15111442971317933648413899);
+
+    let (public_key_point) = recover_public_key(msg_hash=msg_hash, r=r, s=s, v=1);
+    assert public_key_point = EcPoint(
+        BigInt3(41288890227799141013914125, 1919632947027687321481310, 14153193884912501855239432),
+        BigInt3(15991641010428556456595188, 17864715565592799095915125, 17897104229765482815047889),
+    );
+
+    return ();
+}
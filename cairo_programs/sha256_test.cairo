@@ -0,0 +1,54 @@
+%builtins range_check bitwise
+
+from starkware.cairo.common.alloc import alloc
+from starkware.cairo.common.cairo_builtins import BitwiseBuiltin
+from cairo_programs.sha256 import sha256, finalize_sha256
+
+func fill_input(input: felt*, n: felt, value: felt) {
+    if (n == 0) {
+        return ();
+    }
+    assert input[0] = value;
+    return fill_input(input + 1, n - 1, value);
+}
+
+func main{range_check_ptr, bitwise_ptr: BitwiseBuiltin*}() {
+    alloc_locals;
+
+    let (local sha256_ptr_start: felt*) = alloc();
+    let sha256_ptr = sha256_ptr_start;
+
+    // sha256('Hello world')
+    let (hello_world: felt*) = alloc();
+    assert hello_world[0] = 1214606444;
+    assert hello_world[1] = 1864398703;
+    assert hello_world[2] = 1919706112;
+    let (output) = sha256{sha256_ptr=sha256_ptr}(hello_world, 11);
+
+    assert output[0] = 0x64EC88CA;
+    assert output[1] = 0x00B268E5;
+    assert output[2] = 0xBA1A3567;
+    assert output[3] = 0x8A1B5316;
+    assert output[4] = 0xD212F4F3;
+    assert output[5] = 0x66B24772;
+    assert output[6] = 0x32534A8A;
+    assert output[7] = 0xECA37F3C;
+
+    // sha256('a' * 70), which spans two message blocks
+    let (long_input: felt*) = alloc();
+    fill_input(long_input, 17, 0x61616161);
+    assert long_input[17] = 0x61610000;
+    let (output) = sha256{sha256_ptr=sha256_ptr}(long_input, 70);
+
+    assert output[0] = 0x6BD5E503;
+    assert output[1] = 0x4855A112;
+    assert output[2] = 0x41F0DEE8;
+    assert output[3] = 0xFC72850F;
+    assert output[4] = 0xFD9955B2;
+    assert output[5] = 0x8347A864;
+    assert output[6] = 0x28B5FA19;
+    assert output[7] = 0x119F6AD0;
+
+    finalize_sha256(sha256_ptr_start=sha256_ptr_start, sha256_ptr_end=sha256_ptr);
+    return ();
+}
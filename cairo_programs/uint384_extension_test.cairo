@@ -0,0 +1,44 @@
+%builtins range_check
+
+from cairo_programs.uint384 import Uint384
+from cairo_programs.uint384_extension import u384_ext, Uint768
+
+func test_uint384_extension_operations{range_check_ptr}() {
+    let a = Uint768(1, 2, 3, 4, 5, 6);
+    let div = Uint384(7, 8, 9);
+    let expected_quotient = Uint768(
+        162439319188596138937248783794588940448,
+        214251860653924217736198826901483688694,
+        226854911280625642308916404954512140970,
+        0,
+        0,
+        0,
+    );
+    let expected_remainder = Uint384(
+        224054233363580881292756943164950262689, 263263724202207535518989408218816558660, 4
+    );
+
+    // Test unsigned_div_rem_uint768_by_uint384
+    let (quotient, remainder) = u384_ext.unsigned_div_rem_uint768_by_uint384(a, div);
+    assert quotient = expected_quotient;
+    assert remainder = expected_remainder;
+
+    // Test unsigned_div_rem_uint768_by_uint384_alt
+    let (quotient_alt, remainder_alt) = u384_ext.unsigned_div_rem_uint768_by_uint384_alt(a, div);
+    assert quotient_alt = expected_quotient;
+    assert remainder_alt = expected_remainder;
+
+    // Division by zero yields zero
+    let (quotient_zero, remainder_zero) = u384_ext.unsigned_div_rem_uint768_by_uint384(
+        a, Uint384(0, 0, 0)
+    );
+    assert quotient_zero = Uint768(0, 0, 0, 0, 0, 0);
+    assert remainder_zero = Uint384(0, 0, 0);
+
+    return ();
+}
+
+func main{range_check_ptr}() {
+    test_uint384_extension_operations();
+    return ();
+}
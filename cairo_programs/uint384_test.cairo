@@ -0,0 +1,47 @@
+%builtins range_check
+
+from cairo_programs.uint384 import u384, Uint384
+
+func test_uint384_operations{range_check_ptr}() {
+    // Test unsigned_div_rem
+    let a = Uint384(83434123481193248, 82349321849739284, 839243219401320423);
+    let div = Uint384(9283430921839492319493, 313248123482483248, 3790328402913840);
+    let (quotient: Uint384, remainder: Uint384) = u384.unsigned_div_rem{
+        range_check_ptr=range_check_ptr
+    }(a, div);
+
+    assert quotient.d0 = 221;
+    assert quotient.d1 = 0;
+    assert quotient.d2 = 0;
+
+    assert remainder.d0 = 340282366920936411825224315027446796751;
+    assert remainder.d1 = 340282366920938463394229121463989152931;
+    assert remainder.d2 = 1580642357361782;
+
+    // Test split_128
+    let (low, high) = u384.split_128(2 ** 160 + 7);
+    assert low = 7;
+    assert high = 2 ** 32;
+
+    // Test add (uses the add_no_uint384_check hint)
+    let (res, carry) = u384.add(Uint384(2 ** 128 - 1, 2 ** 128 - 1, 1), Uint384(1, 0, 0));
+    assert res = Uint384(0, 0, 2);
+    assert carry = 0;
+
+    // Test signed_nn
+    let (positive) = u384.signed_nn(Uint384(0, 0, 5));
+    assert positive = 1;
+    let (negative) = u384.signed_nn(Uint384(0, 0, 2 ** 127));
+    assert negative = 0;
+
+    // Test sqrt
+    let (root) = u384.sqrt(Uint384(83434123481193248, 82349321849739284, 839243219401320423));
+    assert root = Uint384(100835122758113432298839930225328621183, 916102188, 0);
+
+    return ();
+}
+
+func main{range_check_ptr}() {
+    test_uint384_operations();
+    return ();
+}
@@ -0,0 +1,111 @@
+from starkware.cairo.common.uint256 import Uint256, uint256_add, uint256_lt, uint256_mul
+
+// Represents an integer in the range [0, 2^512).
+// As in Uint256, all functions expect each limb to be less than 2**128.
+struct Uint512 {
+    d0: felt,
+    d1: felt,
+    d2: felt,
+    d3: felt,
+}
+
+// Unsigned integer division between a 512-bit integer and a 256-bit integer.
+// Returns the quotient (512 bits) and the remainder (256 bits).
+func u512_unsigned_div_rem{range_check_ptr}(x: Uint512, div: Uint256) -> (
+    quotient: Uint512, remainder: Uint256
+) {
+    alloc_locals;
+    local quotient: Uint512;
+    local remainder: Uint256;
+
+    %{
+        def split(num: int, num_bits_shift: int, length: int):
+            a = []
+            for _ in range(length):
+                a.append( num & ((1 << num_bits_shift) - 1) )
+                num = num >> num_bits_shift
+            return tuple(a)
+
+        def pack(z, num_bits_shift: int) -> int:
+            limbs = (z.low, z.high)
+            return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+        def pack_extended(z, num_bits_shift: int) -> int:
+            limbs = (z.d0, z.d1, z.d2, z.d3)
+            return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+        x = pack_extended(ids.x, num_bits_shift = 128)
+        div = pack(ids.div, num_bits_shift = 128)
+
+        quotient, remainder = divmod(x, div)
+
+        quotient_split = split(quotient, num_bits_shift=128, length=4)
+
+        ids.quotient.d0 = quotient_split[0]
+        ids.quotient.d1 = quotient_split[1]
+        ids.quotient.d2 = quotient_split[2]
+        ids.quotient.d3 = quotient_split[3]
+
+        remainder_split = split(remainder, num_bits_shift=128, length=2)
+        ids.remainder.low = remainder_split[0]
+        ids.remainder.high = remainder_split[1]
+    %}
+
+    // Check that quotient * div + remainder == x.
+    let (local res_low_low: Uint256, local res_low_high: Uint256) = uint256_mul(
+        Uint256(quotient.d0, quotient.d1), div
+    );
+    let (local res_high_low: Uint256, res_high_high: Uint256) = uint256_mul(
+        Uint256(quotient.d2, quotient.d3), div
+    );
+    assert res_high_high = Uint256(0, 0);
+
+    let (local low: Uint256, local carry0: felt) = uint256_add(res_low_low, remainder);
+    let (high_partial: Uint256, carry1: felt) = uint256_add(res_low_high, res_high_low);
+    assert carry1 = 0;
+    let (local high: Uint256, carry2: felt) = uint256_add(high_partial, Uint256(carry0, 0));
+    assert carry2 = 0;
+
+    assert low = Uint256(x.d0, x.d1);
+    assert high = Uint256(x.d2, x.d3);
+
+    // Check that remainder < div.
+    let (is_valid) = uint256_lt(remainder, div);
+    assert is_valid = 1;
+
+    return (quotient=quotient, remainder=remainder);
+}
+
+// Computes the inverse of a 512-bit integer modulo a 256-bit integer p.
+func inv_mod_p_uint512{range_check_ptr}(x: Uint512, p: Uint256) -> (x_inverse_mod_p: Uint256) {
+    alloc_locals;
+    local x_inverse_mod_p: Uint256;
+
+    %{
+        def pack_512(u, num_bits_shift: int) -> int:
+            limbs = (u.d0, u.d1, u.d2, u.d3)
+            return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+        x = pack_512(ids.x, num_bits_shift = 128)
+        p = ids.p.low + (ids.p.high << 128)
+        x_inverse_mod_p = pow(x,-1, p)
+
+        x_inverse_mod_p_split = (x_inverse_mod_p & ((1 << 128) - 1), x_inverse_mod_p >> 128)
+
+        ids.x_inverse_mod_p.low = x_inverse_mod_p_split[0]
+        ids.x_inverse_mod_p.high = x_inverse_mod_p_split[1]
+    %}
+
+    // Check that (x mod p) * x_inverse_mod_p == 1 (mod p).
+    let (x_div_p: Uint512, x_mod_p: Uint256) = u512_unsigned_div_rem(x, p);
+    let (low: Uint256, high: Uint256) = uint256_mul(x_mod_p, x_inverse_mod_p);
+    let (product_div_p: Uint512, product_mod_p: Uint256) = u512_unsigned_div_rem(
+        Uint512(low.low, low.high, high.low, high.high), p
+    );
+    assert product_mod_p = Uint256(1, 0);
+
+    let (is_valid) = uint256_lt(x_inverse_mod_p, p);
+    assert is_valid = 1;
+
+    return (x_inverse_mod_p=x_inverse_mod_p);
+}
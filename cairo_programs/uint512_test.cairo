@@ -0,0 +1,39 @@
+%builtins range_check
+
+from starkware.cairo.common.uint256 import Uint256
+
+from cairo_programs.uint512 import Uint512, u512_unsigned_div_rem, inv_mod_p_uint512
+
+func test_u512_unsigned_div_rem{range_check_ptr}() {
+    // x = 2**500 + 12345, div = 3 * 2**128 + 7
+    let x = Uint512(12345, 0, 0, 83076749736557242056487941267521536);
+    let div = Uint256(7, 3);
+
+    let (quotient, remainder) = u512_unsigned_div_rem(x, div);
+    assert quotient = Uint512(
+        75769072676397114283000576063433993481,
+        113362840390517721077303156300714664846,
+        27692249912185747352162647089173845,
+        0,
+    );
+    assert remainder = Uint256(150181225107097126945745182419498480890, 1);
+
+    return ();
+}
+
+func test_inv_mod_p_uint512{range_check_ptr}() {
+    let x = Uint512(12345, 0, 0, 83076749736557242056487941267521536);
+    let p = Uint256(7, 3);
+
+    let (x_inverse_mod_p) = inv_mod_p_uint512(x, p);
+    assert x_inverse_mod_p = Uint256(307197291750364332717876841593780677019, 2);
+
+    return ();
+}
+
+func main{range_check_ptr}() {
+    test_u512_unsigned_div_rem();
+    test_inv_mod_p_uint512();
+
+    return ();
+}
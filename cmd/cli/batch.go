@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/cairo_pie"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// Programs run by the batch command, read from a json manifest
+type batchManifest struct {
+	Programs []batchProgram `json:"programs"`
+}
+
+// A program of the batch. Paths are relative to the manifest's directory, and the unset fields default to the flags
+type batchProgram struct {
+	// Name of the program's output files. Default: the program's file name, without the .json extension
+	Name    string `json:"name"`
+	Program string `json:"program"`
+	Layout  string `json:"layout"`
+	// Nil if not set in the manifest
+	ProofMode *bool `json:"proof_mode"`
+	// Json input of the program, such as the simple bootloader's tasks
+	ProgramInput string `json:"program_input"`
+}
+
+// Summary of the run of a program of the batch
+type batchResult struct {
+	Name    string `json:"name"`
+	Program string `json:"program"`
+	// "ok" or "failed"
+	Status string `json:"status"`
+	Steps  uint   `json:"steps"`
+	// Nil if the run failed
+	ExecutionResources *cairo_pie.ExecutionResources `json:"execution_resources,omitempty"`
+	DurationMs         int64                         `json:"duration_ms"`
+	Error              string                        `json:"error,omitempty"`
+}
+
+type batchSummary struct {
+	Total   int           `json:"total"`
+	Failed  int           `json:"failed"`
+	Results []batchResult `json:"results"`
+}
+
+func batchCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "batch",
+		Usage:     "Run many programs in parallel, from a json manifest or a directory of compiled programs",
+		ArgsUsage: "<MANIFEST_FILE | PROGRAMS_DIRECTORY>",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:    "output_dir",
+				Aliases: []string{"o"},
+				Usage:   "Directory the traces, memories, outputs and the summary.json of the runs are written to. Default: batch_output",
+			},
+			&cli.IntFlag{
+				Name:  "workers",
+				Usage: "Amount of programs run at the same time. Default: the number of CPUs",
+			},
+			&cli.StringFlag{
+				Name:    "layout",
+				Aliases: []string{"l"},
+				Usage:   "Layout of the programs that don't set one. Default: plain",
+			},
+			&cli.BoolFlag{
+				Name:    "proof_mode",
+				Aliases: []string{"p"},
+				Usage:   "Run the programs that don't set proof_mode in proof mode",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Stop each run if it takes longer than the given duration, such as 30s. Default: no timeout",
+			},
+			&cli.BoolFlag{
+				Name:  "skip_unknown_hints",
+				Usage: "Skip unsupported hints with a warning instead of failing. Only safe for hints that don't affect the execution, such as prints",
+			},
+			&cli.BoolFlag{
+				Name:  "python_hints",
+				Usage: "Interpret the hints the VM doesn't implement as python code. Only a restricted subset of python is supported",
+			},
+		}, runLimitFlags()...),
+		Action: handleBatch,
+	}
+}
+
+func handleBatch(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if path == "" {
+		return errors.New("Missing manifest file or programs directory")
+	}
+	layout := ctx.String("layout")
+	if layout == "" {
+		layout = "plain"
+	}
+	programs, err := loadBatchPrograms(path, layout, ctx.Bool("proof_mode"))
+	if err != nil {
+		return err
+	}
+	runLimits, err := parseRunLimits(ctx)
+	if err != nil {
+		return err
+	}
+	outputDir := ctx.String("output_dir")
+	if outputDir == "" {
+		outputDir = "batch_output"
+	}
+	err = os.MkdirAll(outputDir, 0755)
+	if err != nil {
+		return err
+	}
+	workers := ctx.Int("workers")
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	config := cairo_run.CairoRunConfig{
+		SkipUnknownHints:     ctx.Bool("skip_unknown_hints"),
+		InterpretPythonHints: ctx.Bool("python_hints"),
+		RunLimits:            runLimits,
+	}
+	results := runBatch(programs, config, ctx.Duration("timeout"), outputDir, workers)
+
+	summary := batchSummary{Total: len(results), Results: results}
+	for _, result := range results {
+		if result.Status != "ok" {
+			summary.Failed++
+		}
+	}
+	summaryJson, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(outputDir, "summary.json"), summaryJson, 0644)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(summaryJson))
+	if summary.Failed != 0 {
+		return cli.Exit(fmt.Sprintf("%d of %d programs failed", summary.Failed, summary.Total), 1)
+	}
+	return nil
+}
+
+// Reads the programs of the batch from a manifest file, or lists the compiled programs of a directory.
+// In a directory, every .json file is a program except the .input.json files, which are the input of the program
+// with the same name (fibonacci.input.json for fibonacci.json)
+func loadBatchPrograms(path string, layout string, proofMode bool) ([]batchProgram, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var programs []batchProgram
+	if info.IsDir() {
+		programPaths, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(programPaths)
+		for _, programPath := range programPaths {
+			if strings.HasSuffix(programPath, ".input.json") {
+				continue
+			}
+			program := batchProgram{Program: programPath}
+			inputPath := strings.TrimSuffix(programPath, ".json") + ".input.json"
+			if _, err := os.Stat(inputPath); err == nil {
+				program.ProgramInput = inputPath
+			}
+			programs = append(programs, program)
+		}
+	} else {
+		manifestJson, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var manifest batchManifest
+		err = json.Unmarshal(manifestJson, &manifest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid batch manifest %s", path)
+		}
+		manifestDir := filepath.Dir(path)
+		for _, program := range manifest.Programs {
+			if program.Program == "" {
+				return nil, errors.Errorf("Invalid batch manifest %s: program without a path", path)
+			}
+			program.Program = filepath.Join(manifestDir, program.Program)
+			if program.ProgramInput != "" {
+				program.ProgramInput = filepath.Join(manifestDir, program.ProgramInput)
+			}
+			programs = append(programs, program)
+		}
+	}
+
+	names := make(map[string]bool, len(programs))
+	for i := range programs {
+		if programs[i].Name == "" {
+			programs[i].Name = strings.TrimSuffix(filepath.Base(programs[i].Program), ".json")
+		}
+		if !isValidBatchName(programs[i].Name) {
+			return nil, errors.Errorf("Invalid program name %q: names must be file names, without path separators", programs[i].Name)
+		}
+		if programs[i].Layout == "" {
+			programs[i].Layout = layout
+		}
+		if programs[i].ProofMode == nil {
+			programs[i].ProofMode = &proofMode
+		}
+		if names[programs[i].Name] {
+			return nil, errors.Errorf("Two programs of the batch are named %s, their output files would collide", programs[i].Name)
+		}
+		names[programs[i].Name] = true
+	}
+	return programs, nil
+}
+
+// Whether the output files of a program with the given name stay in the output directory
+func isValidBatchName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// Runs the programs with a pool of workers, returning their results in the same order
+func runBatch(programs []batchProgram, config cairo_run.CairoRunConfig, timeout time.Duration, outputDir string, workers int) []batchResult {
+	results := make([]batchResult, len(programs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = runBatchProgram(programs[i], config, timeout, outputDir)
+			}
+		}()
+	}
+	for i := range programs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+func runBatchProgram(program batchProgram, config cairo_run.CairoRunConfig, timeout time.Duration, outputDir string) batchResult {
+	result := batchResult{Name: program.Name, Program: program.Program, Status: "failed"}
+	start := time.Now()
+	err := func() error {
+		config.Layout = program.Layout
+		config.ProofMode = *program.ProofMode
+		config.SecureRun = !config.ProofMode
+		if program.ProgramInput != "" {
+			programInput, err := os.ReadFile(program.ProgramInput)
+			if err != nil {
+				return err
+			}
+			config.ProgramInput = programInput
+		}
+		runContext := context.Background()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			runContext, cancel = context.WithTimeout(runContext, timeout)
+			defer cancel()
+		}
+		cairoRunner, err := runRecoveringPanics(func() (*runners.CairoRunner, error) {
+			return cairo_run.CairoRunWithContext(runContext, program.Program, config)
+		})
+		if err != nil {
+			return err
+		}
+		result.Steps = cairoRunner.Vm.CurrentStep
+		executionResources, err := cairoRunner.GetExecutionResources()
+		if err != nil {
+			return err
+		}
+		result.ExecutionResources = &cairo_pie.ExecutionResources{
+			NSteps:                 executionResources.NSteps,
+			NMemoryHoles:           executionResources.NMemoryHoles,
+			BuiltinInstanceCounter: make(map[string]uint, len(executionResources.BuiltinsInstanceCounter)),
+		}
+		for name, instances := range executionResources.BuiltinsInstanceCounter {
+			result.ExecutionResources.BuiltinInstanceCounter[name+"_builtin"] = instances
+		}
+		return writeBatchOutputs(cairoRunner, filepath.Join(outputDir, program.Name))
+	}()
+	result.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Status = "ok"
+	}
+	return result
+}
+
+// Runs a program, turning the panics of unknown layouts into errors so that they don't stop the other runs
+func runRecoveringPanics(run func() (*runners.CairoRunner, error)) (cairoRunner *runners.CairoRunner, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errors.Errorf("%v", recovered)
+		}
+	}()
+	return run()
+}
+
+// Writes the trace, memory and output of the run, to the files with the given path and the .trace, .memory and
+// .output extensions
+func writeBatchOutputs(cairoRunner *runners.CairoRunner, path string) error {
+	var trace bytes.Buffer
+	err := cairo_run.WriteEncodedTrace(cairoRunner.Vm.RelocatedTrace, &trace)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path+".trace", trace.Bytes(), 0644)
+	if err != nil {
+		return err
+	}
+	var memory bytes.Buffer
+	err = cairo_run.WriteEncodedMemory(cairoRunner.Vm.RelocatedMemory, &memory)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path+".memory", memory.Bytes(), 0644)
+	if err != nil {
+		return err
+	}
+	var output bytes.Buffer
+	cairoRunner.Vm.WriteOutput(&output)
+	return os.WriteFile(path+".output", output.Bytes(), 0644)
+}
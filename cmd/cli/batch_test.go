@@ -0,0 +1,155 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
+)
+
+func writeTestFile(t *testing.T, path string, content string) {
+	err := os.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("WriteFile failed with error: %s", err)
+	}
+}
+
+func TestLoadBatchProgramsDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "b.json"), outputProgramJson)
+	writeTestFile(t, filepath.Join(dir, "a.json"), outputProgramJson)
+	writeTestFile(t, filepath.Join(dir, "a.input.json"), `{}`)
+	writeTestFile(t, filepath.Join(dir, "notes.txt"), "")
+
+	programs, err := loadBatchPrograms(dir, "small", true)
+	if err != nil {
+		t.Fatalf("loadBatchPrograms failed with error: %s", err)
+	}
+	proofMode := true
+	expected := []batchProgram{
+		{Name: "a", Program: filepath.Join(dir, "a.json"), Layout: "small", ProofMode: &proofMode, ProgramInput: filepath.Join(dir, "a.input.json")},
+		{Name: "b", Program: filepath.Join(dir, "b.json"), Layout: "small", ProofMode: &proofMode},
+	}
+	if !reflect.DeepEqual(programs, expected) {
+		t.Errorf("Expected programs %+v, got %+v", expected, programs)
+	}
+}
+
+func TestLoadBatchProgramsManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "manifest.json")
+	writeTestFile(t, manifestPath, `{"programs": [
+		{"program": "programs/fibonacci.json", "program_input": "inputs/fibonacci.json"},
+		{"name": "fibonacci..proof", "program": "programs/fibonacci.json", "layout": "small", "proof_mode": true}
+	]}`)
+
+	programs, err := loadBatchPrograms(manifestPath, "plain", false)
+	if err != nil {
+		t.Fatalf("loadBatchPrograms failed with error: %s", err)
+	}
+	proofMode := false
+	manifestProofMode := true
+	expected := []batchProgram{
+		{
+			Name:         "fibonacci",
+			Program:      filepath.Join(dir, "programs", "fibonacci.json"),
+			Layout:       "plain",
+			ProofMode:    &proofMode,
+			ProgramInput: filepath.Join(dir, "inputs", "fibonacci.json"),
+		},
+		{Name: "fibonacci..proof", Program: filepath.Join(dir, "programs", "fibonacci.json"), Layout: "small", ProofMode: &manifestProofMode},
+	}
+	if !reflect.DeepEqual(programs, expected) {
+		t.Errorf("Expected programs %+v, got %+v", expected, programs)
+	}
+}
+
+func TestLoadBatchProgramsInvalidManifest(t *testing.T) {
+	tests := map[string]string{
+		"invalid json":       `{"programs": [`,
+		"missing program":    `{"programs": [{"name": "fibonacci"}]}`,
+		"dot name":           `{"programs": [{"name": ".", "program": "fibonacci.json"}]}`,
+		"dot dot name":       `{"programs": [{"name": "..", "program": "fibonacci.json"}]}`,
+		"parent directory":   `{"programs": [{"name": "../fibonacci", "program": "fibonacci.json"}]}`,
+		"subdirectory":       `{"programs": [{"name": "a/fibonacci", "program": "fibonacci.json"}]}`,
+		"backslash":          `{"programs": [{"name": "a\\fibonacci", "program": "fibonacci.json"}]}`,
+		"program named ..":   `{"programs": [{"program": "..json"}]}`,
+		"duplicate names":    `{"programs": [{"program": "fibonacci.json"}, {"program": "other/fibonacci.json"}]}`,
+		"duplicate set name": `{"programs": [{"program": "fibonacci.json"}, {"name": "fibonacci", "program": "other.json"}]}`,
+	}
+	for name, manifest := range tests {
+		t.Run(name, func(t *testing.T) {
+			manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+			writeTestFile(t, manifestPath, manifest)
+			_, err := loadBatchPrograms(manifestPath, "plain", false)
+			if err == nil {
+				t.Errorf("Expected loadBatchPrograms to fail")
+			}
+		})
+	}
+}
+
+func TestIsValidBatchName(t *testing.T) {
+	valid := []string{"fibonacci", "a..b", "..a", "a..", ".hidden"}
+	for _, name := range valid {
+		if !isValidBatchName(name) {
+			t.Errorf("Expected %q to be a valid name", name)
+		}
+	}
+	invalid := []string{"", ".", "..", "a/b", "../a", `a\b`, "/a"}
+	for _, name := range invalid {
+		if isValidBatchName(name) {
+			t.Errorf("Expected %q to be an invalid name", name)
+		}
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "output.json"), outputProgramJson)
+	outputDir := filepath.Join(dir, "batch_output")
+	err := os.Mkdir(outputDir, 0755)
+	if err != nil {
+		t.Fatalf("Mkdir failed with error: %s", err)
+	}
+	proofMode := false
+	programs := []batchProgram{
+		{Name: "missing", Program: filepath.Join(dir, "missing.json"), Layout: "small", ProofMode: &proofMode},
+		{Name: "output", Program: filepath.Join(dir, "output.json"), Layout: "small", ProofMode: &proofMode},
+		{Name: "unknown_layout", Program: filepath.Join(dir, "output.json"), Layout: "unknown", ProofMode: &proofMode},
+	}
+
+	results := runBatch(programs, cairo_run.CairoRunConfig{}, 0, outputDir, 2)
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for i, result := range results {
+		if result.Name != programs[i].Name {
+			t.Errorf("Expected result %d to be %s, got %s", i, programs[i].Name, result.Name)
+		}
+	}
+	if results[0].Status != "failed" || results[0].Error == "" || results[2].Status != "failed" || results[2].Error == "" {
+		t.Errorf("Expected the missing program and the unknown layout to fail, got %+v and %+v", results[0], results[2])
+	}
+	if results[1].Status != "ok" || results[1].Steps != 4 || results[1].ExecutionResources.BuiltinInstanceCounter["output_builtin"] != 1 {
+		t.Errorf("Unexpected result %+v", results[1])
+	}
+
+	for _, extension := range []string{".trace", ".memory", ".output"} {
+		content, err := os.ReadFile(filepath.Join(outputDir, "output"+extension))
+		if err != nil || len(content) == 0 {
+			t.Errorf("Expected a non empty output%s file, got error %v", extension, err)
+		}
+	}
+	output, _ := os.ReadFile(filepath.Join(outputDir, "output.output"))
+	if !strings.Contains(string(output), "10") {
+		t.Errorf("Expected the output file to contain 10, got %q", output)
+	}
+	entries, _ := os.ReadDir(outputDir)
+	if len(entries) != 3 {
+		t.Errorf("Expected only the files of the successful run, got %d files", len(entries))
+	}
+}
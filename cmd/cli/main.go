@@ -1,10 +1,19 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"reflect"
+	"strconv"
 	"strings"
 
+	"github.com/lambdaclass/cairo-vm.go/pkg/cairo_pie"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
 	"github.com/urfave/cli/v2"
 )
@@ -12,11 +21,20 @@ import (
 func handleCommands(ctx *cli.Context) error {
 	programPath := ctx.Args().First()
 
+	if ctx.Bool("hints_report") {
+		return printHintsReport(programPath)
+	}
+
 	layout := ctx.String("layout")
 	if layout == "" {
 		layout = "plain"
 	}
 
+	factHashFunction, err := runners.FactHashFunctionFromString(ctx.String("fact_hash"))
+	if err != nil {
+		return err
+	}
+
 	proofMode := ctx.Bool("proof_mode")
 
 	secureRun := !proofMode
@@ -24,32 +42,146 @@ func handleCommands(ctx *cli.Context) error {
 		secureRun = true
 	}
 
-	cairoRunConfig := cairo_run.CairoRunConfig{DisableTracePadding: false, ProofMode: proofMode, Layout: layout, SecureRun: secureRun}
+	cairoRunConfig := cairo_run.CairoRunConfig{DisableTracePadding: false, ProofMode: proofMode, Layout: layout, SecureRun: secureRun, SkipUnknownHints: ctx.Bool("skip_unknown_hints"), InterpretPythonHints: ctx.Bool("python_hints"), HintWorker: strings.Fields(ctx.String("hint_worker")), HintWorkerTimeout: ctx.Duration("hint_worker_timeout")}
 
-	cairoRunner, err := cairo_run.CairoRun(programPath, cairoRunConfig)
+	runLimits, err := parseRunLimits(ctx)
+	if err != nil {
+		return err
+	}
+	cairoRunConfig.RunLimits = runLimits
+
+	programInputPath := ctx.String("program_input")
+	if programInputPath != "" {
+		programInput, err := os.ReadFile(programInputPath)
+		if err != nil {
+			return err
+		}
+		cairoRunConfig.ProgramInput = programInput
+	}
+
+	runContext := context.Background()
+	if timeout := ctx.Duration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		runContext, cancel = context.WithTimeout(runContext, timeout)
+		defer cancel()
+	}
+
+	// When running from a Cairo PIE, the program path is the path to the pie's zip file
+	var cairoRunner *runners.CairoRunner
+	programExtension := ".json"
+	if ctx.Bool("run_from_cairo_pie") {
+		programExtension = ".zip"
+		var pie *cairo_pie.CairoPie
+		pie, err = cairo_pie.ParseCairoPie(programPath)
+		if err != nil {
+			return err
+		}
+		cairoRunner, err = cairo_run.CairoRunPieWithContext(runContext, pie, cairoRunConfig)
+	} else {
+		cairoRunner, err = cairo_run.CairoRunWithContext(runContext, programPath, cairoRunConfig)
+	}
 	if err != nil {
 		return err
 	}
 
 	traceFilePath := ctx.String("trace_file")
 	if traceFilePath == "" {
-		traceFilePath = strings.Replace(programPath, ".json", ".go.trace", 1)
+		traceFilePath = strings.Replace(programPath, programExtension, ".go.trace", 1)
 	}
 	traceFile, err := os.OpenFile(traceFilePath, os.O_RDWR|os.O_CREATE, 0644)
 	defer traceFile.Close()
 
 	memoryFilePath := ctx.String("memory_file")
 	if memoryFilePath == "" {
-		memoryFilePath = strings.Replace(programPath, ".json", ".go.memory", 1)
+		memoryFilePath = strings.Replace(programPath, programExtension, ".go.memory", 1)
 	}
 	memoryFile, err := os.OpenFile(memoryFilePath, os.O_RDWR|os.O_CREATE, 0644)
 	defer memoryFile.Close()
 
 	cairo_run.WriteEncodedTrace(cairoRunner.Vm.RelocatedTrace, traceFile)
 	cairo_run.WriteEncodedMemory(cairoRunner.Vm.RelocatedMemory, memoryFile)
+
+	if ctx.Bool("print_fact") {
+		return printFact(cairoRunner, factHashFunction)
+	}
+	return nil
+}
+
+// Builds the run limits from the max_* flags, or returns nil if none of them is set
+func parseRunLimits(ctx *cli.Context) (*vm.RunLimits, error) {
+	runLimits := vm.RunLimits{
+		MaxMemoryCells:    ctx.Uint("max_memory_cells"),
+		MaxSegments:       ctx.Uint("max_segments"),
+		MaxHintExecutions: ctx.Uint("max_hint_executions"),
+		MaxDictEntries:    ctx.Uint("max_dict_entries"),
+	}
+	for _, limit := range strings.Split(ctx.String("max_builtin_instances"), ",") {
+		if limit == "" {
+			continue
+		}
+		name, value, found := strings.Cut(limit, "=")
+		instances, err := strconv.ParseUint(value, 10, 0)
+		if !found || err != nil {
+			return nil, fmt.Errorf("Invalid builtin instances limit %q, expected <builtin>=<instances>", limit)
+		}
+		if runLimits.MaxBuiltinInstances == nil {
+			runLimits.MaxBuiltinInstances = make(map[string]uint)
+		}
+		runLimits.MaxBuiltinInstances[name] = uint(instances)
+	}
+	if reflect.DeepEqual(runLimits, vm.RunLimits{}) {
+		return nil, nil
+	}
+	return &runLimits, nil
+}
+
+// Prints the hints of the program that aren't supported by the VM, without running it
+func printHintsReport(programPath string) error {
+	compiledProgram, err := parser.Parse(programPath)
+	if err != nil {
+		return err
+	}
+	program := vm.DeserializeProgramJson(compiledProgram)
+	hintProcessor := hints.CairoVmHintProcessor{}
+	return hints.WriteHintsReport(hintProcessor.UnknownHints(&program), os.Stdout)
+}
+
+func printFact(cairoRunner *runners.CairoRunner, hashFunction runners.FactHashFunction) error {
+	factInfo, err := cairoRunner.GetFactInfo(hashFunction)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Program hash: %s\n", factInfo.ProgramHash.ToHexString())
+	fmt.Printf("Fact: 0x%064x\n", factInfo.Fact)
 	return nil
 }
 
+// Flags of the limits on the resources used by a run, see parseRunLimits
+func runLimitFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.UintFlag{
+			Name:  "max_memory_cells",
+			Usage: "Fail the run if it writes more memory cells. Default: no limit",
+		},
+		&cli.UintFlag{
+			Name:  "max_segments",
+			Usage: "Fail the run if it creates more memory segments. Default: no limit",
+		},
+		&cli.StringFlag{
+			Name:  "max_builtin_instances",
+			Usage: "Fail the run if it uses more instances of a builtin, such as pedersen=100,range_check=1000. Default: no limit",
+		},
+		&cli.UintFlag{
+			Name:  "max_hint_executions",
+			Usage: "Fail the run if it executes more hints. Default: no limit",
+		},
+		&cli.UintFlag{
+			Name:  "max_dict_entries",
+			Usage: "Fail the run if its dictionaries hold more entries. Default: no limit",
+		},
+	}
+}
+
 func main() {
 	app := &cli.App{
 		Flags: []cli.Flag{
@@ -78,9 +210,55 @@ func main() {
 				Aliases: []string{"m"},
 				Usage:   "--memory_file <MEMORY_FILE>",
 			},
+			&cli.StringFlag{
+				Name:  "program_input",
+				Usage: "--program_input <PROGRAM_INPUT_FILE>. Json input of the program, such as the simple bootloader's tasks",
+			},
+			&cli.BoolFlag{
+				Name:  "run_from_cairo_pie",
+				Usage: "Re-run the execution stored in the given Cairo PIE zip file and check that the run matches it",
+			},
+			&cli.BoolFlag{
+				Name:  "print_fact",
+				Usage: "Print the program hash and the fact of the run. The program must use the output builtin",
+			},
+			&cli.StringFlag{
+				Name:  "fact_hash",
+				Value: "keccak",
+				Usage: "Hash function used to compute the fact: keccak, pedersen or poseidon",
+			},
+			&cli.BoolFlag{
+				Name:  "hints_report",
+				Usage: "Report the program's hints that aren't supported by the VM, with their pcs and source locations, without running it",
+			},
+			&cli.BoolFlag{
+				Name:  "skip_unknown_hints",
+				Usage: "Skip unsupported hints with a warning instead of failing. Only safe for hints that don't affect the execution, such as prints",
+			},
+			&cli.BoolFlag{
+				Name:  "python_hints",
+				Usage: "Interpret the hints the VM doesn't implement as python code. Only a restricted subset of python is supported",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Stop the run if it takes longer than the given duration, such as 30s. Default: no timeout",
+			},
+			&cli.StringFlag{
+				Name:  "hint_worker",
+				Usage: "Command of a worker process that executes the hints the VM doesn't implement, such as \"python3 scripts/hint_worker.py\"",
+			},
+			&cli.DurationFlag{
+				Name:  "hint_worker_timeout",
+				Usage: "Stop the hint worker and fail the run if it takes longer than the given duration to execute a hint, such as 10s. Default: no timeout",
+			},
 		},
 		Action: handleCommands,
+		Commands: []*cli.Command{
+			batchCommand(),
+			serveCommand(),
+		},
 	}
+	app.Flags = append(app.Flags, runLimitFlags()...)
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,432 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/bootloader"
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
+	"github.com/urfave/cli/v2"
+)
+
+// Body of a POST /run request. Either program or program_hash must be set
+type runRequest struct {
+	// Compiled program, as produced by cairo-compile
+	Program json.RawMessage `json:"program"`
+	// Hash of a program preloaded with --program, as listed by GET /programs
+	ProgramHash string `json:"program_hash"`
+	// Json input of the program, such as the simple bootloader's tasks
+	ProgramInput json.RawMessage `json:"program_input"`
+	// Default: plain
+	Layout    string           `json:"layout"`
+	ProofMode bool             `json:"proof_mode"`
+	Limits    runRequestLimits `json:"limits"`
+	// Artifacts to include in the response: "trace", "memory" and "pie"
+	Artifacts []string `json:"artifacts"`
+}
+
+// Limits of a run. They can only lower the limits of the server: zero values, and values above the server's limits,
+// are replaced by the server's
+type runRequestLimits struct {
+	TimeoutMs           uint            `json:"timeout_ms"`
+	MaxMemoryCells      uint            `json:"max_memory_cells"`
+	MaxSegments         uint            `json:"max_segments"`
+	MaxBuiltinInstances map[string]uint `json:"max_builtin_instances"`
+	MaxHintExecutions   uint            `json:"max_hint_executions"`
+	MaxDictEntries      uint            `json:"max_dict_entries"`
+}
+
+type runResponse struct {
+	Steps uint `json:"steps"`
+	// Values of the output builtin's segment, as decimal strings
+	Output []string `json:"output"`
+	// Values main returned: decimal strings for felts and segment:offset for relocatables
+	ReturnValues       []string                `json:"return_values"`
+	ExecutionResources runResponseResources    `json:"execution_resources"`
+	Trace              []byte                  `json:"trace,omitempty"`
+	Memory             []byte                  `json:"memory,omitempty"`
+	Pie                []byte                  `json:"pie,omitempty"`
+	DurationMs         int64                   `json:"duration_ms"`
+	Program            *preloadedProgramResult `json:"program,omitempty"`
+}
+
+type runResponseResources struct {
+	NSteps                 uint            `json:"n_steps"`
+	NMemoryHoles           uint            `json:"n_memory_holes"`
+	BuiltinInstanceCounter map[string]uint `json:"builtin_instance_counter"`
+}
+
+type preloadedProgramResult struct {
+	Hash string `json:"hash"`
+	Path string `json:"path"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Runs the programs received over http, at most maxRuns at the same time
+type runServer struct {
+	// Preloaded programs, indexed by their hash
+	programs     map[string]vm.Program
+	programPaths map[string]string
+	config       cairo_run.CairoRunConfig
+	timeout      time.Duration
+	maxBodySize  int64
+	slots        chan struct{}
+	running      atomic.Int64
+}
+
+func serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "Run programs received over a local http/json api",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "address",
+				Usage: "Address the server listens on. Default: 127.0.0.1:8080",
+			},
+			&cli.StringSliceFlag{
+				Name:  "program",
+				Usage: "Compiled program to preload, so that requests can run it by its hash. Can be repeated",
+			},
+			&cli.IntFlag{
+				Name:  "max_concurrent_runs",
+				Usage: "Amount of programs run at the same time, the other requests wait for a run to finish. Default: the number of CPUs",
+			},
+			&cli.Int64Flag{
+				Name:  "max_request_size",
+				Usage: "Maximum size of a request's body in bytes. Default: 64MiB",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Maximum duration of a run, such as 30s. Default: no timeout",
+			},
+			&cli.BoolFlag{
+				Name:  "skip_unknown_hints",
+				Usage: "Skip unsupported hints with a warning instead of failing. Only safe for hints that don't affect the execution, such as prints",
+			},
+			&cli.BoolFlag{
+				Name:  "python_hints",
+				Usage: "Interpret the hints the VM doesn't implement as python code. Only a restricted subset of python is supported",
+			},
+		}, runLimitFlags()...),
+		Action: handleServe,
+	}
+}
+
+func handleServe(ctx *cli.Context) error {
+	runLimits, err := parseRunLimits(ctx)
+	if err != nil {
+		return err
+	}
+	maxRuns := ctx.Int("max_concurrent_runs")
+	if maxRuns <= 0 {
+		maxRuns = runtime.NumCPU()
+	}
+	maxBodySize := ctx.Int64("max_request_size")
+	if maxBodySize <= 0 {
+		maxBodySize = 64 << 20
+	}
+	server := &runServer{
+		programs:     make(map[string]vm.Program),
+		programPaths: make(map[string]string),
+		config: cairo_run.CairoRunConfig{
+			SkipUnknownHints:     ctx.Bool("skip_unknown_hints"),
+			InterpretPythonHints: ctx.Bool("python_hints"),
+			RunLimits:            runLimits,
+			// Requests must not make the server read or write its files
+			DisableFilePathInputs: true,
+		},
+		timeout:     ctx.Duration("timeout"),
+		maxBodySize: maxBodySize,
+		slots:       make(chan struct{}, maxRuns),
+	}
+	for _, programPath := range ctx.StringSlice("program") {
+		compiledProgram, err := parser.Parse(programPath)
+		if err != nil {
+			return err
+		}
+		program := vm.DeserializeProgramJson(compiledProgram)
+		hash, err := program.ComputeProgramHashChain(0, false)
+		if err != nil {
+			return fmt.Errorf("Failed to compute the hash of %s: %w", programPath, err)
+		}
+		server.programs[hash.ToHexString()] = program
+		server.programPaths[hash.ToHexString()] = programPath
+		log.Printf("Preloaded %s with hash %s", programPath, hash.ToHexString())
+	}
+
+	address := ctx.String("address")
+	if address == "" {
+		address = "127.0.0.1:8080"
+	}
+	log.Printf("Listening on %s", address)
+	httpServer := &http.Server{
+		Addr:    address,
+		Handler: server.handler(),
+		// Requests can't hold a connection open by sending their headers slowly
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return httpServer.ListenAndServe()
+}
+
+func (s *runServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/programs", s.handlePrograms)
+	mux.HandleFunc("/run", s.handleRun)
+	return mux
+}
+
+func (s *runServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, http.StatusOK, map[string]any{
+		"status":              "ok",
+		"running_runs":        s.running.Load(),
+		"max_concurrent_runs": cap(s.slots),
+		"preloaded_programs":  len(s.programs),
+	})
+}
+
+func (s *runServer) handlePrograms(w http.ResponseWriter, r *http.Request) {
+	programs := make([]preloadedProgramResult, 0, len(s.programs))
+	for hash, path := range s.programPaths {
+		programs = append(programs, preloadedProgramResult{Hash: hash, Path: path})
+	}
+	writeJson(w, http.StatusOK, programs)
+}
+
+func (s *runServer) handleRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("Runs must be requested with POST"))
+		return
+	}
+	var request runRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxBodySize))
+	err := decoder.Decode(&request)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("Request body is larger than %d bytes", s.maxBodySize))
+			return
+		}
+		writeError(w, http.StatusBadRequest, fmt.Errorf("Invalid request: %w", err))
+		return
+	}
+	for _, artifact := range request.Artifacts {
+		if artifact != "trace" && artifact != "memory" && artifact != "pie" {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("Unknown artifact %s, expected trace, memory or pie", artifact))
+			return
+		}
+	}
+	program, preloaded, status, err := s.requestProgram(&request)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	err = bootloader.CheckProgramInputFilePaths(request.ProgramInput)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	config, timeout := s.requestConfig(&request)
+
+	// Wait for a free slot, unless the client gives up first
+	select {
+	case s.slots <- struct{}{}:
+	case <-r.Context().Done():
+		return
+	}
+	s.running.Add(1)
+	defer func() {
+		s.running.Add(-1)
+		<-s.slots
+	}()
+
+	runContext := r.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runContext, cancel = context.WithTimeout(runContext, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	cairoRunner, err := runRecoveringPanics(func() (*runners.CairoRunner, error) {
+		return cairo_run.CairoRunProgramWithContext(runContext, program, config)
+	})
+	if errors.Is(err, bootloader.ErrFilePathInput) {
+		// Found in the input of a task run by the bootloader
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	response, err := runResult(cairoRunner, request.Artifacts)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	response.DurationMs = time.Since(start).Milliseconds()
+	response.Program = preloaded
+	writeJson(w, http.StatusOK, response)
+}
+
+// Returns the program to run, and the preloaded program it is if it was requested by hash
+func (s *runServer) requestProgram(request *runRequest) (vm.Program, *preloadedProgramResult, int, error) {
+	if (len(request.Program) == 0) == (request.ProgramHash == "") {
+		return vm.Program{}, nil, http.StatusBadRequest, errors.New("Exactly one of program and program_hash must be set")
+	}
+	if request.ProgramHash != "" {
+		hashValue, ok := new(big.Int).SetString(request.ProgramHash, 0)
+		if !ok || hashValue.Sign() < 0 || hashValue.Cmp(lambdaworks.Prime()) >= 0 {
+			return vm.Program{}, nil, http.StatusBadRequest, fmt.Errorf("Invalid program_hash %s, expected a felt", request.ProgramHash)
+		}
+		hash := lambdaworks.FeltFromBigInt(hashValue).ToHexString()
+		program, ok := s.programs[hash]
+		if !ok {
+			return vm.Program{}, nil, http.StatusNotFound, fmt.Errorf("No preloaded program has hash %s", request.ProgramHash)
+		}
+		return program, &preloadedProgramResult{Hash: hash, Path: s.programPaths[hash]}, http.StatusOK, nil
+	}
+	compiledProgram, err := parser.ParseBytes(request.Program)
+	if err != nil {
+		return vm.Program{}, nil, http.StatusBadRequest, err
+	}
+	return vm.DeserializeProgramJson(compiledProgram), nil, http.StatusOK, nil
+}
+
+// Returns the run config and timeout of the request, bounded by the server's limits
+func (s *runServer) requestConfig(request *runRequest) (cairo_run.CairoRunConfig, time.Duration) {
+	config := s.config
+	config.Layout = request.Layout
+	if config.Layout == "" {
+		config.Layout = "plain"
+	}
+	config.ProofMode = request.ProofMode
+	config.SecureRun = !request.ProofMode
+	if len(request.ProgramInput) != 0 {
+		config.ProgramInput = request.ProgramInput
+	}
+
+	serverLimits := vm.RunLimits{}
+	if s.config.RunLimits != nil {
+		serverLimits = *s.config.RunLimits
+	}
+	limits := vm.RunLimits{
+		MaxMemoryCells:    minLimit(request.Limits.MaxMemoryCells, serverLimits.MaxMemoryCells),
+		MaxSegments:       minLimit(request.Limits.MaxSegments, serverLimits.MaxSegments),
+		MaxHintExecutions: minLimit(request.Limits.MaxHintExecutions, serverLimits.MaxHintExecutions),
+		MaxDictEntries:    minLimit(request.Limits.MaxDictEntries, serverLimits.MaxDictEntries),
+	}
+	if len(request.Limits.MaxBuiltinInstances) != 0 || len(serverLimits.MaxBuiltinInstances) != 0 {
+		limits.MaxBuiltinInstances = make(map[string]uint)
+		for name, instances := range serverLimits.MaxBuiltinInstances {
+			limits.MaxBuiltinInstances[name] = instances
+		}
+		for name, instances := range request.Limits.MaxBuiltinInstances {
+			limits.MaxBuiltinInstances[name] = minLimit(instances, limits.MaxBuiltinInstances[name])
+		}
+	}
+	config.RunLimits = &limits
+
+	timeout := time.Duration(request.Limits.TimeoutMs) * time.Millisecond
+	if s.timeout > 0 && (timeout == 0 || timeout > s.timeout) {
+		timeout = s.timeout
+	}
+	return config, timeout
+}
+
+// Returns the lowest of two limits, where 0 means no limit
+func minLimit(a uint, b uint) uint {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+func runResult(cairoRunner *runners.CairoRunner, artifacts []string) (runResponse, error) {
+	response := runResponse{Steps: cairoRunner.Vm.CurrentStep, Output: []string{}, ReturnValues: []string{}}
+	for _, builtin := range cairoRunner.Vm.BuiltinRunners {
+		if builtin.Name() == builtins.OUTPUT_BUILTIN_NAME {
+			output, err := cairoRunner.GetProgramOutput()
+			if err != nil {
+				return response, err
+			}
+			for _, value := range output {
+				response.Output = append(response.Output, value.ToBigInt().String())
+			}
+		}
+	}
+	returnValues, err := cairoRunner.GetReturnValues()
+	if err != nil {
+		return response, err
+	}
+	for _, value := range returnValues {
+		if felt, ok := value.GetFelt(); ok {
+			response.ReturnValues = append(response.ReturnValues, felt.ToBigInt().String())
+		} else {
+			relocatable, _ := value.GetRelocatable()
+			response.ReturnValues = append(response.ReturnValues, fmt.Sprintf("%d:%d", relocatable.SegmentIndex, relocatable.Offset))
+		}
+	}
+	executionResources, err := cairoRunner.GetExecutionResources()
+	if err != nil {
+		return response, err
+	}
+	response.ExecutionResources = runResponseResources{
+		NSteps:                 executionResources.NSteps,
+		NMemoryHoles:           executionResources.NMemoryHoles,
+		BuiltinInstanceCounter: executionResources.BuiltinsInstanceCounter,
+	}
+
+	for _, artifact := range artifacts {
+		var buffer bytes.Buffer
+		switch artifact {
+		case "trace":
+			err = cairo_run.WriteEncodedTrace(cairoRunner.Vm.RelocatedTrace, &buffer)
+			response.Trace = buffer.Bytes()
+		case "memory":
+			err = cairo_run.WriteEncodedMemory(cairoRunner.Vm.RelocatedMemory, &buffer)
+			response.Memory = buffer.Bytes()
+		case "pie":
+			pie, pieErr := cairoRunner.GetCairoPie()
+			if pieErr != nil {
+				return response, pieErr
+			}
+			err = pie.WriteZip(&buffer)
+			response.Pie = buffer.Bytes()
+		}
+		if err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+func writeJson(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		log.Printf("Failed to write response: %s", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJson(w, status, errorResponse{Error: err.Error()})
+}
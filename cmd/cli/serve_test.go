@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
+)
+
+// Program equivalent to:
+//
+//	func main{output_ptr: felt*}() {
+//	    tempvar value = 10;
+//	    assert [output_ptr] = value;
+//	    let output_ptr = output_ptr + 1;
+//	    return ();
+//	}
+const outputProgramJson = `{
+	"builtins": ["output"],
+	"data": ["0x480680017fff8000", "0xa", "0x400280007ffd7fff", "0x482680017ffd8000", "0x1", "0x208b7fff7fff7ffe"],
+	"hints": {},
+	"identifiers": {"__main__.main": {"decorators": [], "pc": 0, "type": "function"}},
+	"main_scope": "__main__",
+	"prime": "0x800000000000011000000000000000000000000000000000000000000000001",
+	"reference_manager": {"references": []}
+}`
+
+// Returns a server with outputProgramJson preloaded, and the hash of the preloaded program
+func newTestServer(t *testing.T, maxRuns int, limits *vm.RunLimits) (*runServer, string) {
+	compiledProgram, err := parser.ParseBytes([]byte(outputProgramJson))
+	if err != nil {
+		t.Fatalf("ParseBytes failed with error: %s", err)
+	}
+	program := vm.DeserializeProgramJson(compiledProgram)
+	hash, err := program.ComputeProgramHashChain(0, false)
+	if err != nil {
+		t.Fatalf("ComputeProgramHashChain failed with error: %s", err)
+	}
+	server := &runServer{
+		programs:     map[string]vm.Program{hash.ToHexString(): program},
+		programPaths: map[string]string{hash.ToHexString(): "output_program.json"},
+		config: cairo_run.CairoRunConfig{
+			RunLimits:             limits,
+			DisableFilePathInputs: true,
+		},
+		maxBodySize: 1 << 20,
+		slots:       make(chan struct{}, maxRuns),
+	}
+	return server, hash.ToHexString()
+}
+
+func postRun(handler http.Handler, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/run", strings.NewReader(body)))
+	return recorder
+}
+
+func decodeRunResponse(t *testing.T, recorder *httptest.ResponseRecorder) runResponse {
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response runResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	if err != nil {
+		t.Fatalf("Invalid response %s: %s", recorder.Body.String(), err)
+	}
+	return response
+}
+
+func TestServeHealth(t *testing.T) {
+	server, _ := newTestServer(t, 3, nil)
+	recorder := httptest.NewRecorder()
+	server.handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", recorder.Code)
+	}
+	var health map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &health)
+	if err != nil {
+		t.Fatalf("Invalid response %s: %s", recorder.Body.String(), err)
+	}
+	expected := map[string]any{
+		"status":              "ok",
+		"running_runs":        float64(0),
+		"max_concurrent_runs": float64(3),
+		"preloaded_programs":  float64(1),
+	}
+	if !reflect.DeepEqual(health, expected) {
+		t.Errorf("Expected health %v, got %v", expected, health)
+	}
+}
+
+func TestServePrograms(t *testing.T) {
+	server, hash := newTestServer(t, 1, nil)
+	recorder := httptest.NewRecorder()
+	server.handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/programs", nil))
+	var programs []preloadedProgramResult
+	err := json.Unmarshal(recorder.Body.Bytes(), &programs)
+	if err != nil {
+		t.Fatalf("Invalid response %s: %s", recorder.Body.String(), err)
+	}
+	expected := []preloadedProgramResult{{Hash: hash, Path: "output_program.json"}}
+	if !reflect.DeepEqual(programs, expected) {
+		t.Errorf("Expected programs %v, got %v", expected, programs)
+	}
+}
+
+func TestServeRunInlineProgram(t *testing.T) {
+	server, _ := newTestServer(t, 1, nil)
+	body := fmt.Sprintf(`{"program": %s, "layout": "small", "artifacts": ["trace", "memory"]}`, outputProgramJson)
+	response := decodeRunResponse(t, postRun(server.handler(), body))
+	if !reflect.DeepEqual(response.Output, []string{"10"}) {
+		t.Errorf("Expected output [10], got %v", response.Output)
+	}
+	if response.ExecutionResources.BuiltinInstanceCounter["output"] != 1 {
+		t.Errorf("Expected 1 output builtin instance, got %v", response.ExecutionResources.BuiltinInstanceCounter)
+	}
+	if len(response.Trace) == 0 || len(response.Memory) == 0 {
+		t.Errorf("Expected the trace and memory artifacts, got %d and %d bytes", len(response.Trace), len(response.Memory))
+	}
+	if response.Pie != nil || response.Program != nil {
+		t.Errorf("Expected no pie and no preloaded program, got %v and %v", response.Pie, response.Program)
+	}
+}
+
+func TestServeRunPreloadedProgram(t *testing.T) {
+	server, hash := newTestServer(t, 1, nil)
+	response := decodeRunResponse(t, postRun(server.handler(), fmt.Sprintf(`{"program_hash": "%s", "layout": "small"}`, hash)))
+	if !reflect.DeepEqual(response.Output, []string{"10"}) {
+		t.Errorf("Expected output [10], got %v", response.Output)
+	}
+	expected := &preloadedProgramResult{Hash: hash, Path: "output_program.json"}
+	if !reflect.DeepEqual(response.Program, expected) {
+		t.Errorf("Expected program %v, got %v", expected, response.Program)
+	}
+	if response.Trace != nil || response.Memory != nil {
+		t.Errorf("Expected no artifacts, got %d bytes of trace and %d of memory", len(response.Trace), len(response.Memory))
+	}
+}
+
+func TestServeRunBadRequests(t *testing.T) {
+	server, hash := newTestServer(t, 1, nil)
+	program := outputProgramJson
+	tests := map[string]struct {
+		body   string
+		status int
+	}{
+		"invalid json":          {`{"program": `, http.StatusBadRequest},
+		"no program":            {`{"layout": "small"}`, http.StatusBadRequest},
+		"program and hash":      {fmt.Sprintf(`{"program": %s, "program_hash": "%s"}`, program, hash), http.StatusBadRequest},
+		"unknown artifact":      {fmt.Sprintf(`{"program": %s, "artifacts": ["output"]}`, program), http.StatusBadRequest},
+		"invalid program":       {`{"program": {"data": []}}`, http.StatusBadRequest},
+		"non hex hash":          {`{"program_hash": "0xzz"}`, http.StatusBadRequest},
+		"hash above the prime":  {`{"program_hash": "0x800000000000011000000000000000000000000000000000000000000000001"}`, http.StatusBadRequest},
+		"negative hash":         {`{"program_hash": "-1"}`, http.StatusBadRequest},
+		"unknown hash":          {`{"program_hash": "0x1234"}`, http.StatusNotFound},
+		"cairo pie path task":   {fmt.Sprintf(`{"program": %s, "program_input": {"tasks": [{"type": "CairoPiePath", "path": "/etc/passwd"}]}}`, program), http.StatusBadRequest},
+		"fact topologies path":  {fmt.Sprintf(`{"program": %s, "program_input": {"tasks": [], "fact_topologies_path": "/tmp/facts.json"}}`, program), http.StatusBadRequest},
+		"unknown layout":        {fmt.Sprintf(`{"program": %s, "layout": "unknown"}`, program), http.StatusUnprocessableEntity},
+		"memory cells exceeded": {fmt.Sprintf(`{"program": %s, "layout": "small", "limits": {"max_memory_cells": 2}}`, program), http.StatusUnprocessableEntity},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := postRun(server.handler(), test.body)
+			if recorder.Code != test.status {
+				t.Errorf("Expected status %d, got %d: %s", test.status, recorder.Code, recorder.Body.String())
+			}
+			var response errorResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			if err != nil || response.Error == "" {
+				t.Errorf("Expected an error response, got %s", recorder.Body.String())
+			}
+		})
+	}
+	if len(server.slots) != 0 || server.running.Load() != 0 {
+		t.Errorf("Expected the failed runs to free their slots, got %d slots taken and %d runs", len(server.slots), server.running.Load())
+	}
+}
+
+func TestServeRunMethodNotAllowed(t *testing.T) {
+	server, _ := newTestServer(t, 1, nil)
+	recorder := httptest.NewRecorder()
+	server.handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/run", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", recorder.Code)
+	}
+}
+
+func TestServeRunBodyTooLarge(t *testing.T) {
+	server, _ := newTestServer(t, 1, nil)
+	server.maxBodySize = 64
+	recorder := postRun(server.handler(), fmt.Sprintf(`{"program": %s}`, outputProgramJson))
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestServeRunWaitsForFreeSlot(t *testing.T) {
+	server, hash := newTestServer(t, 1, nil)
+	// Take the only slot, as a run in progress would
+	server.slots <- struct{}{}
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- postRun(server.handler(), fmt.Sprintf(`{"program_hash": "%s", "layout": "small"}`, hash))
+	}()
+	select {
+	case recorder := <-done:
+		t.Fatalf("Expected the run to wait for a free slot, got status %d", recorder.Code)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if server.running.Load() != 0 {
+		t.Errorf("Expected waiting runs not to count as running, got %d running runs", server.running.Load())
+	}
+
+	<-server.slots
+	decodeRunResponse(t, <-done)
+	if len(server.slots) != 0 || server.running.Load() != 0 {
+		t.Errorf("Expected the run to free its slot, got %d slots taken and %d runs", len(server.slots), server.running.Load())
+	}
+}
+
+func TestServeRunCancelledWhileWaiting(t *testing.T) {
+	server, hash := newTestServer(t, 1, nil)
+	server.slots <- struct{}{}
+
+	request := httptest.NewRequest(http.MethodPost, "/run", bytes.NewReader([]byte(fmt.Sprintf(`{"program_hash": "%s"}`, hash))))
+	requestContext, cancel := context.WithCancel(request.Context())
+	cancel()
+	recorder := httptest.NewRecorder()
+	server.handler().ServeHTTP(recorder, request.WithContext(requestContext))
+	if recorder.Body.Len() != 0 {
+		t.Errorf("Expected no response for a cancelled request, got %s", recorder.Body.String())
+	}
+	if len(server.slots) != 1 || server.running.Load() != 0 {
+		t.Errorf("Expected the cancelled request not to take a slot, got %d slots taken and %d runs", len(server.slots), server.running.Load())
+	}
+}
+
+func TestServeRequestConfigClampsLimits(t *testing.T) {
+	server, _ := newTestServer(t, 1, &vm.RunLimits{
+		MaxMemoryCells:      1000,
+		MaxSegments:         10,
+		MaxBuiltinInstances: map[string]uint{"pedersen": 5},
+	})
+	server.timeout = 2 * time.Second
+	request := runRequest{
+		ProofMode: true,
+		Limits: runRequestLimits{
+			TimeoutMs:           5000,
+			MaxMemoryCells:      100,
+			MaxSegments:         20,
+			MaxHintExecutions:   7,
+			MaxBuiltinInstances: map[string]uint{"pedersen": 10, "bitwise": 3},
+		},
+	}
+	config, timeout := server.requestConfig(&request)
+	expectedLimits := vm.RunLimits{
+		MaxMemoryCells:      100,
+		MaxSegments:         10,
+		MaxHintExecutions:   7,
+		MaxBuiltinInstances: map[string]uint{"pedersen": 5, "bitwise": 3},
+	}
+	if !reflect.DeepEqual(*config.RunLimits, expectedLimits) {
+		t.Errorf("Expected limits %+v, got %+v", expectedLimits, *config.RunLimits)
+	}
+	if timeout != 2*time.Second {
+		t.Errorf("Expected the server's timeout, got %s", timeout)
+	}
+	if config.Layout != "plain" || !config.ProofMode || config.SecureRun || !config.DisableFilePathInputs {
+		t.Errorf("Unexpected config %+v", config)
+	}
+	if server.config.RunLimits.MaxBuiltinInstances["pedersen"] != 5 {
+		t.Errorf("Expected the server's limits to be left as they were, got %+v", server.config.RunLimits)
+	}
+
+	request.Limits.TimeoutMs = 500
+	_, timeout = server.requestConfig(&request)
+	if timeout != 500*time.Millisecond {
+		t.Errorf("Expected the request's lower timeout, got %s", timeout)
+	}
+}
+
+func TestMinLimit(t *testing.T) {
+	tests := []struct{ a, b, expected uint }{
+		{0, 0, 0},
+		{0, 5, 5},
+		{5, 0, 5},
+		{3, 5, 3},
+		{5, 3, 3},
+	}
+	for _, test := range tests {
+		if result := minLimit(test.a, test.b); result != test.expected {
+			t.Errorf("minLimit(%d, %d): expected %d, got %d", test.a, test.b, test.expected, result)
+		}
+	}
+}
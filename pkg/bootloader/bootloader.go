@@ -0,0 +1,379 @@
+package bootloader
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/cairo_pie"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Task types, as named in the simple bootloader's input
+const (
+	RUN_PROGRAM_TASK = "RunProgramTask"
+	CAIRO_PIE_PATH   = "CairoPiePath"
+)
+
+// Offsets of the fields of cairo-lang's ProgramHeader struct, used when the bootloader's struct definitions aren't
+// available. The builtin list is a continuous memory segment holding the ascii encoding of the program's builtins
+const (
+	PROGRAM_HEADER_DATA_LENGTH        = 0
+	PROGRAM_HEADER_BOOTLOADER_VERSION = 1
+	PROGRAM_HEADER_PROGRAM_MAIN       = 2
+	PROGRAM_HEADER_N_BUILTINS         = 3
+	PROGRAM_HEADER_BUILTIN_LIST       = 4
+)
+
+// Offsets of the fields of the ProgramHeader struct of a bootloader, as defined by its program
+type ProgramHeaderOffsets struct {
+	DataLength        uint
+	BootloaderVersion uint
+	ProgramMain       uint
+	NBuiltins         uint
+	BuiltinList       uint
+}
+
+var DefaultProgramHeaderOffsets = ProgramHeaderOffsets{
+	DataLength:        PROGRAM_HEADER_DATA_LENGTH,
+	BootloaderVersion: PROGRAM_HEADER_BOOTLOADER_VERSION,
+	ProgramMain:       PROGRAM_HEADER_PROGRAM_MAIN,
+	NBuiltins:         PROGRAM_HEADER_N_BUILTINS,
+	BuiltinList:       PROGRAM_HEADER_BUILTIN_LIST,
+}
+
+// Builtins supported by the simple bootloader, in the order of its BuiltinData struct
+var SIMPLE_BOOTLOADER_BUILTINS = []string{
+	builtins.OUTPUT_BUILTIN_NAME,
+	builtins.PEDERSEN_BUILTIN_NAME,
+	builtins.RANGE_CHECK_BUILTIN_NAME,
+	builtins.SIGNATURE_BUILTIN_NAME,
+	builtins.BITWISE_BUILTIN_NAME,
+	builtins.EC_OP_BUILTIN_NAME,
+	builtins.KECCAK_BUILTIN_NAME,
+	builtins.POSEIDON_BUILTIN_NAME,
+}
+
+func BootloaderError(err error) error {
+	return errors.Wrapf(err, "Bootloader error")
+}
+
+// A task as given in the bootloader's input: either a compiled program along with its input (RunProgramTask),
+// or the path to a Cairo PIE (CairoPiePath)
+type TaskSpec struct {
+	Type         string          `json:"type"`
+	Program      json.RawMessage `json:"program,omitempty"`
+	ProgramInput json.RawMessage `json:"program_input,omitempty"`
+	Path         string          `json:"path,omitempty"`
+	UsePoseidon  bool            `json:"use_poseidon"`
+}
+
+// The input of the simple bootloader, passed as its program input
+type SimpleBootloaderInput struct {
+	Tasks []TaskSpec `json:"tasks"`
+	// If set, the fact topologies of the tasks are written to this file
+	FactTopologiesPath *string `json:"fact_topologies_path"`
+	// If set, the output is not split into pages according to the tasks' fact topologies
+	SinglePage bool `json:"single_page"`
+}
+
+var ErrFilePathInput = errors.New("File paths are not allowed in the bootloader input")
+
+// Returns ErrFilePathInput if the input makes the bootloader read or write files: CairoPiePath tasks open their pie's
+// file and fact_topologies_path is written to
+func (i *SimpleBootloaderInput) CheckNoFilePaths() error {
+	if i.FactTopologiesPath != nil {
+		return BootloaderError(errors.Wrapf(ErrFilePathInput, "fact_topologies_path is set"))
+	}
+	for _, task := range i.Tasks {
+		if task.Type == CAIRO_PIE_PATH {
+			return BootloaderError(errors.Wrapf(ErrFilePathInput, "%s task", CAIRO_PIE_PATH))
+		}
+	}
+	return nil
+}
+
+// Same as SimpleBootloaderInput.CheckNoFilePaths, for a program input that may not be a bootloader input, in which
+// case it has no file paths
+func CheckProgramInputFilePaths(programInput []byte) error {
+	var input SimpleBootloaderInput
+	if json.Unmarshal(programInput, &input) != nil {
+		return nil
+	}
+	return input.CheckNoFilePaths()
+}
+
+func ParseSimpleBootloaderInput(data []byte) (SimpleBootloaderInput, error) {
+	var input SimpleBootloaderInput
+	err := json.Unmarshal(data, &input)
+	if err != nil {
+		return SimpleBootloaderInput{}, BootloaderError(err)
+	}
+	return input, nil
+}
+
+// A task loaded from its spec, ready to be executed by the bootloader.
+// CairoPie is only set for pie tasks, in which case Program is the pie's program
+type Task struct {
+	Program      vm.Program
+	ProgramInput json.RawMessage
+	CairoPie     *cairo_pie.CairoPie
+	UsePoseidon  bool
+}
+
+func (t *Task) IsCairoPie() bool {
+	return t.CairoPie != nil
+}
+
+func (s *TaskSpec) LoadTask() (*Task, error) {
+	switch s.Type {
+	case RUN_PROGRAM_TASK:
+		compiledProgram, err := parser.ParseBytes(s.Program)
+		if err != nil {
+			return nil, BootloaderError(errors.Wrapf(err, "Invalid task program"))
+		}
+		return &Task{
+			Program:      vm.DeserializeProgramJson(compiledProgram),
+			ProgramInput: s.ProgramInput,
+			UsePoseidon:  s.UsePoseidon,
+		}, nil
+	case CAIRO_PIE_PATH:
+		pie, err := cairo_pie.ParseCairoPie(s.Path)
+		if err != nil {
+			return nil, BootloaderError(err)
+		}
+		return &Task{Program: pie.Program(), CairoPie: pie, UsePoseidon: s.UsePoseidon}, nil
+	default:
+		return nil, BootloaderError(errors.Errorf("Unexpected task type: %s", s.Type))
+	}
+}
+
+// Writes the program's header and code to memory, starting at the given header address, as cairo-lang's load_program does.
+// Returns the address of the program's code and the total size of the written data
+func LoadProgram(program *vm.Program, segments *memory.MemorySegmentManager, programHeader memory.Relocatable) (memory.Relocatable, uint, error) {
+	return LoadProgramWithHeaderOffsets(program, segments, programHeader, DefaultProgramHeaderOffsets)
+}
+
+// Same as LoadProgram, for a bootloader whose ProgramHeader struct has the given offsets
+func LoadProgramWithHeaderOffsets(program *vm.Program, segments *memory.MemorySegmentManager, programHeader memory.Relocatable, offsets ProgramHeaderOffsets) (memory.Relocatable, uint, error) {
+	mainIdentifier, ok := program.Identifiers["__main__.main"]
+	if !ok {
+		return memory.Relocatable{}, 0, BootloaderError(errors.New("Task program has no __main__.main identifier"))
+	}
+	nBuiltins := uint(len(program.Builtins))
+	headerSize := offsets.BuiltinList + nBuiltins
+
+	// data_length doesn't include the data_length field itself.
+	// bootloader_version is left for the bootloader to fill
+	headerFields := map[uint]uint{
+		offsets.DataLength:  headerSize - 1 + uint(len(program.Data)),
+		offsets.ProgramMain: uint(mainIdentifier.PC),
+		offsets.NBuiltins:   nBuiltins,
+	}
+	for offset, value := range headerFields {
+		err := segments.Memory.Insert(programHeader.AddUint(offset), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(value))))
+		if err != nil {
+			return memory.Relocatable{}, 0, BootloaderError(err)
+		}
+	}
+
+	builtinList := make([]memory.MaybeRelocatable, 0, nBuiltins)
+	for _, builtin := range program.Builtins {
+		builtinFelt, err := vm.FeltFromShortString(builtin)
+		if err != nil {
+			return memory.Relocatable{}, 0, BootloaderError(err)
+		}
+		builtinList = append(builtinList, *memory.NewMaybeRelocatableFelt(builtinFelt))
+	}
+	_, err := segments.LoadData(programHeader.AddUint(offsets.BuiltinList), &builtinList)
+	if err != nil {
+		return memory.Relocatable{}, 0, BootloaderError(err)
+	}
+
+	programAddress := programHeader.AddUint(headerSize)
+	_, err = segments.LoadData(programAddress, &program.Data)
+	if err != nil {
+		return memory.Relocatable{}, 0, BootloaderError(err)
+	}
+	return programAddress, headerSize + uint(len(program.Data)), nil
+}
+
+// Returns the fact topology of a task's output. For program tasks, it is built from the additional data the task
+// added to the output builtin, for pie tasks, from the output builtin's additional data stored in the pie
+func GetTaskFactTopology(outputSize uint, task *Task, outputBuiltin *builtins.OutputBuiltinRunner) (runners.FactTopology, error) {
+	if task.IsCairoPie() {
+		additionalData, err := task.CairoPie.OutputBuiltinAdditionalData()
+		if err != nil {
+			return runners.FactTopology{}, BootloaderError(err)
+		}
+		return runners.GetFactTopologyFromAdditionalData(outputSize, additionalData)
+	}
+	return runners.GetFactTopologyFromAdditionalData(outputSize, outputBuiltin.GetAdditionalData())
+}
+
+// Adds the pages of each task's fact topology to the output builtin, starting from page 1 (page 0 holds the
+// bootloader's own output). The output of each task is preceded by two cells: its size and its program hash.
+// Returns the next available page id
+func ConfigureFactTopologies(factTopologies []runners.FactTopology, outputStart memory.Relocatable, outputBuiltin *builtins.OutputBuiltinRunner) (uint, error) {
+	pageId := uint(1)
+	for _, factTopology := range factTopologies {
+		outputStart = outputStart.AddUint(2)
+		for _, pageSize := range factTopology.PageSizes {
+			err := outputBuiltin.AddPage(pageId, outputStart, pageSize)
+			if err != nil {
+				return 0, BootloaderError(err)
+			}
+			outputStart = outputStart.AddUint(pageSize)
+			pageId++
+		}
+	}
+	return pageId, nil
+}
+
+func WriteFactTopologiesFile(path string, factTopologies []runners.FactTopology) error {
+	contents, err := json.Marshal(map[string][]runners.FactTopology{"fact_topologies": factTopologies})
+	if err != nil {
+		return BootloaderError(err)
+	}
+	err = os.WriteFile(path, contents, 0644)
+	if err != nil {
+		return BootloaderError(err)
+	}
+	return nil
+}
+
+// Loads the memory of a Cairo PIE into the vm, relocating its segments, as cairo-lang's load_cairo_pie does.
+// The program segment is mapped to the address the program was loaded at, the execution segment to the address where
+// the program's initial stack starts, and the builtin segments to the builtin pointers on that stack.
+// This replaces the execution of the (untrusted) hints of the pie's program
+func LoadCairoPie(pie *cairo_pie.CairoPie, virtualMachine *vm.VirtualMachine, programAddress memory.Relocatable, executionSegmentAddress memory.Relocatable, retFp memory.Relocatable, retPc memory.Relocatable) error {
+	segmentOffsets := map[int]memory.Relocatable{
+		pie.Metadata.ProgramSegment.Index:   programAddress,
+		pie.Metadata.ExecutionSegment.Index: executionSegmentAddress,
+		pie.Metadata.RetFpSegment.Index:     retFp,
+		pie.Metadata.RetPcSegment.Index:     retPc,
+	}
+
+	pieMemory := make(map[memory.Relocatable]memory.MaybeRelocatable, len(pie.Memory))
+	for _, cell := range pie.Memory {
+		pieMemory[cell.Address] = cell.Value
+	}
+
+	// The initial stack of the pie's program holds the bases of its builtin segments
+	origExecutionSegment := memory.NewRelocatable(pie.Metadata.ExecutionSegment.Index, 0)
+	for i, name := range pie.Metadata.Program.Builtins {
+		builtinStart, ok := pieMemory[origExecutionSegment.AddUint(uint(i))]
+		if !ok {
+			return BootloaderError(errors.Errorf("Missing %s builtin start address", name))
+		}
+		builtinBase, ok := builtinStart.GetRelocatable()
+		if !ok || builtinBase.Offset != 0 {
+			return BootloaderError(errors.Errorf("Invalid %s builtin start address", name))
+		}
+		newBuiltinBase, err := virtualMachine.Segments.Memory.GetRelocatable(executionSegmentAddress.AddUint(uint(i)))
+		if err != nil {
+			return BootloaderError(err)
+		}
+		segmentOffsets[builtinBase.SegmentIndex] = newBuiltinBase
+	}
+
+	for _, segmentInfo := range pie.Metadata.ExtraSegments {
+		segmentOffsets[segmentInfo.Index] = virtualMachine.Segments.AddSegment()
+	}
+
+	// Signatures have to be added before the memory is loaded, as they are checked when public keys and messages are written
+	signatures, err := pie.SignatureBuiltinAdditionalData()
+	if err != nil {
+		return BootloaderError(err)
+	}
+	if len(signatures) != 0 {
+		builtin, err := virtualMachine.GetBuiltinRunner(builtins.SIGNATURE_BUILTIN_NAME)
+		if err != nil {
+			return BootloaderError(errors.New("The task requires the ecdsa builtin but it is missing"))
+		}
+		signatureBuiltin, ok := (*builtin).(*builtins.SignatureBuiltinRunner)
+		if !ok {
+			return BootloaderError(errors.New("Could not cast to SignatureBuiltinRunner"))
+		}
+		for address, signature := range signatures {
+			relocatedAddress, err := cairo_pie.RelocateAddress(address, segmentOffsets)
+			if err != nil {
+				return BootloaderError(err)
+			}
+			if relocatedAddress.SegmentIndex != signatureBuiltin.Base().SegmentIndex {
+				return BootloaderError(errors.New("Signature address is not in the ecdsa builtin segment"))
+			}
+			signatureBuiltin.AddSignature(relocatedAddress, signature)
+		}
+	}
+
+	for _, cell := range pie.Memory {
+		address, err := cairo_pie.RelocateAddress(cell.Address, segmentOffsets)
+		if err != nil {
+			return BootloaderError(err)
+		}
+		value, err := cairo_pie.RelocateValue(cell.Value, segmentOffsets)
+		if err != nil {
+			return BootloaderError(err)
+		}
+		err = virtualMachine.Segments.Memory.Insert(address, &value)
+		if err != nil {
+			return BootloaderError(err)
+		}
+	}
+	return nil
+}
+
+// Writes the builtin pointers returned by a task to the bootloader's BuiltinData struct at returnBuiltinsAddr.
+// Builtins used by the task take the values it returned (at usedBuiltinsAddr), the rest keep their pre-execution values.
+// For pie tasks, the builtin usage is checked against the pie's builtin segment sizes
+func WriteReturnBuiltins(task *Task, segments *memory.MemorySegmentManager, returnBuiltinsAddr memory.Relocatable, usedBuiltinsAddr memory.Relocatable, preExecutionBuiltinsAddr memory.Relocatable) error {
+	usedBuiltins := make(map[string]bool, len(task.Program.Builtins))
+	for _, builtin := range task.Program.Builtins {
+		usedBuiltins[builtin] = true
+	}
+	usedBuiltinOffset := uint(0)
+	for i, builtin := range SIMPLE_BOOTLOADER_BUILTINS {
+		var value *memory.MaybeRelocatable
+		var err error
+		if usedBuiltins[builtin] {
+			value, err = segments.Memory.Get(usedBuiltinsAddr.AddUint(usedBuiltinOffset))
+			usedBuiltinOffset++
+		} else {
+			// Unused builtins keep the value they had before the task was executed
+			value, err = segments.Memory.Get(preExecutionBuiltinsAddr.AddUint(uint(i)))
+		}
+		if err != nil {
+			return BootloaderError(err)
+		}
+		err = segments.Memory.Insert(returnBuiltinsAddr.AddUint(uint(i)), value)
+		if err != nil {
+			return BootloaderError(err)
+		}
+
+		if task.IsCairoPie() && usedBuiltins[builtin] {
+			preExecutionValue, err := segments.Memory.Get(preExecutionBuiltinsAddr.AddUint(uint(i)))
+			if err != nil {
+				return BootloaderError(err)
+			}
+			usedCells, err := value.Sub(*preExecutionValue)
+			if err != nil {
+				return BootloaderError(err)
+			}
+			usedCellsFelt, _ := usedCells.GetFelt()
+			expectedCells := lambdaworks.FeltFromUint64(uint64(task.CairoPie.Metadata.BuiltinSegments[builtin].Size))
+			if usedCellsFelt != expectedCells {
+				return BootloaderError(errors.Errorf("Builtin usage of %s is inconsistent with the Cairo PIE", builtin))
+			}
+		}
+	}
+	if usedBuiltinOffset != uint(len(task.Program.Builtins)) {
+		return BootloaderError(errors.Errorf("Task uses builtins not supported by the bootloader: %v", task.Program.Builtins))
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package bootloader_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/bootloader"
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/cairo_pie"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestParseSimpleBootloaderInput(t *testing.T) {
+	input, err := bootloader.ParseSimpleBootloaderInput([]byte(`{"tasks": [{"type": "CairoPiePath", "path": "pie.zip", "use_poseidon": true}], "single_page": true}`))
+	if err != nil {
+		t.Fatalf("ParseSimpleBootloaderInput failed with error: %s", err)
+	}
+	if len(input.Tasks) != 1 || input.Tasks[0].Type != bootloader.CAIRO_PIE_PATH || input.Tasks[0].Path != "pie.zip" || !input.Tasks[0].UsePoseidon {
+		t.Errorf("Wrong tasks: %+v", input.Tasks)
+	}
+	if !input.SinglePage || input.FactTopologiesPath != nil {
+		t.Errorf("Wrong bootloader input: %+v", input)
+	}
+}
+
+func TestLoadTaskUnknownType(t *testing.T) {
+	spec := bootloader.TaskSpec{Type: "UnknownTask"}
+	_, err := spec.LoadTask()
+	if err == nil {
+		t.Errorf("LoadTask should fail for an unknown task type")
+	}
+}
+
+func TestLoadProgram(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	programHeader := segments.AddSegment()
+	program := vm.Program{
+		Data: []memory.MaybeRelocatable{
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(10)),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(20)),
+		},
+		Builtins:    []string{"output"},
+		Identifiers: map[string]vm.Identifier{"__main__.main": {PC: 1, Type: "function"}},
+	}
+	programAddress, size, err := bootloader.LoadProgram(&program, &segments, programHeader)
+	if err != nil {
+		t.Fatalf("LoadProgram failed with error: %s", err)
+	}
+	if programAddress != memory.NewRelocatable(0, 5) || size != 7 {
+		t.Errorf("Wrong program address %v or size %d", programAddress, size)
+	}
+	outputFelt, _ := vm.FeltFromShortString("output")
+	expected := []lambdaworks.Felt{
+		lambdaworks.FeltFromUint64(6), lambdaworks.FeltZero(), lambdaworks.FeltOne(), lambdaworks.FeltOne(), outputFelt,
+		lambdaworks.FeltFromUint64(10), lambdaworks.FeltFromUint64(20),
+	}
+	for i, value := range expected {
+		if i == 1 {
+			// bootloader_version is filled by the bootloader
+			continue
+		}
+		cell, err := segments.Memory.GetFelt(programHeader.AddUint(uint(i)))
+		if err != nil || cell != value {
+			t.Errorf("Wrong value at offset %d. Expected %v, got %v", i, value, cell)
+		}
+	}
+}
+
+// Loads the same shared task program into several VMs in parallel, as concurrent bootloader runs do, and runs its
+// first step. Meant to be run with -race, as the Makefile's coverage target does
+func TestLoadSharedProgramConcurrently(t *testing.T) {
+	program := vm.Program{
+		Data: []memory.MaybeRelocatable{
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480680017fff8000)),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x208b7fff7fff7ffe)),
+		},
+		Builtins: []string{"output"},
+		Identifiers: map[string]vm.Identifier{
+			"__main__.main": {PC: 0, Type: "function"},
+			"__main__.N":    {Type: "const", Value: lambdaworks.FeltFromUint64(3)},
+		},
+		Hints: map[uint][]parser.HintParams{0: {{Code: hint_codes.VM_ENTER_SCOPE, AccessibleScopes: []string{"__main__", "__main__.main"}}}},
+	}.Shared()
+
+	results := make([]*vm.VirtualMachine, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			virtualMachine := vm.NewVirtualMachine()
+			programAddress, _, err := bootloader.LoadProgram(&program, &virtualMachine.Segments, virtualMachine.Segments.AddSegment())
+			if err != nil {
+				t.Errorf("LoadProgram failed with error: %s", err)
+				return
+			}
+			hintProcessor := &hints.CairoVmHintProcessor{}
+			err = virtualMachine.LoadProgram(&program, programAddress, hintProcessor)
+			if err != nil {
+				t.Errorf("vm.LoadProgram failed with error: %s", err)
+				return
+			}
+			_, err = program.ComputeProgramHashChain(0, true)
+			if err != nil {
+				t.Errorf("ComputeProgramHashChain failed with error: %s", err)
+				return
+			}
+			frame, _ := virtualMachine.Segments.LoadData(virtualMachine.Segments.AddSegment(), &[]memory.MaybeRelocatable{*memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero())})
+			virtualMachine.RunContext = vm.RunContext{Pc: programAddress, Ap: frame, Fp: frame}
+			execScopes := types.NewExecutionScopes()
+			err = virtualMachine.Step(hintProcessor, &map[uint][]any{}, nil, execScopes)
+			if err != nil {
+				t.Errorf("Step failed with error: %s", err)
+				return
+			}
+			if len(execScopes.Scopes()) != 2 {
+				t.Errorf("The hint of the loaded program wasn't executed")
+			}
+			results[i] = virtualMachine
+		}(i)
+	}
+	wg.Wait()
+	for _, result := range results[1:] {
+		if result == nil || results[0] == nil {
+			return
+		}
+		if !reflect.DeepEqual(result.Segments.Memory.Data, results[0].Segments.Memory.Data) {
+			t.Errorf("The programs loaded concurrently differ")
+		}
+	}
+}
+
+func TestLoadProgramWithoutMain(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	programHeader := segments.AddSegment()
+	program := vm.Program{}
+	_, _, err := bootloader.LoadProgram(&program, &segments, programHeader)
+	if err == nil {
+		t.Errorf("LoadProgram should fail for a program without main")
+	}
+}
+
+func TestConfigureFactTopologies(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	outputBuiltin := builtins.NewOutputBuiltinRunner()
+	outputBuiltin.InitializeSegments(&segments)
+	factTopologies := []runners.FactTopology{
+		{TreeStructure: []uint{1, 0}, PageSizes: []uint{3}},
+		{TreeStructure: []uint{2, 1, 0, 2}, PageSizes: []uint{1, 2}},
+	}
+	nextPageId, err := bootloader.ConfigureFactTopologies(factTopologies, memory.NewRelocatable(0, 1), outputBuiltin)
+	if err != nil {
+		t.Fatalf("ConfigureFactTopologies failed with error: %s", err)
+	}
+	if nextPageId != 4 {
+		t.Errorf("Wrong next page id: %d", nextPageId)
+	}
+	expectedPages := map[uint]builtins.PublicMemoryPage{
+		1: {Start: 3, Size: 3},
+		2: {Start: 8, Size: 1},
+		3: {Start: 9, Size: 2},
+	}
+	if !reflect.DeepEqual(outputBuiltin.GetPages(), expectedPages) {
+		t.Errorf("Wrong pages. Expected %v, got %v", expectedPages, outputBuiltin.GetPages())
+	}
+}
+
+func insertRelocatables(t *testing.T, segments *memory.MemorySegmentManager, address memory.Relocatable, values []memory.Relocatable) {
+	for i, value := range values {
+		err := segments.Memory.Insert(address.AddUint(uint(i)), memory.NewMaybeRelocatableRelocatable(value))
+		if err != nil {
+			t.Fatalf("Insert failed with error: %s", err)
+		}
+	}
+}
+
+func setupReturnBuiltins(t *testing.T) (memory.MemorySegmentManager, memory.Relocatable, memory.Relocatable, memory.Relocatable) {
+	segments := memory.NewMemorySegmentManager()
+	preExecutionBuiltins := segments.AddSegment()
+	usedBuiltins := segments.AddSegment()
+	returnBuiltins := segments.AddSegment()
+	preExecutionValues := make([]memory.Relocatable, 0, len(bootloader.SIMPLE_BOOTLOADER_BUILTINS))
+	for i := range bootloader.SIMPLE_BOOTLOADER_BUILTINS {
+		preExecutionValues = append(preExecutionValues, memory.NewRelocatable(10+i, 0))
+	}
+	insertRelocatables(t, &segments, preExecutionBuiltins, preExecutionValues)
+	// Values returned by a task using the output and range_check builtins
+	insertRelocatables(t, &segments, usedBuiltins, []memory.Relocatable{memory.NewRelocatable(10, 3), memory.NewRelocatable(12, 5)})
+	return segments, returnBuiltins, usedBuiltins, preExecutionBuiltins
+}
+
+func TestWriteReturnBuiltins(t *testing.T) {
+	segments, returnBuiltins, usedBuiltins, preExecutionBuiltins := setupReturnBuiltins(t)
+	task := bootloader.Task{Program: vm.Program{Builtins: []string{"output", "range_check"}}}
+	err := bootloader.WriteReturnBuiltins(&task, &segments, returnBuiltins, usedBuiltins, preExecutionBuiltins)
+	if err != nil {
+		t.Fatalf("WriteReturnBuiltins failed with error: %s", err)
+	}
+	for i := range bootloader.SIMPLE_BOOTLOADER_BUILTINS {
+		expected := memory.NewRelocatable(10+i, 0)
+		switch i {
+		case 0:
+			expected = memory.NewRelocatable(10, 3)
+		case 2:
+			expected = memory.NewRelocatable(12, 5)
+		}
+		value, err := segments.Memory.GetRelocatable(returnBuiltins.AddUint(uint(i)))
+		if err != nil || value != expected {
+			t.Errorf("Wrong return builtin %d. Expected %v, got %v", i, expected, value)
+		}
+	}
+}
+
+func TestWriteReturnBuiltinsUnsupportedBuiltin(t *testing.T) {
+	segments, returnBuiltins, usedBuiltins, preExecutionBuiltins := setupReturnBuiltins(t)
+	task := bootloader.Task{Program: vm.Program{Builtins: []string{"output", "segment_arena"}}}
+	err := bootloader.WriteReturnBuiltins(&task, &segments, returnBuiltins, usedBuiltins, preExecutionBuiltins)
+	if err == nil {
+		t.Errorf("WriteReturnBuiltins should fail for builtins not supported by the bootloader")
+	}
+}
+
+func TestWriteReturnBuiltinsInconsistentPie(t *testing.T) {
+	segments, returnBuiltins, usedBuiltins, preExecutionBuiltins := setupReturnBuiltins(t)
+	pie := cairo_pie.CairoPie{Metadata: cairo_pie.CairoPieMetadata{
+		BuiltinSegments: map[string]cairo_pie.SegmentInfo{
+			"output":      {Index: 2, Size: 3},
+			"range_check": {Index: 3, Size: 4},
+		},
+	}}
+	task := bootloader.Task{Program: vm.Program{Builtins: []string{"output", "range_check"}}, CairoPie: &pie}
+	err := bootloader.WriteReturnBuiltins(&task, &segments, returnBuiltins, usedBuiltins, preExecutionBuiltins)
+	if err == nil {
+		t.Errorf("WriteReturnBuiltins should fail if the builtin usage doesn't match the pie")
+	}
+}
+
+func TestCheckProgramInputFilePaths(t *testing.T) {
+	allowedInputs := []string{
+		`{"tasks": [{"type": "RunProgramTask", "program": {}}]}`,
+		`[1, 2, 3]`,
+		`{"tasks": "not a bootloader input"}`,
+	}
+	for _, programInput := range allowedInputs {
+		err := bootloader.CheckProgramInputFilePaths([]byte(programInput))
+		if err != nil {
+			t.Errorf("CheckProgramInputFilePaths failed for %s: %s", programInput, err)
+		}
+	}
+	rejectedInputs := []string{
+		`{"tasks": [{"type": "RunProgramTask", "program": {}}, {"type": "CairoPiePath", "path": "/etc/passwd"}]}`,
+		`{"tasks": [], "fact_topologies_path": "/tmp/x"}`,
+	}
+	for _, programInput := range rejectedInputs {
+		err := bootloader.CheckProgramInputFilePaths([]byte(programInput))
+		if !errors.Is(err, bootloader.ErrFilePathInput) {
+			t.Errorf("Expected ErrFilePathInput for %s, got: %v", programInput, err)
+		}
+	}
+}
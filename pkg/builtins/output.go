@@ -2,19 +2,47 @@ package builtins
 
 import (
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
 )
 
 const OUTPUT_BUILTIN_NAME = "output"
 const OUTPUT_CELLS_PER_INSTANCE = 1
 
+// Name of the output builtin attribute holding the tree structure of the fact topology
+const GPS_FACT_TOPOLOGY = "gps_fact_topology"
+
+// A range of the output segment which is hashed as a separate page of the fact
+type PublicMemoryPage struct {
+	Start uint
+	Size  uint
+}
+
+// Additional data of the output builtin, exported as part of the run's result (and cairo PIEs)
+type OutputBuiltinAdditionalData struct {
+	Pages      map[uint]PublicMemoryPage
+	Attributes map[string][]uint
+}
+
+// Snapshot of the output builtin's base and additional data (see GetState)
+type OutputBuiltinState struct {
+	Base       memory.Relocatable
+	Pages      map[uint]PublicMemoryPage
+	Attributes map[string][]uint
+}
+
 type OutputBuiltinRunner struct {
-	base     memory.Relocatable
-	included bool
-	StopPtr  *uint
+	base       memory.Relocatable
+	included   bool
+	StopPtr    *uint
+	pages      map[uint]PublicMemoryPage
+	attributes map[string][]uint
 }
 
 func NewOutputBuiltinRunner() *OutputBuiltinRunner {
-	return &OutputBuiltinRunner{}
+	return &OutputBuiltinRunner{
+		pages:      make(map[uint]PublicMemoryPage),
+		attributes: make(map[string][]uint),
+	}
 }
 
 func (o *OutputBuiltinRunner) Base() memory.Relocatable {
@@ -149,3 +177,105 @@ func (r *OutputBuiltinRunner) CellsPerInstance() uint {
 func (b *OutputBuiltinRunner) InputCellsPerInstance() uint {
 	return OUTPUT_CELLS_PER_INSTANCE
 }
+
+// Adds a new page to the output's public memory. Page 0 is the default page, and can't be added explicitly.
+// The start of the page is stored relative to the builtin's base
+func (o *OutputBuiltinRunner) AddPage(pageId uint, pageStart memory.Relocatable, pageSize uint) error {
+	if pageStart.SegmentIndex != o.base.SegmentIndex || pageStart.Offset < o.base.Offset {
+		return errors.Errorf("page_start must be in the output segment (start=%s, base=%s)", pageStart.ToString(), o.base.ToString())
+	}
+	if _, ok := o.pages[pageId]; ok || pageId == 0 {
+		return errors.Errorf("Page %d was already used", pageId)
+	}
+	o.pages[pageId] = PublicMemoryPage{Start: pageStart.Offset - o.base.Offset, Size: pageSize}
+	return nil
+}
+
+// Adds an attribute to the output builtin, such as the gps_fact_topology used to build the fact's tree
+func (o *OutputBuiltinRunner) AddAttribute(name string, value []uint) error {
+	if _, ok := o.attributes[name]; ok {
+		return errors.Errorf("Attribute %s was already set", name)
+	}
+	o.attributes[name] = value
+	return nil
+}
+
+func (o *OutputBuiltinRunner) GetPages() map[uint]PublicMemoryPage {
+	return o.pages
+}
+
+func (o *OutputBuiltinRunner) GetAttributes() map[string][]uint {
+	return o.attributes
+}
+
+func (o *OutputBuiltinRunner) GetAdditionalData() OutputBuiltinAdditionalData {
+	pages := make(map[uint]PublicMemoryPage, len(o.pages))
+	for pageId, page := range o.pages {
+		pages[pageId] = page
+	}
+	attributes := make(map[string][]uint, len(o.attributes))
+	for name, value := range o.attributes {
+		attributes[name] = append([]uint{}, value...)
+	}
+	return OutputBuiltinAdditionalData{Pages: pages, Attributes: attributes}
+}
+
+// Adds the pages and attributes of the given additional data to the builtin. Fails if any of them was already set
+func (o *OutputBuiltinRunner) ExtendAdditionalData(data OutputBuiltinAdditionalData) error {
+	for pageId, page := range data.Pages {
+		err := o.AddPage(pageId, o.base.AddUint(page.Start), page.Size)
+		if err != nil {
+			return err
+		}
+	}
+	for name, value := range data.Attributes {
+		err := o.AddAttribute(name, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Removes all pages and attributes
+func (o *OutputBuiltinRunner) ClearAdditionalData() {
+	o.pages = make(map[uint]PublicMemoryPage)
+	o.attributes = make(map[string][]uint)
+}
+
+// Returns the current base, pages and attributes of the builtin, so that they can be restored with SetState
+func (o *OutputBuiltinRunner) GetState() OutputBuiltinState {
+	return OutputBuiltinState{Base: o.base, Pages: o.pages, Attributes: o.attributes}
+}
+
+func (o *OutputBuiltinRunner) SetState(state OutputBuiltinState) {
+	o.base = state.Base
+	o.pages = state.Pages
+	o.attributes = state.Attributes
+}
+
+// Moves the builtin's base to the given address and clears its pages and attributes.
+// Used by the bootloader so that the pages of each task are recorded relative to the start of the task's output
+func (o *OutputBuiltinRunner) NewState(base memory.Relocatable) {
+	o.base = base
+	o.ClearAdditionalData()
+}
+
+// Returns the output's public memory: every used cell, along with the page it belongs to
+func (o *OutputBuiltinRunner) GetPublicMemory(segments *memory.MemorySegmentManager) ([]memory.PublicMemoryOffset, error) {
+	size, _, err := o.GetUsedCellsAndAllocatedSizes(segments, 0)
+	if err != nil {
+		return nil, err
+	}
+	pageIdByOffset := make(map[uint]uint)
+	for pageId, page := range o.pages {
+		for offset := page.Start; offset < page.Start+page.Size; offset++ {
+			pageIdByOffset[offset] = pageId
+		}
+	}
+	publicMemory := make([]memory.PublicMemoryOffset, 0, size)
+	for i := uint(0); i < size; i++ {
+		publicMemory = append(publicMemory, memory.PublicMemoryOffset{Offset: i, Page: pageIdByOffset[i]})
+	}
+	return publicMemory, nil
+}
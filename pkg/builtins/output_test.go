@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
 )
@@ -72,3 +73,120 @@ func TestGetAllocatedMemoryUnitsOutput(t *testing.T) {
 		t.Errorf("expected memory units to be 5, got: %d", mem_units)
 	}
 }
+
+func TestOutputAddPage(t *testing.T) {
+	output := builtins.NewOutputBuiltinRunner()
+	err := output.AddPage(1, memory.NewRelocatable(0, 2), 3)
+	if err != nil {
+		t.Fatalf("AddPage failed with error: %s", err)
+	}
+	expected := map[uint]builtins.PublicMemoryPage{1: {Start: 2, Size: 3}}
+	if !reflect.DeepEqual(output.GetPages(), expected) {
+		t.Errorf("Wrong pages. Expected %v, got %v", expected, output.GetPages())
+	}
+}
+
+func TestOutputAddPageErrors(t *testing.T) {
+	output := builtins.NewOutputBuiltinRunner()
+	if output.AddPage(1, memory.NewRelocatable(1, 0), 1) == nil {
+		t.Errorf("AddPage should fail if the page is outside the output segment")
+	}
+	if output.AddPage(0, memory.NewRelocatable(0, 0), 1) == nil {
+		t.Errorf("AddPage should fail for page 0")
+	}
+	if output.AddPage(1, memory.NewRelocatable(0, 0), 1) != nil {
+		t.Errorf("AddPage should succeed for a new page")
+	}
+	if output.AddPage(1, memory.NewRelocatable(0, 1), 1) == nil {
+		t.Errorf("AddPage should fail for a duplicated page")
+	}
+}
+
+func TestOutputAddAttribute(t *testing.T) {
+	output := builtins.NewOutputBuiltinRunner()
+	err := output.AddAttribute(builtins.GPS_FACT_TOPOLOGY, []uint{2, 1, 0, 2})
+	if err != nil {
+		t.Fatalf("AddAttribute failed with error: %s", err)
+	}
+	if output.AddAttribute(builtins.GPS_FACT_TOPOLOGY, []uint{1, 0}) == nil {
+		t.Errorf("AddAttribute should fail for a duplicated attribute")
+	}
+	expected := map[string][]uint{builtins.GPS_FACT_TOPOLOGY: {2, 1, 0, 2}}
+	if !reflect.DeepEqual(output.GetAttributes(), expected) {
+		t.Errorf("Wrong attributes. Expected %v, got %v", expected, output.GetAttributes())
+	}
+}
+
+func TestOutputExtendAndClearAdditionalData(t *testing.T) {
+	output := builtins.NewOutputBuiltinRunner()
+	data := builtins.OutputBuiltinAdditionalData{
+		Pages:      map[uint]builtins.PublicMemoryPage{1: {Start: 1, Size: 2}},
+		Attributes: map[string][]uint{builtins.GPS_FACT_TOPOLOGY: {2, 1, 0, 2}},
+	}
+	err := output.ExtendAdditionalData(data)
+	if err != nil {
+		t.Fatalf("ExtendAdditionalData failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(output.GetAdditionalData(), data) {
+		t.Errorf("Wrong additional data. Expected %v, got %v", data, output.GetAdditionalData())
+	}
+	if output.ExtendAdditionalData(data) == nil {
+		t.Errorf("ExtendAdditionalData should fail if the pages were already added")
+	}
+	output.ClearAdditionalData()
+	if len(output.GetPages()) != 0 || len(output.GetAttributes()) != 0 {
+		t.Errorf("ClearAdditionalData should remove all pages and attributes")
+	}
+}
+
+func TestOutputGetPublicMemory(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	output := builtins.NewOutputBuiltinRunner()
+	output.InitializeSegments(&segments)
+	for i := uint(0); i < 4; i++ {
+		segments.Memory.Insert(memory.NewRelocatable(0, i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i))))
+	}
+	segments.ComputeEffectiveSizes()
+	err := output.AddPage(1, memory.NewRelocatable(0, 2), 2)
+	if err != nil {
+		t.Fatalf("AddPage failed with error: %s", err)
+	}
+	publicMemory, err := output.GetPublicMemory(&segments)
+	if err != nil {
+		t.Fatalf("GetPublicMemory failed with error: %s", err)
+	}
+	expected := []memory.PublicMemoryOffset{{Offset: 0, Page: 0}, {Offset: 1, Page: 0}, {Offset: 2, Page: 1}, {Offset: 3, Page: 1}}
+	if !reflect.DeepEqual(publicMemory, expected) {
+		t.Errorf("Wrong public memory. Expected %v, got %v", expected, publicMemory)
+	}
+}
+
+func TestOutputGetAndSetState(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	output := builtins.NewOutputBuiltinRunner()
+	output.InitializeSegments(&segments)
+	err := output.AddPage(1, memory.NewRelocatable(0, 1), 2)
+	if err != nil {
+		t.Fatalf("AddPage failed with error: %s", err)
+	}
+	state := output.GetState()
+
+	output.NewState(memory.NewRelocatable(0, 5))
+	if output.Base() != memory.NewRelocatable(0, 5) || len(output.GetPages()) != 0 {
+		t.Errorf("NewState should move the base and clear the pages")
+	}
+	err = output.AddPage(1, memory.NewRelocatable(0, 6), 1)
+	if err != nil {
+		t.Fatalf("AddPage failed with error: %s", err)
+	}
+	expectedPages := map[uint]builtins.PublicMemoryPage{1: {Start: 1, Size: 1}}
+	if !reflect.DeepEqual(output.GetPages(), expectedPages) {
+		t.Errorf("Wrong pages. Expected %v, got %v", expectedPages, output.GetPages())
+	}
+
+	output.SetState(state)
+	expectedPages = map[uint]builtins.PublicMemoryPage{1: {Start: 1, Size: 2}}
+	if output.Base() != memory.NewRelocatable(0, 0) || !reflect.DeepEqual(output.GetPages(), expectedPages) {
+		t.Errorf("SetState should restore the base and pages")
+	}
+}
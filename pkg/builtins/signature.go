@@ -177,6 +177,11 @@ func (r *SignatureBuiltinRunner) AddSignature(
 	r.signatures[address] = signature
 }
 
+// Returns the signatures added to the builtin, indexed by the address of their public key
+func (r *SignatureBuiltinRunner) GetSignatures() map[memory.Relocatable]Signature {
+	return r.signatures
+}
+
 func (runner *SignatureBuiltinRunner) GetMemoryAccesses(manager *memory.MemorySegmentManager) ([]memory.Relocatable, error) {
 	segmentSize, err := manager.GetSegmentSize(uint(runner.Base().SegmentIndex))
 	if err != nil {
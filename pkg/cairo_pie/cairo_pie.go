@@ -0,0 +1,515 @@
+package cairo_pie
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"os"
+	"reflect"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+const (
+	METADATA_FILE            = "metadata.json"
+	MEMORY_FILE              = "memory.bin"
+	ADDITIONAL_DATA_FILE     = "additional_data.json"
+	EXECUTION_RESOURCES_FILE = "execution_resources.json"
+	VERSION_FILE             = "version.json"
+)
+
+// Version of the pie format produced by the vm
+const CAIRO_PIE_VERSION = "1.1"
+
+// Sizes of the encoded addresses and values in the memory file
+const (
+	ADDR_SIZE_IN_BYTES  = 8
+	FIELD_SIZE_IN_BYTES = 32
+)
+
+// Relocatable values are encoded as 2^(8*n_bytes-1) + segment_index * 2^OFFSET_BITS + offset
+const (
+	SEGMENT_BITS = 16
+	OFFSET_BITS  = 47
+)
+
+type SegmentInfo struct {
+	Index int  `json:"index"`
+	Size  uint `json:"size"`
+}
+
+// The part of the program that is kept in the pie: its bytecode, builtins and main offset
+type StrippedProgram struct {
+	Data     []string `json:"data"`
+	Builtins []string `json:"builtins"`
+	Main     uint     `json:"main"`
+	Prime    string   `json:"prime"`
+}
+
+type CairoPieMetadata struct {
+	Program          StrippedProgram        `json:"program"`
+	ProgramSegment   SegmentInfo            `json:"program_segment"`
+	ExecutionSegment SegmentInfo            `json:"execution_segment"`
+	RetFpSegment     SegmentInfo            `json:"ret_fp_segment"`
+	RetPcSegment     SegmentInfo            `json:"ret_pc_segment"`
+	BuiltinSegments  map[string]SegmentInfo `json:"builtin_segments"`
+	ExtraSegments    []SegmentInfo          `json:"extra_segments"`
+}
+
+type ExecutionResources struct {
+	NSteps                 uint            `json:"n_steps"`
+	NMemoryHoles           uint            `json:"n_memory_holes"`
+	BuiltinInstanceCounter map[string]uint `json:"builtin_instance_counter"`
+}
+
+type MemoryCell struct {
+	Address memory.Relocatable
+	Value   memory.MaybeRelocatable
+}
+
+// A Cairo Position Independent Execution: the (unrelocated) memory of a run, along with the information needed
+// to relocate it and re-execute the program, as produced by cairo-lang's CairoPie
+type CairoPie struct {
+	Metadata           CairoPieMetadata
+	Memory             []MemoryCell
+	AdditionalData     map[string]json.RawMessage
+	ExecutionResources ExecutionResources
+	Version            map[string]string
+}
+
+func CairoPieError(err error) error {
+	return errors.Wrapf(err, "Cairo PIE error")
+}
+
+// Reads a Cairo PIE from a zip file
+func ParseCairoPie(path string) (*CairoPie, error) {
+	pieFile, err := os.Open(path)
+	if err != nil {
+		return nil, CairoPieError(err)
+	}
+	defer pieFile.Close()
+
+	pieBytes, err := io.ReadAll(pieFile)
+	if err != nil {
+		return nil, CairoPieError(err)
+	}
+	return CairoPieFromBytes(pieBytes)
+}
+
+// Reads a Cairo PIE from the contents of a zip file
+func CairoPieFromBytes(pieBytes []byte) (*CairoPie, error) {
+	archive, err := zip.NewReader(bytes.NewReader(pieBytes), int64(len(pieBytes)))
+	if err != nil {
+		return nil, CairoPieError(err)
+	}
+
+	var pie CairoPie
+	err = readZipJson(archive, METADATA_FILE, &pie.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	err = readZipJson(archive, ADDITIONAL_DATA_FILE, &pie.AdditionalData)
+	if err != nil {
+		return nil, err
+	}
+	err = readZipJson(archive, EXECUTION_RESOURCES_FILE, &pie.ExecutionResources)
+	if err != nil {
+		return nil, err
+	}
+	// Older pies don't include a version file
+	if _, err := archive.Open(VERSION_FILE); err == nil {
+		err = readZipJson(archive, VERSION_FILE, &pie.Version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	memoryBytes, err := readZipFile(archive, MEMORY_FILE)
+	if err != nil {
+		return nil, err
+	}
+	pie.Memory, err = DeserializeMemory(memoryBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &pie, nil
+}
+
+// Writes the Cairo PIE as a zip file, in the format read by CairoPieFromBytes and cairo-lang's CairoPie.from_file
+func (p *CairoPie) WriteZip(w io.Writer) error {
+	archive := zip.NewWriter(w)
+	files := []struct {
+		name  string
+		value any
+	}{
+		{METADATA_FILE, p.Metadata},
+		{ADDITIONAL_DATA_FILE, p.AdditionalData},
+		{EXECUTION_RESOURCES_FILE, p.ExecutionResources},
+		{VERSION_FILE, p.Version},
+	}
+	for _, file := range files {
+		contents, err := json.Marshal(file.value)
+		if err != nil {
+			return CairoPieError(err)
+		}
+		err = writeZipFile(archive, file.name, contents)
+		if err != nil {
+			return err
+		}
+	}
+	err := writeZipFile(archive, MEMORY_FILE, SerializeMemory(p.Memory))
+	if err != nil {
+		return err
+	}
+	return CairoPieError(archive.Close())
+}
+
+func writeZipFile(archive *zip.Writer, name string, contents []byte) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return CairoPieError(err)
+	}
+	_, err = file.Write(contents)
+	if err != nil {
+		return CairoPieError(err)
+	}
+	return nil
+}
+
+func readZipFile(archive *zip.Reader, name string) ([]byte, error) {
+	file, err := archive.Open(name)
+	if err != nil {
+		return nil, CairoPieError(errors.Wrapf(err, "Missing %s", name))
+	}
+	defer file.Close()
+	contents, err := io.ReadAll(file)
+	if err != nil {
+		return nil, CairoPieError(err)
+	}
+	return contents, nil
+}
+
+func readZipJson(archive *zip.Reader, name string, value any) error {
+	contents, err := readZipFile(archive, name)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(contents, value)
+	if err != nil {
+		return CairoPieError(errors.Wrapf(err, "Invalid %s", name))
+	}
+	return nil
+}
+
+// Decodes a value encoded as cairo-lang's RelocatableValue.to_bytes (little endian)
+func decodeMaybeRelocatable(data []byte) memory.MaybeRelocatable {
+	bigEndian := make([]byte, len(data))
+	for i := range data {
+		bigEndian[len(data)-1-i] = data[i]
+	}
+	num := new(big.Int).SetBytes(bigEndian)
+	relocatableFlagBit := 8*len(data) - 1
+	if num.Bit(relocatableFlagBit) == 0 {
+		return *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromBigInt(num))
+	}
+	offsetMask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), OFFSET_BITS), big.NewInt(1))
+	segmentMask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), SEGMENT_BITS), big.NewInt(1))
+	offset := new(big.Int).And(num, offsetMask)
+	segmentIndex := new(big.Int).And(new(big.Int).Rsh(num, OFFSET_BITS), segmentMask)
+	return *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(int(segmentIndex.Int64()), uint(offset.Uint64())))
+}
+
+// Encodes a value as cairo-lang's RelocatableValue.to_bytes (little endian), the inverse of decodeMaybeRelocatable
+func encodeMaybeRelocatable(value memory.MaybeRelocatable, size int) []byte {
+	var num *big.Int
+	if relocatable, ok := value.GetRelocatable(); ok {
+		num = new(big.Int).Lsh(big.NewInt(1), uint(8*size-1))
+		num.Add(num, new(big.Int).Lsh(big.NewInt(int64(relocatable.SegmentIndex)), OFFSET_BITS))
+		num.Add(num, new(big.Int).SetUint64(uint64(relocatable.Offset)))
+	} else {
+		felt, _ := value.GetFelt()
+		num = felt.ToBigInt()
+	}
+	bigEndian := num.FillBytes(make([]byte, size))
+	data := make([]byte, size)
+	for i := range bigEndian {
+		data[size-1-i] = bigEndian[i]
+	}
+	return data
+}
+
+// Encodes memory cells in the format of a pie's memory file, see DeserializeMemory
+func SerializeMemory(cells []MemoryCell) []byte {
+	data := make([]byte, 0, len(cells)*(ADDR_SIZE_IN_BYTES+FIELD_SIZE_IN_BYTES))
+	for _, cell := range cells {
+		data = append(data, encodeMaybeRelocatable(*memory.NewMaybeRelocatableRelocatable(cell.Address), ADDR_SIZE_IN_BYTES)...)
+		data = append(data, encodeMaybeRelocatable(cell.Value, FIELD_SIZE_IN_BYTES)...)
+	}
+	return data
+}
+
+// Decodes the contents of a pie's memory file: a sequence of (address, value) pairs, encoded
+// in ADDR_SIZE_IN_BYTES and FIELD_SIZE_IN_BYTES bytes respectively
+func DeserializeMemory(data []byte) ([]MemoryCell, error) {
+	cellSize := ADDR_SIZE_IN_BYTES + FIELD_SIZE_IN_BYTES
+	if len(data)%cellSize != 0 {
+		return nil, CairoPieError(errors.Errorf("Memory file size %d is not a multiple of %d", len(data), cellSize))
+	}
+	cells := make([]MemoryCell, 0, len(data)/cellSize)
+	for i := 0; i < len(data); i += cellSize {
+		encodedAddress := decodeMaybeRelocatable(data[i : i+ADDR_SIZE_IN_BYTES])
+		address, ok := encodedAddress.GetRelocatable()
+		if !ok {
+			return nil, CairoPieError(errors.Errorf("Memory address at position %d is not relocatable", i/cellSize))
+		}
+		value := decodeMaybeRelocatable(data[i+ADDR_SIZE_IN_BYTES : i+cellSize])
+		cells = append(cells, MemoryCell{Address: address, Value: value})
+	}
+	return cells, nil
+}
+
+// Returns the program stored in the pie. As only the main offset is kept, it is the only identifier of the program
+func (p *CairoPie) Program() vm.Program {
+	data := make([]memory.MaybeRelocatable, 0, len(p.Metadata.Program.Data))
+	for _, value := range p.Metadata.Program.Data {
+		data = append(data, *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex(value)))
+	}
+	return vm.Program{
+		Data:     data,
+		Builtins: p.Metadata.Program.Builtins,
+		Identifiers: map[string]vm.Identifier{
+			"__main__.main": {PC: int(p.Metadata.Program.Main), Type: "function"},
+		},
+	}
+}
+
+// Returns the output builtin's pages and attributes, or empty additional data if the pie doesn't have any
+func (p *CairoPie) OutputBuiltinAdditionalData() (builtins.OutputBuiltinAdditionalData, error) {
+	data := builtins.OutputBuiltinAdditionalData{
+		Pages:      make(map[uint]builtins.PublicMemoryPage),
+		Attributes: make(map[string][]uint),
+	}
+	rawData, ok := p.AdditionalData[builtins.OUTPUT_BUILTIN_NAME+"_builtin"]
+	if !ok {
+		return data, nil
+	}
+	var outputData struct {
+		Pages      map[uint][2]uint  `json:"pages"`
+		Attributes map[string][]uint `json:"attributes"`
+	}
+	err := json.Unmarshal(rawData, &outputData)
+	if err != nil {
+		return data, CairoPieError(errors.Wrapf(err, "Invalid output builtin additional data"))
+	}
+	for pageId, page := range outputData.Pages {
+		data.Pages[pageId] = builtins.PublicMemoryPage{Start: page[0], Size: page[1]}
+	}
+	for name, value := range outputData.Attributes {
+		data.Attributes[name] = value
+	}
+	return data, nil
+}
+
+// Encodes the output builtin's pages and attributes the way cairo-lang stores them in the pie
+func EncodeOutputBuiltinAdditionalData(data builtins.OutputBuiltinAdditionalData) (json.RawMessage, error) {
+	pages := make(map[uint][2]uint, len(data.Pages))
+	for pageId, page := range data.Pages {
+		pages[pageId] = [2]uint{page.Start, page.Size}
+	}
+	attributes := data.Attributes
+	if attributes == nil {
+		attributes = make(map[string][]uint)
+	}
+	encoded, err := json.Marshal(map[string]any{"pages": pages, "attributes": attributes})
+	if err != nil {
+		return nil, CairoPieError(err)
+	}
+	return encoded, nil
+}
+
+// Encodes the signatures of the ecdsa builtin the way cairo-lang stores them in the pie
+func EncodeSignatureBuiltinAdditionalData(signatures map[memory.Relocatable]builtins.Signature) (json.RawMessage, error) {
+	entries := make([][2][2]json.Number, 0, len(signatures))
+	for address, signature := range signatures {
+		entries = append(entries, [2][2]json.Number{
+			{json.Number(big.NewInt(int64(address.SegmentIndex)).String()), json.Number(new(big.Int).SetUint64(uint64(address.Offset)).String())},
+			{json.Number(signature.R.ToBigInt().String()), json.Number(signature.S.ToBigInt().String())},
+		})
+	}
+	encoded, err := json.Marshal(entries)
+	if err != nil {
+		return nil, CairoPieError(err)
+	}
+	return encoded, nil
+}
+
+// Returns the signatures added to the ecdsa builtin, indexed by the address of the public key
+func (p *CairoPie) SignatureBuiltinAdditionalData() (map[memory.Relocatable]builtins.Signature, error) {
+	signatures := make(map[memory.Relocatable]builtins.Signature)
+	rawData, ok := p.AdditionalData[builtins.SIGNATURE_BUILTIN_NAME+"_builtin"]
+	if !ok {
+		return signatures, nil
+	}
+	// Encoded as a list of [[segment_index, offset], [r, s]]
+	var entries [][2][2]json.Number
+	err := json.Unmarshal(rawData, &entries)
+	if err != nil {
+		return nil, CairoPieError(errors.Wrapf(err, "Invalid ecdsa builtin additional data"))
+	}
+	for _, entry := range entries {
+		segmentIndex, err := entry[0][0].Int64()
+		if err != nil {
+			return nil, CairoPieError(err)
+		}
+		offset, err := entry[0][1].Int64()
+		if err != nil {
+			return nil, CairoPieError(err)
+		}
+		signatures[memory.NewRelocatable(int(segmentIndex), uint(offset))] = builtins.Signature{
+			R: lambdaworks.FeltFromDecString(entry[1][0].String()),
+			S: lambdaworks.FeltFromDecString(entry[1][1].String()),
+		}
+	}
+	return signatures, nil
+}
+
+// Maps a value of the pie's memory to the address space of the vm it is being loaded into, given the new
+// location of each of the pie's segments. Felts are left unchanged
+func RelocateValue(value memory.MaybeRelocatable, segmentOffsets map[int]memory.Relocatable) (memory.MaybeRelocatable, error) {
+	relocatable, ok := value.GetRelocatable()
+	if !ok {
+		return value, nil
+	}
+	relocated, err := RelocateAddress(relocatable, segmentOffsets)
+	if err != nil {
+		return memory.MaybeRelocatable{}, err
+	}
+	return *memory.NewMaybeRelocatableRelocatable(relocated), nil
+}
+
+func RelocateAddress(address memory.Relocatable, segmentOffsets map[int]memory.Relocatable) (memory.Relocatable, error) {
+	segmentStart, ok := segmentOffsets[address.SegmentIndex]
+	if !ok {
+		return memory.Relocatable{}, CairoPieError(errors.Errorf("No relocation found for segment %d", address.SegmentIndex))
+	}
+	return segmentStart.AddUint(address.Offset), nil
+}
+
+// Checks that two pies describe the same execution: same metadata, memory, execution resources and builtin
+// additional data. Only the additional data of the output and ecdsa builtins is compared, as it is the only one
+// tracked by the vm
+func (p *CairoPie) CheckCompatibility(other *CairoPie) error {
+	err := p.Metadata.checkCompatibility(&other.Metadata)
+	if err != nil {
+		return CairoPieError(err)
+	}
+
+	if len(p.Memory) != len(other.Memory) {
+		return CairoPieError(errors.Errorf("Memory size mismatch: %d != %d", len(p.Memory), len(other.Memory)))
+	}
+	otherMemory := make(map[memory.Relocatable]memory.MaybeRelocatable, len(other.Memory))
+	for _, cell := range other.Memory {
+		otherMemory[cell.Address] = cell.Value
+	}
+	for _, cell := range p.Memory {
+		otherValue, ok := otherMemory[cell.Address]
+		if !ok || otherValue != cell.Value {
+			return CairoPieError(errors.Errorf("Memory mismatch at address %s", cell.Address.ToString()))
+		}
+	}
+
+	if p.ExecutionResources.NSteps != other.ExecutionResources.NSteps ||
+		p.ExecutionResources.NMemoryHoles != other.ExecutionResources.NMemoryHoles ||
+		!reflect.DeepEqual(usedBuiltinInstances(p.ExecutionResources), usedBuiltinInstances(other.ExecutionResources)) {
+		return CairoPieError(errors.Errorf("Execution resources mismatch: %+v != %+v", p.ExecutionResources, other.ExecutionResources))
+	}
+
+	outputData, err := p.OutputBuiltinAdditionalData()
+	if err != nil {
+		return err
+	}
+	otherOutputData, err := other.OutputBuiltinAdditionalData()
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(outputData, otherOutputData) {
+		return CairoPieError(errors.New("Output builtin additional data mismatch"))
+	}
+	signatures, err := p.SignatureBuiltinAdditionalData()
+	if err != nil {
+		return err
+	}
+	otherSignatures, err := other.SignatureBuiltinAdditionalData()
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(signatures, otherSignatures) {
+		return CairoPieError(errors.New("Ecdsa builtin additional data mismatch"))
+	}
+	return nil
+}
+
+func (m *CairoPieMetadata) checkCompatibility(other *CairoPieMetadata) error {
+	if len(m.Program.Data) != len(other.Program.Data) {
+		return errors.New("Program data length mismatch")
+	}
+	for i := range m.Program.Data {
+		if lambdaworks.FeltFromHex(m.Program.Data[i]) != lambdaworks.FeltFromHex(other.Program.Data[i]) {
+			return errors.Errorf("Program data mismatch at offset %d", i)
+		}
+	}
+	if !reflect.DeepEqual(m.Program.Builtins, other.Program.Builtins) || m.Program.Main != other.Program.Main {
+		return errors.New("Program mismatch")
+	}
+	prime, _ := new(big.Int).SetString(m.Program.Prime, 0)
+	otherPrime, _ := new(big.Int).SetString(other.Program.Prime, 0)
+	if prime == nil || otherPrime == nil || prime.Cmp(otherPrime) != 0 {
+		return errors.Errorf("Prime mismatch: %s != %s", m.Program.Prime, other.Program.Prime)
+	}
+
+	segments := map[string][2]SegmentInfo{
+		"program":   {m.ProgramSegment, other.ProgramSegment},
+		"execution": {m.ExecutionSegment, other.ExecutionSegment},
+		"ret_fp":    {m.RetFpSegment, other.RetFpSegment},
+		"ret_pc":    {m.RetPcSegment, other.RetPcSegment},
+	}
+	for name, pair := range segments {
+		if pair[0] != pair[1] {
+			return errors.Errorf("%s segment mismatch: %+v != %+v", name, pair[0], pair[1])
+		}
+	}
+	if len(m.BuiltinSegments) != len(other.BuiltinSegments) {
+		return errors.New("Builtin segments mismatch")
+	}
+	for name, segment := range m.BuiltinSegments {
+		if other.BuiltinSegments[name] != segment {
+			return errors.Errorf("%s builtin segment mismatch", name)
+		}
+	}
+	if len(m.ExtraSegments) != len(other.ExtraSegments) {
+		return errors.New("Extra segments mismatch")
+	}
+	for i := range m.ExtraSegments {
+		if m.ExtraSegments[i] != other.ExtraSegments[i] {
+			return errors.Errorf("Extra segment mismatch: %+v != %+v", m.ExtraSegments[i], other.ExtraSegments[i])
+		}
+	}
+	return nil
+}
+
+// Builtins with no used instances may or may not be listed in the execution resources
+func usedBuiltinInstances(resources ExecutionResources) map[string]uint {
+	used := make(map[string]uint)
+	for name, instances := range resources.BuiltinInstanceCounter {
+		if instances != 0 {
+			used[name] = instances
+		}
+	}
+	return used
+}
@@ -0,0 +1,195 @@
+package cairo_pie_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/cairo_pie"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// Encodes a number in little endian using nBytes bytes
+func littleEndian(num *big.Int, nBytes int) []byte {
+	bigEndian := num.FillBytes(make([]byte, nBytes))
+	encoded := make([]byte, nBytes)
+	for i := range bigEndian {
+		encoded[nBytes-1-i] = bigEndian[i]
+	}
+	return encoded
+}
+
+func encodeRelocatable(segmentIndex int, offset uint, nBytes int) []byte {
+	num := new(big.Int).Lsh(big.NewInt(1), uint(8*nBytes-1))
+	num.Add(num, new(big.Int).Lsh(big.NewInt(int64(segmentIndex)), cairo_pie.OFFSET_BITS))
+	num.Add(num, new(big.Int).SetUint64(uint64(offset)))
+	return littleEndian(num, nBytes)
+}
+
+func encodedMemory() []byte {
+	var data []byte
+	data = append(data, encodeRelocatable(0, 0, cairo_pie.ADDR_SIZE_IN_BYTES)...)
+	data = append(data, littleEndian(big.NewInt(17), cairo_pie.FIELD_SIZE_IN_BYTES)...)
+	data = append(data, encodeRelocatable(1, 3, cairo_pie.ADDR_SIZE_IN_BYTES)...)
+	data = append(data, encodeRelocatable(2, 5, cairo_pie.FIELD_SIZE_IN_BYTES)...)
+	return data
+}
+
+func TestDeserializeMemory(t *testing.T) {
+	cells, err := cairo_pie.DeserializeMemory(encodedMemory())
+	if err != nil {
+		t.Fatalf("DeserializeMemory failed with error: %s", err)
+	}
+	expected := []cairo_pie.MemoryCell{
+		{Address: memory.NewRelocatable(0, 0), Value: *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(17))},
+		{Address: memory.NewRelocatable(1, 3), Value: *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(2, 5))},
+	}
+	if !reflect.DeepEqual(cells, expected) {
+		t.Errorf("Wrong memory. Expected %v, got %v", expected, cells)
+	}
+}
+
+func TestDeserializeMemoryInvalidSize(t *testing.T) {
+	_, err := cairo_pie.DeserializeMemory(make([]byte, cairo_pie.ADDR_SIZE_IN_BYTES))
+	if err == nil {
+		t.Errorf("DeserializeMemory should fail for a truncated memory file")
+	}
+}
+
+func TestDeserializeMemoryFeltAddress(t *testing.T) {
+	_, err := cairo_pie.DeserializeMemory(make([]byte, cairo_pie.ADDR_SIZE_IN_BYTES+cairo_pie.FIELD_SIZE_IN_BYTES))
+	if err == nil {
+		t.Errorf("DeserializeMemory should fail for non-relocatable addresses")
+	}
+}
+
+func zipFiles(t *testing.T, files map[string][]byte) []byte {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, contents := range files {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create zip file: %s", err)
+		}
+		_, err = file.Write(contents)
+		if err != nil {
+			t.Fatalf("Failed to write zip file: %s", err)
+		}
+	}
+	err := writer.Close()
+	if err != nil {
+		t.Fatalf("Failed to close zip: %s", err)
+	}
+	return buffer.Bytes()
+}
+
+func TestCairoPieFromBytes(t *testing.T) {
+	pieBytes := zipFiles(t, map[string][]byte{
+		cairo_pie.METADATA_FILE: []byte(`{
+			"program": {"data": ["0x11", "0x22"], "builtins": ["output"], "main": 1, "prime": "0x800000000000011000000000000000000000000000000000000000000000001"},
+			"program_segment": {"index": 0, "size": 2},
+			"execution_segment": {"index": 1, "size": 4},
+			"ret_fp_segment": {"index": 3, "size": 0},
+			"ret_pc_segment": {"index": 4, "size": 0},
+			"builtin_segments": {"output": {"index": 2, "size": 6}},
+			"extra_segments": []
+		}`),
+		cairo_pie.MEMORY_FILE:              encodedMemory(),
+		cairo_pie.ADDITIONAL_DATA_FILE:     []byte(`{"output_builtin": {"pages": {"1": [2, 4]}, "attributes": {"gps_fact_topology": [2, 1, 0, 2]}}}`),
+		cairo_pie.EXECUTION_RESOURCES_FILE: []byte(`{"n_steps": 10, "n_memory_holes": 0, "builtin_instance_counter": {"output_builtin": 6}}`),
+	})
+	pie, err := cairo_pie.CairoPieFromBytes(pieBytes)
+	if err != nil {
+		t.Fatalf("CairoPieFromBytes failed with error: %s", err)
+	}
+	if pie.Metadata.BuiltinSegments["output"] != (cairo_pie.SegmentInfo{Index: 2, Size: 6}) || pie.ExecutionResources.NSteps != 10 {
+		t.Errorf("Wrong pie contents: %+v", pie)
+	}
+	if len(pie.Memory) != 2 {
+		t.Errorf("Wrong pie memory: %v", pie.Memory)
+	}
+
+	program := pie.Program()
+	if len(program.Data) != 2 || program.Identifiers["__main__.main"].PC != 1 || !reflect.DeepEqual(program.Builtins, []string{"output"}) {
+		t.Errorf("Wrong pie program: %+v", program)
+	}
+
+	outputData, err := pie.OutputBuiltinAdditionalData()
+	if err != nil {
+		t.Fatalf("OutputBuiltinAdditionalData failed with error: %s", err)
+	}
+	expectedPages := map[uint]builtins.PublicMemoryPage{1: {Start: 2, Size: 4}}
+	if !reflect.DeepEqual(outputData.Pages, expectedPages) {
+		t.Errorf("Wrong pages. Expected %v, got %v", expectedPages, outputData.Pages)
+	}
+	expectedAttributes := map[string][]uint{builtins.GPS_FACT_TOPOLOGY: {2, 1, 0, 2}}
+	if !reflect.DeepEqual(outputData.Attributes, expectedAttributes) {
+		t.Errorf("Wrong attributes. Expected %v, got %v", expectedAttributes, outputData.Attributes)
+	}
+}
+
+func TestCairoPieFromBytesMissingFile(t *testing.T) {
+	pieBytes := zipFiles(t, map[string][]byte{cairo_pie.METADATA_FILE: []byte(`{}`)})
+	_, err := cairo_pie.CairoPieFromBytes(pieBytes)
+	if err == nil {
+		t.Errorf("CairoPieFromBytes should fail for a pie without additional data")
+	}
+}
+
+func TestRelocateValue(t *testing.T) {
+	segmentOffsets := map[int]memory.Relocatable{1: memory.NewRelocatable(5, 10)}
+	value, err := cairo_pie.RelocateValue(*memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 3)), segmentOffsets)
+	if err != nil || value != *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(5, 13)) {
+		t.Errorf("Wrong relocated value %v, error: %v", value, err)
+	}
+	felt := *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3))
+	value, err = cairo_pie.RelocateValue(felt, segmentOffsets)
+	if err != nil || value != felt {
+		t.Errorf("Felts shouldn't be relocated, got %v, error: %v", value, err)
+	}
+	_, err = cairo_pie.RelocateValue(*memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(2, 0)), segmentOffsets)
+	if err == nil {
+		t.Errorf("RelocateValue should fail for segments without relocation")
+	}
+}
+
+func TestEncodeSignatureBuiltinAdditionalData(t *testing.T) {
+	signatures := map[memory.Relocatable]builtins.Signature{
+		memory.NewRelocatable(3, 2): {R: lambdaworks.FeltFromUint64(5), S: lambdaworks.FeltFromHex("0x800000000000011000000000000000000000000000000000000000000000000")},
+	}
+	data, err := cairo_pie.EncodeSignatureBuiltinAdditionalData(signatures)
+	if err != nil {
+		t.Fatalf("EncodeSignatureBuiltinAdditionalData failed with error: %s", err)
+	}
+	pie := cairo_pie.CairoPie{AdditionalData: map[string]json.RawMessage{"ecdsa_builtin": data}}
+	decoded, err := pie.SignatureBuiltinAdditionalData()
+	if err != nil {
+		t.Fatalf("SignatureBuiltinAdditionalData failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, signatures) {
+		t.Errorf("Wrong signatures. Expected %v, got %v", signatures, decoded)
+	}
+}
+
+func TestCheckCompatibilityIgnoresUnusedBuiltins(t *testing.T) {
+	pie := cairo_pie.CairoPie{
+		Metadata:           cairo_pie.CairoPieMetadata{Program: cairo_pie.StrippedProgram{Prime: lambdaworks.CAIRO_PRIME_HEX}},
+		ExecutionResources: cairo_pie.ExecutionResources{NSteps: 3, BuiltinInstanceCounter: map[string]uint{"output_builtin": 1, "pedersen_builtin": 0}},
+	}
+	other := pie
+	other.Metadata.Program.Prime = "3618502788666131213697322783095070105623107215331596699973092056135872020481"
+	other.ExecutionResources.BuiltinInstanceCounter = map[string]uint{"output_builtin": 1}
+	err := pie.CheckCompatibility(&other)
+	if err != nil {
+		t.Errorf("CheckCompatibility failed with error: %s", err)
+	}
+	other.ExecutionResources.BuiltinInstanceCounter = map[string]uint{"output_builtin": 2}
+	if pie.CheckCompatibility(&other) == nil {
+		t.Errorf("CheckCompatibility should fail for different builtin usage")
+	}
+}
@@ -0,0 +1,215 @@
+package contract_class
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/ebfe/keccak"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
+)
+
+// Version of the deprecated (Cairo 0) contract class hash computation
+const DEPRECATED_API_VERSION = 0
+
+type DeprecatedEntryPoint struct {
+	Selector lambdaworks.Felt
+	Offset   lambdaworks.Felt
+}
+
+// A Cairo 0 contract class, as output by starknet-compile-deprecated.
+// The abi and program are kept as raw json values, as the hinted class hash is computed over their serialization
+type DeprecatedContractClass struct {
+	Abi               any
+	EntryPointsByType map[string][]DeprecatedEntryPoint
+	Program           map[string]any
+}
+
+func ContractClassError(err error) error {
+	return errors.Wrapf(err, "Contract class error")
+}
+
+func ParseDeprecatedContractClass(contractPath string) (DeprecatedContractClass, error) {
+	contractFile, err := os.Open(contractPath)
+	if err != nil {
+		return DeprecatedContractClass{}, ContractClassError(err)
+	}
+	defer contractFile.Close()
+
+	contractBytes, err := ioutil.ReadAll(contractFile)
+	if err != nil {
+		return DeprecatedContractClass{}, ContractClassError(err)
+	}
+	return DeprecatedContractClassFromJson(contractBytes)
+}
+
+func DeprecatedContractClassFromJson(contractBytes []byte) (DeprecatedContractClass, error) {
+	decoder := json.NewDecoder(bytes.NewReader(contractBytes))
+	// Numbers are kept as their json representation so that they can be serialized back without loss of precision
+	decoder.UseNumber()
+
+	var rawContract map[string]any
+	err := decoder.Decode(&rawContract)
+	if err != nil {
+		return DeprecatedContractClass{}, ContractClassError(err)
+	}
+
+	program, ok := rawContract["program"].(map[string]any)
+	if !ok {
+		return DeprecatedContractClass{}, ContractClassError(errors.New("Missing or invalid program field"))
+	}
+	rawEntryPoints, ok := rawContract["entry_points_by_type"].(map[string]any)
+	if !ok {
+		return DeprecatedContractClass{}, ContractClassError(errors.New("Missing or invalid entry_points_by_type field"))
+	}
+
+	entryPointsByType := make(map[string][]DeprecatedEntryPoint)
+	for entryPointType, rawEntryPointList := range rawEntryPoints {
+		entryPointList, ok := rawEntryPointList.([]any)
+		if !ok {
+			return DeprecatedContractClass{}, ContractClassError(errors.Errorf("Invalid %s entry points", entryPointType))
+		}
+		entryPoints := make([]DeprecatedEntryPoint, 0, len(entryPointList))
+		for _, rawEntryPoint := range entryPointList {
+			entryPoint, ok := rawEntryPoint.(map[string]any)
+			if !ok {
+				return DeprecatedContractClass{}, ContractClassError(errors.Errorf("Invalid %s entry point", entryPointType))
+			}
+			selector, err := feltFromJsonValue(entryPoint["selector"])
+			if err != nil {
+				return DeprecatedContractClass{}, ContractClassError(err)
+			}
+			offset, err := feltFromJsonValue(entryPoint["offset"])
+			if err != nil {
+				return DeprecatedContractClass{}, ContractClassError(err)
+			}
+			entryPoints = append(entryPoints, DeprecatedEntryPoint{Selector: selector, Offset: offset})
+		}
+		entryPointsByType[entryPointType] = entryPoints
+	}
+
+	return DeprecatedContractClass{
+		Abi:               rawContract["abi"],
+		EntryPointsByType: entryPointsByType,
+		Program:           program,
+	}, nil
+}
+
+// Entry point offsets can be either hex strings or plain numbers, depending on the compiler version
+func feltFromJsonValue(value any) (lambdaworks.Felt, error) {
+	switch v := value.(type) {
+	case string:
+		return lambdaworks.FeltFromHex(v), nil
+	case json.Number:
+		return lambdaworks.FeltFromDecString(v.String()), nil
+	default:
+		return lambdaworks.FeltZero(), errors.Errorf("Expected a hex string or a number, got %v", value)
+	}
+}
+
+// Computes the keccak256 of the given data, truncated to 250 bits so that it fits in a felt
+func StarknetKeccak(data []byte) lambdaworks.Felt {
+	hasher := keccak.New256()
+	hasher.Write(data)
+	var digest [32]byte
+	copy(digest[:], hasher.Sum(nil))
+	digest[0] &= 0x03
+	return lambdaworks.FeltFromBeBytes(&digest)
+}
+
+// Computes the hash of the contract class' abi and program (without debug info), as cairo-lang's compute_hinted_class_hash does
+func (c *DeprecatedContractClass) ComputeHintedClassHash() (lambdaworks.Felt, error) {
+	program := make(map[string]any, len(c.Program))
+	for key, value := range c.Program {
+		program[key] = value
+	}
+	program["debug_info"] = nil
+
+	// Attributes are removed (or stripped of their empty fields) for backwards compatibility with
+	// classes declared before they were introduced
+	attributes, _ := program["attributes"].([]any)
+	if len(attributes) == 0 {
+		delete(program, "attributes")
+	} else {
+		strippedAttributes := make([]any, 0, len(attributes))
+		for _, rawAttribute := range attributes {
+			attribute, ok := rawAttribute.(map[string]any)
+			if !ok {
+				return lambdaworks.FeltZero(), ContractClassError(errors.New("Invalid program attribute"))
+			}
+			strippedAttribute := make(map[string]any, len(attribute))
+			for key, value := range attribute {
+				strippedAttribute[key] = value
+			}
+			if scopes, _ := attribute["accessible_scopes"].([]any); len(scopes) == 0 {
+				delete(strippedAttribute, "accessible_scopes")
+			}
+			if attribute["flow_tracking_data"] == nil {
+				delete(strippedAttribute, "flow_tracking_data")
+			}
+			strippedAttributes = append(strippedAttributes, strippedAttribute)
+		}
+		program["attributes"] = strippedAttributes
+	}
+
+	serialized, err := PythonJsonDumps(map[string]any{"abi": c.Abi, "program": program})
+	if err != nil {
+		return lambdaworks.FeltZero(), ContractClassError(err)
+	}
+	return StarknetKeccak(serialized), nil
+}
+
+func (c *DeprecatedContractClass) entryPointsHash(entryPointType string) lambdaworks.Felt {
+	entryPoints := c.EntryPointsByType[entryPointType]
+	flattened := make([]lambdaworks.Felt, 0, 2*len(entryPoints))
+	for _, entryPoint := range entryPoints {
+		flattened = append(flattened, entryPoint.Selector, entryPoint.Offset)
+	}
+	return starknet_crypto.ComputeHashOnElements(flattened)
+}
+
+// Computes the class hash of a Cairo 0 contract class, as cairo-lang's compute_deprecated_class_hash does
+func (c *DeprecatedContractClass) ComputeClassHash() (lambdaworks.Felt, error) {
+	rawBuiltins, _ := c.Program["builtins"].([]any)
+	builtins := make([]lambdaworks.Felt, 0, len(rawBuiltins))
+	for _, rawBuiltin := range rawBuiltins {
+		builtin, ok := rawBuiltin.(string)
+		if !ok {
+			return lambdaworks.FeltZero(), ContractClassError(errors.New("Invalid program builtin"))
+		}
+		builtinFelt, err := vm.FeltFromShortString(builtin)
+		if err != nil {
+			return lambdaworks.FeltZero(), ContractClassError(err)
+		}
+		builtins = append(builtins, builtinFelt)
+	}
+
+	rawData, _ := c.Program["data"].([]any)
+	data := make([]lambdaworks.Felt, 0, len(rawData))
+	for _, rawValue := range rawData {
+		value, ok := rawValue.(string)
+		if !ok {
+			return lambdaworks.FeltZero(), ContractClassError(errors.New("Invalid program data"))
+		}
+		data = append(data, lambdaworks.FeltFromHex(value))
+	}
+
+	hintedClassHash, err := c.ComputeHintedClassHash()
+	if err != nil {
+		return lambdaworks.FeltZero(), err
+	}
+
+	return starknet_crypto.ComputeHashOnElements([]lambdaworks.Felt{
+		lambdaworks.FeltFromUint64(DEPRECATED_API_VERSION),
+		c.entryPointsHash("EXTERNAL"),
+		c.entryPointsHash("L1_HANDLER"),
+		c.entryPointsHash("CONSTRUCTOR"),
+		starknet_crypto.ComputeHashOnElements(builtins),
+		hintedClassHash,
+		starknet_crypto.ComputeHashOnElements(data),
+	}), nil
+}
@@ -0,0 +1,108 @@
+package contract_class_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/contract_class"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+const testContract = `{
+	"abi": [{"inputs": [], "name": "constructor", "outputs": [], "type": "constructor"}],
+	"entry_points_by_type": {
+		"CONSTRUCTOR": [{"offset": "0x3", "selector": "0x28ffe4ff0f226a9107253e17a904099aa4f63a02a5621de0576e5aa71bc5194"}],
+		"EXTERNAL": [{"offset": 7, "selector": "0x83afd3f4caedc6eebf44246fe54e38c95e3179a5ec9ea81740eca5b482d12e"}],
+		"L1_HANDLER": []
+	},
+	"program": {
+		"attributes": [{"accessible_scopes": [], "end_pc": 3, "flow_tracking_data": null, "name": "error_message", "start_pc": 1, "value": "Café"}],
+		"builtins": ["pedersen", "range_check"],
+		"data": ["0x40780017fff7fff", "0x1"],
+		"debug_info": {"file_contents": {}},
+		"main_scope": "__main__",
+		"prime": "0x800000000000011000000000000000000000000000000000000000000000001"
+	}
+}`
+
+func TestStarknetKeccak(t *testing.T) {
+	// get_selector_from_name("transfer")
+	expected := lambdaworks.FeltFromHex("0x83afd3f4caedc6eebf44246fe54e38c95e3179a5ec9ea81740eca5b482d12e")
+	result := contract_class.StarknetKeccak([]byte("transfer"))
+	if result != expected {
+		t.Errorf("Wrong keccak. Expected %s, got %s", expected.ToHexString(), result.ToHexString())
+	}
+}
+
+func TestPythonJsonDumps(t *testing.T) {
+	contractClass, err := contract_class.DeprecatedContractClassFromJson([]byte(`{"program": {"b": [1, true, null], "a": "café \"q\"\n", "c": "del\u007f~ \ud83d\ude00"}, "entry_points_by_type": {}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	result, err := contract_class.PythonJsonDumps(contractClass.Program)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// Generated with python's json.dumps(program, sort_keys=True)
+	expected := `{"a": "caf\u00e9 \"q\"\n", "b": [1, true, null], "c": "del\u007f~ \ud83d\ude00"}`
+	if string(result) != expected {
+		t.Errorf("Wrong serialization. Expected %s, got %s", expected, result)
+	}
+}
+
+func TestDeprecatedContractClassEntryPoints(t *testing.T) {
+	contractClass, err := contract_class.DeprecatedContractClassFromJson([]byte(testContract))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	external := contractClass.EntryPointsByType["EXTERNAL"]
+	if len(external) != 1 || external[0].Offset != lambdaworks.FeltFromUint64(7) {
+		t.Errorf("Wrong external entry points: %v", external)
+	}
+	constructor := contractClass.EntryPointsByType["CONSTRUCTOR"]
+	if len(constructor) != 1 || constructor[0].Offset != lambdaworks.FeltFromUint64(3) {
+		t.Errorf("Wrong constructor entry points: %v", constructor)
+	}
+}
+
+// The expected hashes are the ones of cairo-lang's compute_hinted_class_hash and compute_deprecated_class_hash for
+// testContract, in which debug_info is nulled and the attribute's empty fields are removed
+func TestComputeHintedClassHash(t *testing.T) {
+	contractClass, err := contract_class.DeprecatedContractClassFromJson([]byte(testContract))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := lambdaworks.FeltFromHex("0xac4bbf20738b0f010e6064497b3544b90bd4edc688a28119317974ec1f0735")
+	result, err := contractClass.ComputeHintedClassHash()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if result != expected {
+		t.Errorf("Wrong hinted class hash. Expected %s, got %s", expected.ToHexString(), result.ToHexString())
+	}
+}
+
+func TestComputeClassHash(t *testing.T) {
+	contractClass, err := contract_class.DeprecatedContractClassFromJson([]byte(testContract))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := lambdaworks.FeltFromHex("0x5318a122daed93414f9a55b25074c65227afedc4a551451c2ca3d159553164c")
+	result, err := contractClass.ComputeClassHash()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if result != expected {
+		t.Errorf("Wrong class hash. Expected %s, got %s", expected.ToHexString(), result.ToHexString())
+	}
+}
+
+func TestComputeClassHashInvalidData(t *testing.T) {
+	contractClass, err := contract_class.DeprecatedContractClassFromJson([]byte(`{"program": {"data": [1]}, "entry_points_by_type": {}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	_, err = contractClass.ComputeClassHash()
+	if err == nil {
+		t.Errorf("Expected an error")
+	}
+}
@@ -0,0 +1,110 @@
+package contract_class
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+// Serializes a json value (as decoded by a json.Decoder with UseNumber enabled) in the same way
+// python's json.dumps(value, sort_keys=True) does:
+// object keys are sorted, ", " and ": " are used as separators and the characters outside printable ascii are escaped
+func PythonJsonDumps(value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := writePythonJson(&buffer, value)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func writePythonJson(buffer *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buffer.WriteString("null")
+	case bool:
+		if v {
+			buffer.WriteString("true")
+		} else {
+			buffer.WriteString("false")
+		}
+	case json.Number:
+		buffer.WriteString(v.String())
+	case string:
+		writePythonJsonString(buffer, v)
+	case []any:
+		buffer.WriteByte('[')
+		for i, elem := range v {
+			if i != 0 {
+				buffer.WriteString(", ")
+			}
+			err := writePythonJson(buffer, elem)
+			if err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buffer.WriteByte('{')
+		for i, key := range keys {
+			if i != 0 {
+				buffer.WriteString(", ")
+			}
+			writePythonJsonString(buffer, key)
+			buffer.WriteString(": ")
+			err := writePythonJson(buffer, v[key])
+			if err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+	default:
+		return errors.Errorf("Unsupported json value type %T", value)
+	}
+	return nil
+}
+
+func writePythonJsonString(buffer *bytes.Buffer, value string) {
+	buffer.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"':
+			buffer.WriteString("\\\"")
+		case '\\':
+			buffer.WriteString("\\\\")
+		case '\n':
+			buffer.WriteString("\\n")
+		case '\r':
+			buffer.WriteString("\\r")
+		case '\t':
+			buffer.WriteString("\\t")
+		case '\b':
+			buffer.WriteString("\\b")
+		case '\f':
+			buffer.WriteString("\\f")
+		default:
+			switch {
+			case r < 0x20:
+				buffer.WriteString(fmt.Sprintf("\\u%04x", r))
+			case r < 0x7f:
+				buffer.WriteRune(r)
+			case r <= 0xffff:
+				buffer.WriteString(fmt.Sprintf("\\u%04x", r))
+			default:
+				// Characters outside the basic multilingual plane are escaped as utf-16 surrogate pairs
+				high, low := utf16.EncodeRune(r)
+				buffer.WriteString(fmt.Sprintf("\\u%04x\\u%04x", high, low))
+			}
+		}
+	}
+	buffer.WriteByte('"')
+}
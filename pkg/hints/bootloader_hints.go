@@ -0,0 +1,478 @@
+package hints
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/bootloader"
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/types"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Size of the simple bootloader's BuiltinData struct, which holds one pointer per supported builtin.
+// Only used when the program's identifiers aren't available, otherwise the size is read from the program
+var BUILTIN_DATA_SIZE = uint(len(bootloader.SIMPLE_BOOTLOADER_BUILTINS))
+
+// Returns ids.BuiltinData.SIZE
+func builtinDataSize(ids IdsManager) (uint, error) {
+	if ids.Identifiers == nil {
+		return BUILTIN_DATA_SIZE, nil
+	}
+	return ids.GetStructSize("BuiltinData")
+}
+
+// Returns the offsets of the members of ids.ProgramHeader
+func programHeaderOffsets(ids IdsManager) (bootloader.ProgramHeaderOffsets, error) {
+	if ids.Identifiers == nil {
+		return bootloader.DefaultProgramHeaderOffsets, nil
+	}
+	var offsets bootloader.ProgramHeaderOffsets
+	members := map[string]*uint{
+		"data_length":        &offsets.DataLength,
+		"bootloader_version": &offsets.BootloaderVersion,
+		"program_main":       &offsets.ProgramMain,
+		"n_builtins":         &offsets.NBuiltins,
+		"builtin_list":       &offsets.BuiltinList,
+	}
+	for member, offset := range members {
+		var err error
+		*offset, err = ids.GetStructMemberOffset("ProgramHeader", member)
+		if err != nil {
+			return bootloader.ProgramHeaderOffsets{}, err
+		}
+	}
+	return offsets, nil
+}
+
+// Returns the address of a struct identifier, which is the value of the identifier if it is a pointer to the struct
+// (ids.<name>.address_)
+func getStructAddress(name string, ids IdsManager, vm *VirtualMachine) (memory.Relocatable, error) {
+	reference, ok := ids.References[name]
+	if ok && strings.HasSuffix(reference.ValueType, "*") {
+		return ids.GetRelocatable(name, vm)
+	}
+	return ids.GetAddr(name, vm)
+}
+
+// Implements hint:
+// %{ simple_bootloader_input = SimpleBootloaderInput.Schema().load(program_input) %}
+// Inputs with file paths fail if disableFilePaths is set
+func simpleBootloaderLoadInput(scopes *ExecutionScopes, disableFilePaths bool) error {
+	programInput, err := FetchScopeVar[json.RawMessage]("program_input", scopes)
+	if err != nil {
+		return err
+	}
+	input, err := bootloader.ParseSimpleBootloaderInput(programInput)
+	if err != nil {
+		return err
+	}
+	if disableFilePaths {
+		err = input.CheckNoFilePaths()
+		if err != nil {
+			return err
+		}
+	}
+	scopes.AssignOrUpdateVariable("simple_bootloader_input", input)
+	return nil
+}
+
+// Writes the number of tasks to the output, computes ids.task_range_check_ptr and initializes fact_topologies
+func simpleBootloaderPrepareTaskRangeChecks(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	input, err := FetchScopeVar[bootloader.SimpleBootloaderInput]("simple_bootloader_input", scopes)
+	if err != nil {
+		return err
+	}
+	nTasks := uint(len(input.Tasks))
+	outputPtr, err := ids.GetRelocatable("output_ptr", vm)
+	if err != nil {
+		return err
+	}
+	err = vm.Segments.Memory.Insert(outputPtr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(nTasks))))
+	if err != nil {
+		return err
+	}
+
+	// Task range checks are located right after the simple bootloader's validation range checks
+	rangeCheckPtr, err := ids.GetRelocatable("range_check_ptr", vm)
+	if err != nil {
+		return err
+	}
+	dataSize, err := builtinDataSize(ids)
+	if err != nil {
+		return err
+	}
+	taskRangeCheckPtr := rangeCheckPtr.AddUint(dataSize * nTasks)
+	err = ids.Insert("task_range_check_ptr", memory.NewMaybeRelocatableRelocatable(taskRangeCheckPtr), vm)
+	if err != nil {
+		return err
+	}
+
+	scopes.AssignOrUpdateVariable("fact_topologies", make([]runners.FactTopology, 0, nTasks))
+	return nil
+}
+
+// Implements hint:
+// %{ tasks = simple_bootloader_input.tasks %}
+func simpleBootloaderSetTasksVariable(scopes *ExecutionScopes) error {
+	input, err := FetchScopeVar[bootloader.SimpleBootloaderInput]("simple_bootloader_input", scopes)
+	if err != nil {
+		return err
+	}
+	scopes.AssignOrUpdateVariable("tasks", input.Tasks)
+	return nil
+}
+
+// Loads the next task to be executed, the tasks are executed in order and ids.n_tasks holds the amount of remaining tasks
+func simpleBootloaderSetCurrentTask(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	input, err := FetchScopeVar[bootloader.SimpleBootloaderInput]("simple_bootloader_input", scopes)
+	if err != nil {
+		return err
+	}
+	nTasksFelt, err := ids.GetFelt("n_tasks", vm)
+	if err != nil {
+		return err
+	}
+	nTasks, err := nTasksFelt.ToU64()
+	if err != nil {
+		return err
+	}
+	if nTasks == 0 || nTasks > uint64(len(input.Tasks)) {
+		return errors.Errorf("Invalid amount of remaining tasks: %d", nTasks)
+	}
+	taskId := uint64(len(input.Tasks)) - nTasks
+	task, err := input.Tasks[taskId].LoadTask()
+	if err != nil {
+		return err
+	}
+	scopes.AssignOrUpdateVariable("task_id", taskId)
+	scopes.AssignOrUpdateVariable("task", task)
+	return nil
+}
+
+// Adds the pages of each task to the output builtin (unless the whole output is a single page)
+// and writes the fact topologies to a file if requested
+func simpleBootloaderConfigureFactTopologies(vm *VirtualMachine, scopes *ExecutionScopes) error {
+	input, err := FetchScopeVar[bootloader.SimpleBootloaderInput]("simple_bootloader_input", scopes)
+	if err != nil {
+		return err
+	}
+	factTopologies, err := FetchScopeVar[[]runners.FactTopology]("fact_topologies", scopes)
+	if err != nil {
+		return err
+	}
+	outputBuiltin, err := vm.GetOutputBuiltin()
+	if err != nil {
+		return err
+	}
+	// The tasks' output is prefixed by a single cell containing the number of tasks
+	outputBase := outputBuiltin.Base()
+	tasksOutputStart := outputBase.AddUint(1)
+
+	if !input.SinglePage {
+		_, err = bootloader.ConfigureFactTopologies(factTopologies, tasksOutputStart, outputBuiltin)
+		if err != nil {
+			return err
+		}
+	}
+	if input.FactTopologiesPath != nil {
+		return bootloader.WriteFactTopologiesFile(*input.FactTopologiesPath, factTopologies)
+	}
+	return nil
+}
+
+// Implements hint:
+// %{ ids.program_data_ptr = program_data_base = segments.add() %}
+func executeTaskAllocateProgramDataSegment(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	programDataBase := vm.Segments.AddSegment()
+	scopes.AssignOrUpdateVariable("program_data_base", programDataBase)
+	return ids.Insert("program_data_ptr", memory.NewMaybeRelocatableRelocatable(programDataBase), vm)
+}
+
+// Writes the task's program header and code into the program data segment, and finalizes the segment
+func executeTaskLoadProgram(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	task, err := FetchScopeVar[*bootloader.Task]("task", scopes)
+	if err != nil {
+		return err
+	}
+	programDataBase, err := FetchScopeVar[memory.Relocatable]("program_data_base", scopes)
+	if err != nil {
+		return err
+	}
+	programHeader, err := ids.GetRelocatable("program_header", vm)
+	if err != nil {
+		return err
+	}
+	headerOffsets, err := programHeaderOffsets(ids)
+	if err != nil {
+		return err
+	}
+	programAddress, programDataSize, err := bootloader.LoadProgramWithHeaderOffsets(&task.Program, &vm.Segments, programHeader, headerOffsets)
+	if err != nil {
+		return err
+	}
+	vm.Segments.Finalize(&programDataSize, uint(programDataBase.SegmentIndex), nil)
+	scopes.AssignOrUpdateVariable("program_address", programAddress)
+	return nil
+}
+
+// Checks that the program hash written to the output by the bootloader matches the hash of the task's program.
+// If usePoseidonFromIds is false, the pedersen hash is always used
+func executeTaskValidateHash(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes, usePoseidonFromIds bool) error {
+	task, err := FetchScopeVar[*bootloader.Task]("task", scopes)
+	if err != nil {
+		return err
+	}
+	usePoseidon := false
+	if usePoseidonFromIds {
+		usePoseidonFelt, err := ids.GetFelt("use_poseidon", vm)
+		if err != nil {
+			return err
+		}
+		usePoseidon = !usePoseidonFelt.IsZero()
+	}
+	outputPtr, err := ids.GetRelocatable("output_ptr", vm)
+	if err != nil {
+		return err
+	}
+	programHash, err := vm.Segments.Memory.GetFelt(outputPtr.AddUint(1))
+	if err != nil {
+		return err
+	}
+	computedHash, err := task.Program.ComputeProgramHashChain(0, usePoseidon)
+	if err != nil {
+		return err
+	}
+	if programHash != computedHash {
+		return errors.New("Computed hash does not match input.")
+	}
+	return nil
+}
+
+// Implements hint:
+// %{ assert ids.program_address == program_address %}
+func executeTaskAssertProgramAddress(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	programAddress, err := FetchScopeVar[memory.Relocatable]("program_address", scopes)
+	if err != nil {
+		return err
+	}
+	idsProgramAddress, err := ids.GetRelocatable("program_address", vm)
+	if err != nil {
+		return err
+	}
+	if idsProgramAddress != programAddress {
+		return errors.Errorf("Program address mismatch: expected %s, got %s", programAddress.ToString(), idsProgramAddress.ToString())
+	}
+	return nil
+}
+
+// Prepares the execution of the task: program tasks get their hints loaded into the vm and their output builtin pages
+// recorded separately, while pie tasks get their whole memory loaded (which replaces the execution of their hints).
+// Enters the task's scope, where program tasks can access their program_input
+func executeTaskCallTask(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes, hintProcessor HintProcessor) error {
+	task, err := FetchScopeVar[*bootloader.Task]("task", scopes)
+	if err != nil {
+		return err
+	}
+	programAddress, err := FetchScopeVar[memory.Relocatable]("program_address", scopes)
+	if err != nil {
+		return err
+	}
+	nBuiltins := uint(len(task.Program.Builtins))
+	newTaskLocals := make(map[string]interface{})
+
+	if task.IsCairoPie() {
+		// The task returns to the instruction after the call
+		encodedInstruction, err := vm.Segments.Memory.GetFelt(vm.RunContext.Pc)
+		if err != nil {
+			return err
+		}
+		encodedInstructionUint, err := encodedInstruction.ToU64()
+		if err != nil {
+			return err
+		}
+		callInstruction, err := DecodeInstruction(encodedInstructionUint)
+		if err != nil {
+			return err
+		}
+		retPc := vm.RunContext.Pc.AddUint(callInstruction.Size())
+		executionSegmentAddress, err := vm.RunContext.Ap.SubUint(nBuiltins)
+		if err != nil {
+			return err
+		}
+		err = bootloader.LoadCairoPie(task.CairoPie, vm, programAddress, executionSegmentAddress, vm.RunContext.Fp, retPc)
+		if err != nil {
+			return err
+		}
+	} else {
+		newTaskLocals["program_input"] = task.ProgramInput
+		newTaskLocals["WITH_BOOTLOADER"] = true
+		err = vm.LoadProgram(&task.Program, programAddress, hintProcessor)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Pie tasks don't run hints, so they can't change the output builtin
+	var outputRunnerData *builtins.OutputBuiltinState
+	if !task.IsCairoPie() {
+		outputBuiltin, err := vm.GetOutputBuiltin()
+		if err != nil {
+			return err
+		}
+		preExecutionBuiltinPtrs, err := getStructAddress("pre_execution_builtin_ptrs", ids, vm)
+		if err != nil {
+			return err
+		}
+		outputPtr, err := vm.Segments.Memory.GetRelocatable(preExecutionBuiltinPtrs)
+		if err != nil {
+			return err
+		}
+		state := outputBuiltin.GetState()
+		outputRunnerData = &state
+		outputBuiltin.NewState(outputPtr)
+	}
+	scopes.AssignOrUpdateVariable("output_runner_data", outputRunnerData)
+
+	scopes.EnterScope(newTaskLocals)
+	return nil
+}
+
+// Writes the builtin pointers after the execution of the task to ids.return_builtin_ptrs, and enters the scope
+// used to select the task's builtins
+func executeTaskWriteReturnBuiltins(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	task, err := FetchScopeVar[*bootloader.Task]("task", scopes)
+	if err != nil {
+		return err
+	}
+	returnBuiltinsAddr, err := getStructAddress("return_builtin_ptrs", ids, vm)
+	if err != nil {
+		return err
+	}
+	usedBuiltinsAddr, err := ids.GetRelocatable("used_builtins_addr", vm)
+	if err != nil {
+		return err
+	}
+	preExecutionBuiltinsAddr, err := getStructAddress("pre_execution_builtin_ptrs", ids, vm)
+	if err != nil {
+		return err
+	}
+	err = bootloader.WriteReturnBuiltins(task, &vm.Segments, returnBuiltinsAddr, usedBuiltinsAddr, preExecutionBuiltinsAddr)
+	if err != nil {
+		return err
+	}
+	nBuiltins := lambdaworks.FeltFromUint64(uint64(len(task.Program.Builtins)))
+	scopes.EnterScope(map[string]interface{}{"n_selected_builtins": nBuiltins})
+	return nil
+}
+
+// Adds the fact topology of the task that was just executed to fact_topologies, and restores the output builtin's
+// state for program tasks
+func executeTaskAppendFactTopologies(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	task, err := FetchScopeVar[*bootloader.Task]("task", scopes)
+	if err != nil {
+		return err
+	}
+	factTopologies, err := FetchScopeVar[[]runners.FactTopology]("fact_topologies", scopes)
+	if err != nil {
+		return err
+	}
+	outputRunnerData, err := FetchScopeVar[*builtins.OutputBuiltinState]("output_runner_data", scopes)
+	if err != nil {
+		return err
+	}
+	outputBuiltin, err := vm.GetOutputBuiltin()
+	if err != nil {
+		return err
+	}
+
+	preExecutionBuiltinPtrs, err := getStructAddress("pre_execution_builtin_ptrs", ids, vm)
+	if err != nil {
+		return err
+	}
+	returnBuiltinPtrs, err := getStructAddress("return_builtin_ptrs", ids, vm)
+	if err != nil {
+		return err
+	}
+	outputStart, err := vm.Segments.Memory.GetRelocatable(preExecutionBuiltinPtrs)
+	if err != nil {
+		return err
+	}
+	outputEnd, err := vm.Segments.Memory.GetRelocatable(returnBuiltinPtrs)
+	if err != nil {
+		return err
+	}
+	outputSize, err := outputEnd.Sub(outputStart)
+	if err != nil {
+		return err
+	}
+	outputSizeUint, err := outputSize.ToU64()
+	if err != nil {
+		return err
+	}
+
+	factTopology, err := bootloader.GetTaskFactTopology(uint(outputSizeUint), task, outputBuiltin)
+	if err != nil {
+		return err
+	}
+	if !task.IsCairoPie() {
+		if outputRunnerData == nil {
+			return errors.New("Missing output builtin state of the task")
+		}
+		outputBuiltin.SetState(*outputRunnerData)
+	}
+	scopes.AssignOrUpdateVariable("fact_topologies", append(factTopologies, factTopology))
+	return nil
+}
+
+// Implements hint:
+// %{ vm_enter_scope({'n_selected_builtins': ids.n_selected_builtins}) %}
+func selectBuiltinsEnterScope(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	nSelectedBuiltins, err := ids.GetFelt("n_selected_builtins", vm)
+	if err != nil {
+		return err
+	}
+	scopes.EnterScope(map[string]interface{}{"n_selected_builtins": nSelectedBuiltins})
+	return nil
+}
+
+// A builtin is selected if its encoding is the next one in the list of selected encodings, which isn't exhausted
+// (both lists are sorted, so comparing the first elements is enough)
+func innerSelectBuiltinsSelectBuiltin(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	nSelectedBuiltins, err := FetchScopeVar[lambdaworks.Felt]("n_selected_builtins", scopes)
+	if err != nil {
+		return err
+	}
+	selectedEncodings, err := ids.GetRelocatable("selected_encodings", vm)
+	if err != nil {
+		return err
+	}
+	allEncodings, err := ids.GetRelocatable("all_encodings", vm)
+	if err != nil {
+		return err
+	}
+
+	selectBuiltin := false
+	if !nSelectedBuiltins.IsZero() {
+		selectedEncoding, err := vm.Segments.Memory.Get(selectedEncodings)
+		if err != nil {
+			return err
+		}
+		encoding, err := vm.Segments.Memory.Get(allEncodings)
+		if err != nil {
+			return err
+		}
+		selectBuiltin = *selectedEncoding == *encoding
+	}
+
+	if selectBuiltin {
+		scopes.AssignOrUpdateVariable("n_selected_builtins", nSelectedBuiltins.Sub(lambdaworks.FeltOne()))
+		return ids.Insert("select_builtin", memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne()), vm)
+	}
+	return ids.Insert("select_builtin", memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero()), vm)
+}
@@ -0,0 +1,316 @@
+package hints_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/bootloader"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/types"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestSimpleBootloaderPrepareTaskRangeChecks(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Fp = NewRelocatable(1, 0)
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"output_ptr":           {NewMaybeRelocatableRelocatable(NewRelocatable(0, 0))},
+			"range_check_ptr":      {NewMaybeRelocatableRelocatable(NewRelocatable(0, 10))},
+			"task_range_check_ptr": {nil},
+		},
+		vm,
+	)
+	scopes := NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("simple_bootloader_input", bootloader.SimpleBootloaderInput{
+		Tasks: []bootloader.TaskSpec{{Type: bootloader.RUN_PROGRAM_TASK}, {Type: bootloader.RUN_PROGRAM_TASK}},
+	})
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SIMPLE_BOOTLOADER_PREPARE_TASK_RANGE_CHECKS,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Fatalf("SIMPLE_BOOTLOADER_PREPARE_TASK_RANGE_CHECKS hint test failed with error %s", err)
+	}
+	nTasks, err := vm.Segments.Memory.GetFelt(NewRelocatable(0, 0))
+	if err != nil || nTasks != lambdaworks.FeltFromUint64(2) {
+		t.Errorf("Wrong number of tasks written to the output: %v", nTasks)
+	}
+	taskRangeCheckPtr, err := idsManager.GetRelocatable("task_range_check_ptr", vm)
+	expected := NewRelocatable(0, 10+2*uint(len(bootloader.SIMPLE_BOOTLOADER_BUILTINS)))
+	if err != nil || taskRangeCheckPtr != expected {
+		t.Errorf("Wrong task_range_check_ptr. Expected %v, got %v", expected, taskRangeCheckPtr)
+	}
+	_, err = scopes.Get("fact_topologies")
+	if err != nil {
+		t.Errorf("fact_topologies not initialized: %s", err)
+	}
+}
+
+func TestSimpleBootloaderPrepareTaskRangeChecksProgramBuiltinDataSize(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Fp = NewRelocatable(1, 0)
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"output_ptr":           {NewMaybeRelocatableRelocatable(NewRelocatable(0, 0))},
+			"range_check_ptr":      {NewMaybeRelocatableRelocatable(NewRelocatable(0, 10))},
+			"task_range_check_ptr": {nil},
+		},
+		vm,
+	)
+	// The struct is imported from execute_task, with a size other than the one of the supported builtins
+	idsManager.AccessibleScopes = []string{"simple_bootloader", "simple_bootloader.run_simple_bootloader"}
+	idsManager.Identifiers = map[string]Identifier{
+		"simple_bootloader.BuiltinData": {Type: "alias", Destination: "execute_task.BuiltinData"},
+		"execute_task.BuiltinData":      {Type: "struct", Size: 3},
+	}
+	scopes := NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("simple_bootloader_input", bootloader.SimpleBootloaderInput{
+		Tasks: []bootloader.TaskSpec{{Type: bootloader.RUN_PROGRAM_TASK}, {Type: bootloader.RUN_PROGRAM_TASK}},
+	})
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SIMPLE_BOOTLOADER_PREPARE_TASK_RANGE_CHECKS,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Fatalf("SIMPLE_BOOTLOADER_PREPARE_TASK_RANGE_CHECKS hint test failed with error %s", err)
+	}
+	taskRangeCheckPtr, err := idsManager.GetRelocatable("task_range_check_ptr", vm)
+	expected := NewRelocatable(0, 10+2*3)
+	if err != nil || taskRangeCheckPtr != expected {
+		t.Errorf("Wrong task_range_check_ptr. Expected %v, got %v", expected, taskRangeCheckPtr)
+	}
+
+	// Programs without BuiltinData are rejected instead of using the size of the supported builtins
+	idsManager.Identifiers = map[string]Identifier{}
+	hintData = any(HintData{Ids: idsManager, Code: SIMPLE_BOOTLOADER_PREPARE_TASK_RANGE_CHECKS})
+	err = hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err == nil {
+		t.Errorf("SIMPLE_BOOTLOADER_PREPARE_TASK_RANGE_CHECKS should fail without BuiltinData")
+	}
+}
+
+func TestSimpleBootloaderSetCurrentTaskInvalidType(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"n_tasks": {NewMaybeRelocatableFelt(lambdaworks.FeltOne())},
+		},
+		vm,
+	)
+	scopes := NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("simple_bootloader_input", bootloader.SimpleBootloaderInput{
+		Tasks: []bootloader.TaskSpec{{Type: "UnknownTask"}},
+	})
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SIMPLE_BOOTLOADER_SET_CURRENT_TASK,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err == nil {
+		t.Errorf("SIMPLE_BOOTLOADER_SET_CURRENT_TASK hint test should have failed")
+	}
+}
+
+func TestExecuteTaskAllocateProgramDataSegment(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"program_data_ptr": {nil},
+		},
+		vm,
+	)
+	scopes := NewExecutionScopes()
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: EXECUTE_TASK_ALLOCATE_PROGRAM_DATA_SEGMENT,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Fatalf("EXECUTE_TASK_ALLOCATE_PROGRAM_DATA_SEGMENT hint test failed with error %s", err)
+	}
+	programDataPtr, err := idsManager.GetRelocatable("program_data_ptr", vm)
+	if err != nil || programDataPtr != NewRelocatable(1, 0) {
+		t.Errorf("Wrong program_data_ptr: %v", programDataPtr)
+	}
+	programDataBase, err := FetchScopeVar[Relocatable]("program_data_base", scopes)
+	if err != nil || programDataBase != programDataPtr {
+		t.Errorf("Wrong program_data_base: %v", programDataBase)
+	}
+}
+
+func TestExecuteTaskLoadProgramProgramHeaderOffsets(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	programDataBase := vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"program_header": {NewMaybeRelocatableRelocatable(programDataBase)},
+		},
+		vm,
+	)
+	// A header with a field before the builtin list that cairo-lang's ProgramHeader doesn't have
+	idsManager.AccessibleScopes = []string{"execute_task", "execute_task.execute_task"}
+	idsManager.Identifiers = map[string]Identifier{
+		"execute_task.ProgramHeader": {Type: "struct", Size: 6, Members: map[string]any{
+			"data_length":        map[string]any{"cairo_type": "felt", "offset": 0},
+			"bootloader_version": map[string]any{"cairo_type": "felt", "offset": 1},
+			"program_main":       map[string]any{"cairo_type": "felt", "offset": 2},
+			"n_builtins":         map[string]any{"cairo_type": "felt", "offset": 3},
+			"program_version":    map[string]any{"cairo_type": "felt", "offset": 4},
+			"builtin_list":       map[string]any{"cairo_type": "felt*", "offset": 5},
+		}},
+	}
+	scopes := NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("program_data_base", programDataBase)
+	scopes.AssignOrUpdateVariable("task", &bootloader.Task{Program: Program{
+		Data: []MaybeRelocatable{
+			*NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(10)),
+			*NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(20)),
+		},
+		Builtins:    []string{"output"},
+		Identifiers: map[string]Identifier{"__main__.main": {PC: 1, Type: "function"}},
+	}})
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: EXECUTE_TASK_LOAD_PROGRAM,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Fatalf("EXECUTE_TASK_LOAD_PROGRAM hint test failed with error %s", err)
+	}
+	programAddress, err := FetchScopeVar[Relocatable]("program_address", scopes)
+	if err != nil || programAddress != programDataBase.AddUint(6) {
+		t.Errorf("Wrong program_address: %v", programAddress)
+	}
+	outputFelt, _ := FeltFromShortString("output")
+	expected := map[uint]lambdaworks.Felt{
+		0: lambdaworks.FeltFromUint64(7),
+		2: lambdaworks.FeltOne(),
+		3: lambdaworks.FeltOne(),
+		5: outputFelt,
+		6: lambdaworks.FeltFromUint64(10),
+		7: lambdaworks.FeltFromUint64(20),
+	}
+	for offset, value := range expected {
+		cell, err := vm.Segments.Memory.GetFelt(programDataBase.AddUint(offset))
+		if err != nil || cell != value {
+			t.Errorf("Wrong value at offset %d. Expected %v, got %v", offset, value, cell)
+		}
+	}
+	if _, err := vm.Segments.Memory.Get(programDataBase.AddUint(4)); err == nil {
+		t.Errorf("The field unknown to the VM should be left for the bootloader")
+	}
+
+	// Headers without one of the members the VM writes are rejected
+	delete(idsManager.Identifiers["execute_task.ProgramHeader"].Members, "n_builtins")
+	hintData = any(HintData{Ids: idsManager, Code: EXECUTE_TASK_LOAD_PROGRAM})
+	err = hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err == nil {
+		t.Errorf("EXECUTE_TASK_LOAD_PROGRAM should fail without ProgramHeader.n_builtins")
+	}
+}
+
+func TestInnerSelectBuiltinsSelectBuiltin(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Fp = NewRelocatable(1, 0)
+	vm.Segments.Memory.Insert(NewRelocatable(0, 0), NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)))
+	vm.Segments.Memory.Insert(NewRelocatable(0, 1), NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)))
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"n_selected_builtins": {NewMaybeRelocatableFelt(lambdaworks.FeltOne())},
+			"selected_encodings":  {NewMaybeRelocatableRelocatable(NewRelocatable(0, 0))},
+			"all_encodings":       {NewMaybeRelocatableRelocatable(NewRelocatable(0, 1))},
+			"select_builtin":      {nil},
+		},
+		vm,
+	)
+	scopes := NewExecutionScopes()
+	hintProcessor := CairoVmHintProcessor{}
+	for _, code := range []string{SELECT_BUILTINS_ENTER_SCOPE, INNER_SELECT_BUILTINS_SELECT_BUILTIN} {
+		hintData := any(HintData{Ids: idsManager, Code: code})
+		err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+		if err != nil {
+			t.Fatalf("Hint test failed with error %s", err)
+		}
+	}
+	selectBuiltin, err := idsManager.GetFelt("select_builtin", vm)
+	if err != nil || selectBuiltin != lambdaworks.FeltOne() {
+		t.Errorf("Builtin should have been selected, got %v", selectBuiltin)
+	}
+	nSelectedBuiltins, err := FetchScopeVar[lambdaworks.Felt]("n_selected_builtins", scopes)
+	if err != nil || !nSelectedBuiltins.IsZero() {
+		t.Errorf("n_selected_builtins should have been decremented, got %v", nSelectedBuiltins)
+	}
+}
+
+func TestInnerSelectBuiltinsSelectBuiltinExhausted(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Fp = NewRelocatable(1, 0)
+	vm.Segments.Memory.Insert(NewRelocatable(0, 0), NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)))
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"selected_encodings": {NewMaybeRelocatableRelocatable(NewRelocatable(0, 0))},
+			"all_encodings":      {NewMaybeRelocatableRelocatable(NewRelocatable(0, 0))},
+			"select_builtin":     {nil},
+		},
+		vm,
+	)
+	scopes := NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("n_selected_builtins", lambdaworks.FeltZero())
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{Ids: idsManager, Code: INNER_SELECT_BUILTINS_SELECT_BUILTIN})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Fatalf("INNER_SELECT_BUILTINS_SELECT_BUILTIN hint test failed with error %s", err)
+	}
+	selectBuiltin, err := idsManager.GetFelt("select_builtin", vm)
+	if err != nil || !selectBuiltin.IsZero() {
+		t.Errorf("Builtin shouldn't have been selected, got %v", selectBuiltin)
+	}
+}
+
+func TestSimpleBootloaderLoadInputDisableFilePaths(t *testing.T) {
+	programInputs := map[string]string{
+		`{"tasks": [{"type": "CairoPiePath", "path": "pie.zip"}]}`: "CairoPiePath task",
+		`{"tasks": [], "fact_topologies_path": "topologies.json"}`: "fact_topologies_path",
+	}
+	for programInput, name := range programInputs {
+		scopes := NewExecutionScopes()
+		scopes.AssignOrUpdateVariable("program_input", json.RawMessage(programInput))
+		hintData := any(HintData{Code: SIMPLE_BOOTLOADER_LOAD_INPUT})
+
+		hintProcessor := CairoVmHintProcessor{}
+		err := hintProcessor.ExecuteHint(NewVirtualMachine(), &hintData, nil, scopes)
+		if err != nil {
+			t.Errorf("SIMPLE_BOOTLOADER_LOAD_INPUT failed for an input with a %s: %s", name, err)
+		}
+		hintProcessor.DisableFilePathInputs = true
+		err = hintProcessor.ExecuteHint(NewVirtualMachine(), &hintData, nil, scopes)
+		if !errors.Is(err, bootloader.ErrFilePathInput) {
+			t.Errorf("Expected ErrFilePathInput for an input with a %s, got: %v", name, err)
+		}
+	}
+}
@@ -32,6 +32,48 @@ func (d *DictManager) NewDefaultDictionary(defaultValue *MaybeRelocatable, vm *V
 	return base
 }
 
+// Snapshot of a dictionary tracked by a DictManager (see DictManager.GetState)
+type DictTrackerState struct {
+	Data map[MaybeRelocatable]MaybeRelocatable
+	// Value of the keys that are read before being written, nil if the dictionary has no default value
+	DefaultValue *MaybeRelocatable
+	CurrentPtr   Relocatable
+}
+
+// Returns the dictionaries of the manager, indexed by the segment they were created in, so that they can be restored
+// with DictManagerFromState
+func (d *DictManager) GetState() map[int]DictTrackerState {
+	state := make(map[int]DictTrackerState, len(d.trackers))
+	for segmentIndex, tracker := range d.trackers {
+		state[segmentIndex] = DictTrackerState{Data: tracker.data.dict, DefaultValue: tracker.data.defaultValue, CurrentPtr: tracker.CurrentPtr}
+	}
+	return state
+}
+
+func DictManagerFromState(state map[int]DictTrackerState) *DictManager {
+	dictManager := NewDictManager()
+	for segmentIndex, trackerState := range state {
+		data := trackerState.Data
+		if data == nil {
+			data = make(map[MaybeRelocatable]MaybeRelocatable)
+		}
+		dictManager.trackers[segmentIndex] = &DictTracker{
+			data:       Dictionary{dict: data, defaultValue: trackerState.DefaultValue},
+			CurrentPtr: trackerState.CurrentPtr,
+		}
+	}
+	return &dictManager
+}
+
+// Returns the amount of entries across all the managed dictionaries, see vm.RunLimits.MaxDictEntries
+func (d *DictManager) DictEntries() uint {
+	entries := uint(0)
+	for _, tracker := range d.trackers {
+		entries += uint(len(tracker.data.dict))
+	}
+	return entries
+}
+
 func (d *DictManager) GetTracker(dict_ptr Relocatable) (*DictTracker, error) {
 	tracker, ok := d.trackers[dict_ptr.SegmentIndex]
 	if !ok {
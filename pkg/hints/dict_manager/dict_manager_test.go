@@ -209,3 +209,19 @@ func TestDefaultDictionary(t *testing.T) {
 		t.Error("Wrong value returned by Get")
 	}
 }
+
+func TestDictManagerDictEntries(t *testing.T) {
+	dictManager := NewDictManager()
+	vm := vm.NewVirtualMachine()
+	initialDict := &map[MaybeRelocatable]MaybeRelocatable{
+		*NewMaybeRelocatableFelt(FeltFromUint64(1)): *NewMaybeRelocatableFelt(FeltFromUint64(2)),
+	}
+	dictManager.NewDictionary(initialDict, vm)
+	base := dictManager.NewDefaultDictionary(NewMaybeRelocatableFelt(FeltZero()), vm)
+	tracker, _ := dictManager.GetTracker(base)
+	tracker.GetValue(NewMaybeRelocatableFelt(FeltFromUint64(3)))
+	tracker.InsertValue(NewMaybeRelocatableFelt(FeltFromUint64(4)), NewMaybeRelocatableFelt(FeltFromUint64(5)))
+	if dictManager.DictEntries() != 3 {
+		t.Errorf("Expected 3 dict entries, got %d", dictManager.DictEntries())
+	}
+}
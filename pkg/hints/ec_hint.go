@@ -12,6 +12,7 @@ import (
 	. "github.com/lambdaclass/cairo-vm.go/pkg/types"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
 	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
 )
 
 type EcPoint struct {
@@ -193,7 +194,7 @@ func computeSlope(vm *VirtualMachine, execScopes ExecutionScopes, idsData IdsMan
 // y = pack(ids.point.y, PRIME)
 //
 // value = new_x = (pow(slope, 2, SECP_P) - 2 * x) % SECP_P
-func ecDoubleAssignNewX(vm *VirtualMachine, execScopes ExecutionScopes, ids IdsManager, secpP big.Int) error {
+func ecDoubleAssignNewX(vm *VirtualMachine, execScopes ExecutionScopes, ids IdsManager, pointName string, secpP big.Int) error {
 	execScopes.AssignOrUpdateVariable("SECP_P", secpP)
 
 	slope3, err := BigInt3FromVarName("slope", ids, vm)
@@ -202,7 +203,7 @@ func ecDoubleAssignNewX(vm *VirtualMachine, execScopes ExecutionScopes, ids IdsM
 	}
 	packedSlope := slope3.Pack86()
 	slope := new(big.Int).Mod(&packedSlope, Prime())
-	point, err := EcPointFromVarName("point", vm, ids)
+	point, err := EcPointFromVarName(pointName, vm, ids)
 	if err != nil {
 		return err
 	}
@@ -227,6 +228,44 @@ func ecDoubleAssignNewX(vm *VirtualMachine, execScopes ExecutionScopes, ids IdsM
 	return nil
 }
 
+/*
+Implements hint:
+
+	%{ value = new_y = (slope * (x - new_x) - y) % SECP_P %}
+*/
+func ecDoubleAssignNewY(execScopes *ExecutionScopes) error {
+	// slope, x & y are stored as pointers by ecDoubleAssignNewX
+	slope, err := FetchScopeVar[*big.Int]("slope", execScopes)
+	if err != nil {
+		return err
+	}
+	x, err := FetchScopeVar[*big.Int]("x", execScopes)
+	if err != nil {
+		return err
+	}
+	y, err := FetchScopeVar[*big.Int]("y", execScopes)
+	if err != nil {
+		return err
+	}
+	newX, err := FetchScopeVar[big.Int]("new_x", execScopes)
+	if err != nil {
+		return err
+	}
+	secpP, err := FetchScopeVar[big.Int]("SECP_P", execScopes)
+	if err != nil {
+		return err
+	}
+
+	value := new(big.Int).Sub(x, &newX)
+	value.Mul(value, slope)
+	value.Sub(value, y)
+	value.Mod(value, &secpP)
+
+	execScopes.AssignOrUpdateVariable("value", *value)
+	execScopes.AssignOrUpdateVariable("new_y", *value)
+	return nil
+}
+
 /*
 Implements hint:
 %{ from starkware.cairo.common.cairo_secp.secp256r1_utils import SECP256R1_ALPHA as ALPHA %}
@@ -407,3 +446,58 @@ func fastEcAddAssignNewY(execScopes *ExecutionScopes) error {
 
 	return nil
 }
+
+/*
+Implements hint:
+
+	%{ memory[ap] = (ids.scalar % PRIME) % 2 %}
+*/
+func ecMulInner(ids IdsManager, vm *VirtualMachine) error {
+	scalar, err := ids.GetFelt("scalar", vm)
+	if err != nil {
+		return err
+	}
+	return vm.Segments.Memory.Insert(vm.RunContext.Ap, memory.NewMaybeRelocatableFelt(scalar.And(FeltOne())))
+}
+
+/*
+Implements hint:
+
+	%{
+		from starkware.crypto.signature.signature import ALPHA, BETA, FIELD_PRIME
+		from starkware.python.math_utils import recover_y
+		ids.p.x = ids.x
+		# This raises an exception if `x` is not on the curve.
+		ids.p.y = recover_y(ids.x, ALPHA, BETA, FIELD_PRIME)
+	%}
+*/
+func recoverY(ids IdsManager, vm *VirtualMachine) error {
+	xFelt, err := ids.GetFelt("x", vm)
+	if err != nil {
+		return err
+	}
+	// Stark curve parameters
+	alpha := big.NewInt(1)
+	beta, _ := new(big.Int).SetString("3141592653589793238462643383279502884197169399375105820974944592307816406665", 10)
+	fieldPrime := Prime()
+
+	x := xFelt.ToBigInt()
+	ySquared := new(big.Int).Exp(x, big.NewInt(3), fieldPrime)
+	ySquared.Add(ySquared, new(big.Int).Mul(alpha, x))
+	ySquared.Add(ySquared, beta)
+	ySquared.Mod(ySquared, fieldPrime)
+	y := new(big.Int).ModSqrt(ySquared, fieldPrime)
+	if y == nil {
+		return errors.New("x is not on the curve")
+	}
+	// recover_y returns the smallest of both roots
+	if negY := new(big.Int).Sub(fieldPrime, y); negY.Cmp(y) == -1 {
+		y = negY
+	}
+
+	err = ids.InsertStructField("p", 0, memory.NewMaybeRelocatableFelt(xFelt), vm)
+	if err != nil {
+		return err
+	}
+	return ids.InsertStructField("p", 1, memory.NewMaybeRelocatableFelt(FeltFromBigInt(y)), vm)
+}
@@ -304,6 +304,60 @@ func TestEcDoubleAssignNewXOk(t *testing.T) {
 	}
 }
 
+func TestEcDoubleAssignNewXV4Ok(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"slope": {
+				NewMaybeRelocatableFelt(FeltFromUint64(3)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+			},
+			"pt": {
+				// X
+				NewMaybeRelocatableFelt(FeltFromUint64(2)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+				// Y
+				NewMaybeRelocatableFelt(FeltFromUint64(4)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+			},
+		},
+		vm,
+	)
+
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: EC_DOUBLE_ASSIGN_NEW_X_V4,
+	})
+
+	execScopes := types.NewExecutionScopes()
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, execScopes)
+	if err != nil {
+		t.Fatalf("EC_DOUBLE_ASSIGN_NEW_X_V4 hint failed with error: %s", err)
+	}
+
+	secpPUncast, _ := execScopes.Get("SECP_P")
+	secpP := secpPUncast.(big.Int)
+	expectedSecpP := SECP_P()
+	if secpP.Cmp(&expectedSecpP) != 0 {
+		t.Errorf("EC_DOUBLE_ASSIGN_NEW_X_V4 hint failed: expected SECP_P to be secp256k1's prime, got %v", secpP)
+	}
+	valueUncast, _ := execScopes.Get("value")
+	value := valueUncast.(big.Int)
+	if value.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("EC_DOUBLE_ASSIGN_NEW_X_V4 hint failed: expected value (%v) to be 5", value)
+	}
+	yUncast, _ := execScopes.Get("y")
+	y := yUncast.(*big.Int)
+	if y.Cmp(big.NewInt(4)) != 0 {
+		t.Errorf("EC_DOUBLE_ASSIGN_NEW_X_V4 hint failed: expected y (%v) to be 4", y)
+	}
+}
+
 func TestRunComputeSlopeV2Ok(t *testing.T) {
 
 	vm := NewVirtualMachine()
@@ -655,3 +709,105 @@ func TestFastEcAddAssignNewY(t *testing.T) {
 		t.Errorf("expected new_y=%v, got: new_y=%v", expectedValue, valueRes)
 	}
 }
+
+func TestEcMulInner(t *testing.T) {
+	for scalar, expected := range map[uint64]uint64{11: 1, 14: 0} {
+		vm := NewVirtualMachine()
+		vm.Segments.AddSegment()
+		vm.Segments.AddSegment()
+		vm.RunContext.Ap = NewRelocatable(1, 0)
+		idsManager := SetupIdsForTest(
+			map[string][]*MaybeRelocatable{
+				"scalar": {NewMaybeRelocatableFelt(FeltFromUint64(scalar))},
+			},
+			vm,
+		)
+		hintProcessor := CairoVmHintProcessor{}
+		hintData := any(HintData{
+			Ids:  idsManager,
+			Code: EC_MUL_INNER,
+		})
+		err := hintProcessor.ExecuteHint(vm, &hintData, nil, types.NewExecutionScopes())
+		if err != nil {
+			t.Errorf("EC_MUL_INNER hint test failed with error %s", err)
+		}
+		bit, err := vm.Segments.Memory.GetFelt(vm.RunContext.Ap)
+		if err != nil || bit != FeltFromUint64(expected) {
+			t.Errorf("Wrong/No value inserted into memory[ap] for scalar %d: %v", scalar, bit)
+		}
+	}
+}
+
+func TestRecoverY(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	// The x coordinate of the stark curve's generator
+	x := FeltFromHex("0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca")
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"x": {NewMaybeRelocatableFelt(x)},
+			"p": {nil, nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: RECOVER_Y,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, types.NewExecutionScopes())
+	if err != nil {
+		t.Errorf("RECOVER_Y hint test failed with error %s", err)
+	}
+	px, err := idsManager.GetStructFieldFelt("p", 0, vm)
+	if err != nil || px != x {
+		t.Errorf("Wrong/No value inserted into ids.p.x: %v", px)
+	}
+	py, err := idsManager.GetStructFieldFelt("p", 1, vm)
+	expectedY := FeltFromHex("0x5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f")
+	if err != nil || py != expectedY {
+		t.Errorf("Wrong/No value inserted into ids.p.y: %v", py.ToHexString())
+	}
+}
+
+func TestRecoverYNotOnCurve(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"x": {NewMaybeRelocatableFelt(FeltFromUint64(0))},
+			"p": {nil, nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: RECOVER_Y,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, types.NewExecutionScopes())
+	if err == nil {
+		t.Errorf("RECOVER_Y hint test should have failed")
+	}
+}
+
+func TestEcDoubleAssignNewY(t *testing.T) {
+	vm := NewVirtualMachine()
+	execScopes := types.NewExecutionScopes()
+	execScopes.AssignOrUpdateVariable("slope", big.NewInt(3))
+	execScopes.AssignOrUpdateVariable("x", big.NewInt(2))
+	execScopes.AssignOrUpdateVariable("y", big.NewInt(4))
+	execScopes.AssignOrUpdateVariable("new_x", *big.NewInt(5))
+	execScopes.AssignOrUpdateVariable("SECP_P", SECP_P())
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{Code: EC_DOUBLE_ASSIGN_NEW_Y})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, execScopes)
+	if err != nil {
+		t.Errorf("EC_DOUBLE_ASSIGN_NEW_Y hint failed with error: %s", err)
+	}
+	// (3 * (2 - 5) - 4) % SECP_P
+	secpP := SECP_P()
+	expected := new(big.Int).Sub(&secpP, big.NewInt(13))
+	CheckScopeVar[big.Int]("value", *expected, execScopes, t)
+	CheckScopeVar[big.Int]("new_y", *expected, execScopes, t)
+}
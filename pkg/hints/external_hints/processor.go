@@ -0,0 +1,411 @@
+package external_hints
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
+)
+
+// Hint processor that runs the hints implemented by CairoVmHintProcessor natively,
+// and sends every other hint to an external worker process
+type ExternalHintProcessor struct {
+	hints.CairoVmHintProcessor
+	// Maximum time the worker can take to execute a hint, after which the worker is stopped and the hint fails.
+	// 0 for no timeout
+	HintTimeout time.Duration
+	// Nil if the worker wasn't started by the processor
+	cmd     *exec.Cmd
+	input   io.WriteCloser
+	output  io.Reader
+	encoder *json.Encoder
+	decoder *json.Decoder
+	nextId  uint64
+	// Hints are executed one at a time
+	mutex sync.Mutex
+	// Set once the worker is stopped by a timeout or a cancellation, after which every hint fails
+	stopped atomic.Bool
+}
+
+// Maximum amount of cells a read_memory request can read
+const maxReadMemorySize = 1 << 16
+
+// Maximum amount of segments a hint executed by the worker can add
+const maxNewSegments = 1 << 16
+
+func ErrHintWorker(err error) error {
+	return errors.Wrap(err, "Hint worker error")
+}
+
+// Starts the worker process and returns a processor that sends the unknown hints to it.
+// The worker's stderr is forwarded to the VM's stderr
+func NewExternalHintProcessor(command string, args ...string) (*ExternalHintProcessor, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Stderr = os.Stderr
+	input, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, ErrHintWorker(err)
+	}
+	output, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, ErrHintWorker(err)
+	}
+	err = cmd.Start()
+	if err != nil {
+		return nil, ErrHintWorker(err)
+	}
+	processor := NewExternalHintProcessorFromStreams(output, input)
+	processor.cmd = cmd
+	return processor, nil
+}
+
+// Returns a processor that talks to an already running worker: requests are written to workerInput and
+// the worker's messages are read from workerOutput
+func NewExternalHintProcessorFromStreams(workerOutput io.Reader, workerInput io.WriteCloser) *ExternalHintProcessor {
+	decoder := json.NewDecoder(bufio.NewReader(workerOutput))
+	decoder.UseNumber()
+	return &ExternalHintProcessor{
+		input:   workerInput,
+		output:  workerOutput,
+		encoder: json.NewEncoder(workerInput),
+		decoder: decoder,
+	}
+}
+
+// Closes the worker's input, and waits for the worker process to exit if the processor started it
+func (p *ExternalHintProcessor) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.stopped.Load() {
+		// The worker was already killed, its exit status doesn't matter
+		if p.cmd != nil {
+			p.cmd.Wait()
+		}
+		return nil
+	}
+	err := p.input.Close()
+	if p.cmd != nil {
+		waitErr := p.cmd.Wait()
+		if err == nil && waitErr != nil {
+			err = ErrHintWorker(waitErr)
+		}
+	}
+	return err
+}
+
+func (p *ExternalHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	return p.ExecuteHintWithContext(context.Background(), vm, hintData, constants, execScopes)
+}
+
+// Same as ExecuteHint, but stops the worker if ctx is done before it executes the hint. Workers can't be resumed, so
+// every later hint fails
+func (p *ExternalHintProcessor) ExecuteHintWithContext(ctx context.Context, vm *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	data, ok := (*hintData).(hints.HintData)
+	if !ok {
+		return errors.New("Wrong Hint Data")
+	}
+	if p.IsHintSupported(data.Code) {
+		return p.CairoVmHintProcessor.ExecuteHint(vm, hintData, constants, execScopes)
+	}
+	params, err := newExecuteHintParams(&data, vm, constants, execScopes)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.HintTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.HintTimeout)
+		defer cancel()
+	}
+	rawResult, err := p.call(ctx, "execute_hint", params, vm)
+	if err != nil {
+		return err
+	}
+	var result executeHintResult
+	err = json.Unmarshal(rawResult, &result)
+	if err != nil {
+		return ErrHintWorker(errors.Wrap(err, "Invalid execute_hint result"))
+	}
+	return applyResult(&result, vm, execScopes)
+}
+
+func newExecuteHintParams(data *hints.HintData, vm *vm.VirtualMachine, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) (*executeHintParams, error) {
+	params := &executeHintParams{
+		Code:        data.Code,
+		Ids:         make(map[string]idsEntry, len(data.Ids.References)),
+		Constants:   make(map[string]*big.Int),
+		Ap:          encodeRelocatable(vm.RunContext.Ap),
+		Fp:          encodeRelocatable(vm.RunContext.Fp),
+		Pc:          encodeRelocatable(vm.RunContext.Pc),
+		Prime:       lambdaworks.Prime(),
+		NumSegments: vm.Segments.Memory.NumSegments(),
+		Scope:       make(map[string]any),
+		Structs:     make(map[string]map[string]structMember),
+	}
+	for name, reference := range data.Ids.References {
+		entry := idsEntry{Type: referenceType(&reference)}
+		addStructDefinitions(params.Structs, &data.Ids, entry.Type)
+		addr, err := data.Ids.GetAddr(name, vm)
+		if err == nil {
+			encodedAddr := encodeRelocatable(addr)
+			entry.Address = &encodedAddr
+		}
+		value, err := data.Ids.Get(name, vm)
+		if err == nil {
+			entry.Value = encodeMaybeRelocatable(value)
+		}
+		params.Ids[name] = entry
+	}
+	if constants != nil {
+		// Accessible scopes are listed from outer to inner, so inner constants take precedence
+		for _, scope := range data.Ids.AccessibleScopes {
+			prefix := scope + "."
+			for path, constant := range *constants {
+				name, found := strings.CutPrefix(path, prefix)
+				if found && !strings.Contains(name, ".") {
+					params.Constants[name] = constant.ToBigInt()
+				}
+			}
+		}
+	}
+	if execScopes != nil {
+		scopeVars, err := execScopes.GetLocalVariables()
+		if err != nil {
+			return nil, err
+		}
+		for name, scopeVar := range scopeVars {
+			encoded, ok := encodeScopeVar(scopeVar)
+			if ok {
+				params.Scope[name] = encoded
+			}
+		}
+	}
+	return params, nil
+}
+
+// Returns the type of an ids given its reference: [cast(addr, T*)] is a T located at addr
+func referenceType(reference *hint_utils.HintReference) string {
+	if reference.Dereference {
+		return strings.TrimSuffix(reference.ValueType, "*")
+	}
+	return reference.ValueType
+}
+
+// Adds the definitions of the struct pointed to or given by cairoType, and of the structs of its members, to structs.
+// Types that aren't structs of the program, such as felt, are skipped
+func addStructDefinitions(structs map[string]map[string]structMember, ids *hint_utils.IdsManager, cairoType string) {
+	cairoType = strings.TrimRight(cairoType, "*")
+	if _, found := structs[cairoType]; found {
+		return
+	}
+	members, err := ids.GetStructMembers(cairoType)
+	if err != nil {
+		return
+	}
+	definition := make(map[string]structMember, len(members))
+	for name, member := range members {
+		definition[name] = structMember{Offset: member.Offset, Type: member.CairoType}
+	}
+	structs[cairoType] = definition
+	for _, member := range members {
+		addStructDefinitions(structs, ids, member.CairoType)
+	}
+}
+
+// Sends a request to the worker and waits for its result, answering the worker's requests in the meantime.
+// The worker is stopped if ctx is done first
+func (p *ExternalHintProcessor) call(ctx context.Context, method string, params any, vm *vm.VirtualMachine) (json.RawMessage, error) {
+	if p.stopped.Load() {
+		return nil, ErrHintWorker(errors.New("The worker was stopped by a previous timeout or cancellation"))
+	}
+	if ctx.Done() != nil {
+		done := make(chan struct{})
+		exited := make(chan struct{})
+		// Waiting for the watcher to exit ensures that the worker isn't stopped once the call returned
+		defer func() {
+			close(done)
+			<-exited
+		}()
+		go func() {
+			defer close(exited)
+			select {
+			case <-ctx.Done():
+				// ctx may be done right as the call returns, the worker is only stopped if the call is still running
+				select {
+				case <-done:
+				default:
+					p.stopWorker()
+				}
+			case <-done:
+			}
+		}()
+	}
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	p.nextId++
+	id := p.nextId
+	err = p.encoder.Encode(rpcMessage{JsonRpc: jsonRpcVersion, Id: &id, Method: method, Params: rawParams})
+	if err != nil {
+		if p.stopped.Load() && ctx.Err() != nil {
+			return nil, ErrHintWorker(errors.Wrap(ctx.Err(), "Stopped the worker"))
+		}
+		return nil, ErrHintWorker(err)
+	}
+	for {
+		var message rpcMessage
+		err = p.decoder.Decode(&message)
+		if err != nil {
+			if p.stopped.Load() && ctx.Err() != nil {
+				return nil, ErrHintWorker(errors.Wrap(ctx.Err(), "Stopped the worker"))
+			}
+			if err == io.EOF {
+				err = errors.New("The worker closed its output")
+			}
+			return nil, ErrHintWorker(err)
+		}
+		if message.Method != "" {
+			err = p.answer(&message, vm)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if message.Id == nil || *message.Id != id {
+			return nil, ErrHintWorker(errors.New("Received a response to an unknown request"))
+		}
+		if message.Error != nil {
+			return nil, ErrHintWorker(errors.New(message.Error.Message))
+		}
+		return message.Result, nil
+	}
+}
+
+// Kills the worker and closes its streams, which makes the pending writes of requests and reads of messages fail
+func (p *ExternalHintProcessor) stopWorker() {
+	p.stopped.Store(true)
+	p.input.Close()
+	if closer, ok := p.output.(io.Closer); ok {
+		closer.Close()
+	}
+	if p.cmd != nil {
+		p.cmd.Process.Kill()
+	}
+}
+
+// Answers a request sent by the worker while it executes a hint
+func (p *ExternalHintProcessor) answer(request *rpcMessage, vm *vm.VirtualMachine) error {
+	response := rpcMessage{JsonRpc: jsonRpcVersion, Id: request.Id}
+	switch request.Method {
+	case "read_memory":
+		var params readMemoryParams
+		err := json.Unmarshal(request.Params, &params)
+		if err != nil {
+			response.Error = &rpcError{Code: errCodeInvalidParams, Message: err.Error()}
+			break
+		}
+		if params.Size > maxReadMemorySize {
+			response.Error = &rpcError{Code: errCodeInvalidParams, Message: "Can't read more than 65536 cells at once"}
+			break
+		}
+		result := readMemoryResult{Values: make([]any, 0, params.Size)}
+		addr := params.Address.relocatable()
+		for i := uint(0); i < params.Size; i++ {
+			value, err := vm.Segments.Memory.Get(addr.AddUint(i))
+			if err != nil {
+				// Unknown cells are sent as null
+				result.Values = append(result.Values, nil)
+			} else {
+				result.Values = append(result.Values, encodeMaybeRelocatable(value))
+			}
+		}
+		response.Result, err = json.Marshal(result)
+		if err != nil {
+			return err
+		}
+	default:
+		response.Error = &rpcError{Code: errCodeMethodNotFound, Message: "Method not found: " + request.Method}
+	}
+	// Notifications don't get a response
+	if request.Id == nil {
+		return nil
+	}
+	err := p.encoder.Encode(response)
+	if err != nil {
+		return ErrHintWorker(err)
+	}
+	return nil
+}
+
+// Applies the effects of a hint executed by the worker
+func applyResult(result *executeHintResult, vm *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+	if result.NewSegments > maxNewSegments {
+		return ErrHintWorker(errors.Errorf("Can't add more than %d segments in a hint, got %d", maxNewSegments, result.NewSegments))
+	}
+	for i := uint(0); i < result.NewSegments; i++ {
+		vm.Segments.AddSegment()
+		// Segments over RunLimits.MaxSegments aren't added, so the rest would be refused too
+		err := vm.Segments.Memory.LimitError()
+		if err != nil {
+			return err
+		}
+	}
+	for _, write := range result.MemoryWrites {
+		value, err := decodeMaybeRelocatable(write.Value)
+		if err != nil {
+			return ErrHintWorker(err)
+		}
+		err = vm.Segments.Memory.Insert(write.Address.relocatable(), value)
+		if err != nil {
+			return err
+		}
+	}
+	if len(result.ScopeUpdates) == 0 && !result.ExitScope && result.EnterScope == nil {
+		return nil
+	}
+	if execScopes == nil {
+		return ErrHintWorker(errors.New("The hint updated the execution scopes, but there are none"))
+	}
+	for name, rawValue := range result.ScopeUpdates {
+		value, err := decodeScopeVar(rawValue)
+		if err != nil {
+			return ErrHintWorker(err)
+		}
+		execScopes.AssignOrUpdateVariable(name, value)
+	}
+	if result.ExitScope {
+		err := execScopes.ExitScope()
+		if err != nil {
+			return err
+		}
+	}
+	if result.EnterScope != nil {
+		newScope := make(map[string]interface{}, len(result.EnterScope))
+		for name, rawValue := range result.EnterScope {
+			value, err := decodeScopeVar(rawValue)
+			if err != nil {
+				return ErrHintWorker(err)
+			}
+			newScope[name] = value
+		}
+		execScopes.EnterScope(newScope)
+	}
+	return nil
+}
@@ -0,0 +1,410 @@
+package external_hints_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/external_hints"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+type fakeWorker struct {
+	t      *testing.T
+	input  *bufio.Scanner
+	output io.Writer
+}
+
+// Starts a worker that answers each execute_hint request with handle, which can send requests to the VM through call
+func startFakeWorker(t *testing.T, handle func(w *fakeWorker, params map[string]any) string) *external_hints.ExternalHintProcessor {
+	vmToWorker, workerInput := io.Pipe()
+	workerOutput, workerToVm := io.Pipe()
+	worker := &fakeWorker{t: t, input: bufio.NewScanner(vmToWorker), output: workerToVm}
+	worker.input.Buffer(make([]byte, 1<<20), 1<<20)
+	go func() {
+		defer workerToVm.Close()
+		for worker.input.Scan() {
+			var request struct {
+				Id     uint64         `json:"id"`
+				Method string         `json:"method"`
+				Params map[string]any `json:"params"`
+			}
+			err := json.Unmarshal(worker.input.Bytes(), &request)
+			if err != nil || request.Method != "execute_hint" {
+				t.Errorf("Invalid request %s: %v", worker.input.Text(), err)
+				return
+			}
+			response := handle(worker, request.Params)
+			_, err = io.WriteString(workerToVm, strings.Replace(response, "ID", strconv.FormatUint(request.Id, 10), 1)+"\n")
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return external_hints.NewExternalHintProcessorFromStreams(workerOutput, workerInput)
+}
+
+func (w *fakeWorker) call(request string) string {
+	_, err := io.WriteString(w.output, request+"\n")
+	if err != nil || !w.input.Scan() {
+		w.t.Errorf("Failed to call the VM: %v", err)
+		return ""
+	}
+	return w.input.Text()
+}
+
+func executeHint(processor *external_hints.ExternalHintProcessor, code string, vm *VirtualMachine, ids IdsManager, scopes *types.ExecutionScopes) error {
+	hintData := any(HintData{Ids: ids, Code: code})
+	return processor.ExecuteHint(vm, &hintData, nil, scopes)
+}
+
+func TestExternalHintAppliesEffects(t *testing.T) {
+	var params map[string]any
+	var readMemoryResponse string
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		params = p
+		readMemoryResponse = w.call(`{"jsonrpc": "2.0", "id": 7, "method": "read_memory", "params": {"address": {"segment_index": 1, "offset": 0}, "size": 2}}`)
+		return `{"jsonrpc": "2.0", "id": ID, "result": {
+			"new_segments": 1,
+			"memory_writes": [
+				{"address": {"segment_index": 1, "offset": 5}, "value": 42},
+				{"address": {"segment_index": 2, "offset": 0}, "value": -1},
+				{"address": {"segment_index": 2, "offset": 1}, "value": {"segment_index": 1, "offset": 5}}
+			],
+			"scope_updates": {"n": 6, "name": "abc"},
+			"enter_scope": {"inner": 1}
+		}}`
+	})
+	defer processor.Close()
+
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Ap = NewRelocatable(1, 5)
+	vm.RunContext.Fp = NewRelocatable(1, 0)
+	ids := SetupIdsForTest(map[string][]*MaybeRelocatable{
+		"x": {NewMaybeRelocatableFelt(FeltFromUint64(41))},
+	}, vm)
+	scopes := types.NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("n", *big.NewInt(5))
+
+	err := executeHint(processor, "memory[ap] = ids.x + 1", vm, ids, scopes)
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+
+	if params["code"] != "memory[ap] = ids.x + 1" || params["num_segments"] != float64(2) {
+		t.Errorf("Wrong params: %v", params)
+	}
+	x := params["ids"].(map[string]any)["x"].(map[string]any)
+	if x["value"] != float64(41) || x["address"].(map[string]any)["segment_index"] != float64(1) {
+		t.Errorf("Wrong ids.x params: %v", x)
+	}
+	if params["scope"].(map[string]any)["n"] != float64(5) {
+		t.Errorf("Wrong scope params: %v", params["scope"])
+	}
+	expectedReadMemory := `{"jsonrpc":"2.0","id":7,"result":{"values":[41,null]}}`
+	if readMemoryResponse != expectedReadMemory {
+		t.Errorf("Wrong read_memory response. Expected %s, got %s", expectedReadMemory, readMemoryResponse)
+	}
+
+	value, err := vm.Segments.Memory.GetFelt(NewRelocatable(1, 5))
+	if err != nil || value != FeltFromUint64(42) {
+		t.Errorf("Wrong value at ap: %v, %v", value, err)
+	}
+	value, err = vm.Segments.Memory.GetFelt(NewRelocatable(2, 0))
+	if err != nil || value != FeltZero().Sub(FeltOne()) {
+		t.Errorf("Wrong value in the new segment: %v, %v", value, err)
+	}
+	ptr, err := vm.Segments.Memory.GetRelocatable(NewRelocatable(2, 1))
+	if err != nil || ptr != NewRelocatable(1, 5) {
+		t.Errorf("Wrong pointer in the new segment: %v, %v", ptr, err)
+	}
+
+	inner, err := types.FetchScopeVar[big.Int]("inner", scopes)
+	if err != nil || inner.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("Wrong inner scope variable: %v, %v", inner.String(), err)
+	}
+	err = scopes.ExitScope()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := types.FetchScopeVar[big.Int]("n", scopes)
+	if err != nil || n.Cmp(big.NewInt(6)) != 0 {
+		t.Errorf("Wrong n scope variable: %v, %v", n.String(), err)
+	}
+	name, err := types.FetchScopeVar[string]("name", scopes)
+	if err != nil || name != "abc" {
+		t.Errorf("Wrong name scope variable: %v, %v", name, err)
+	}
+}
+
+func TestExternalHintWorkerError(t *testing.T) {
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		return `{"jsonrpc": "2.0", "id": ID, "error": {"code": -32603, "message": "AssertionError: x must be positive"}}`
+	})
+	defer processor.Close()
+	vm := NewVirtualMachine()
+	err := executeHint(processor, "assert ids.x > 0, 'x must be positive'", vm, IdsManager{}, types.NewExecutionScopes())
+	expected := "Hint worker error: AssertionError: x must be positive"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Wrong error. Expected %q, got %v", expected, err)
+	}
+}
+
+func TestExternalHintInvalidMemoryWrite(t *testing.T) {
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		return `{"jsonrpc": "2.0", "id": ID, "result": {"memory_writes": [{"address": {"segment_index": 0, "offset": 0}, "value": "abc"}]}}`
+	})
+	defer processor.Close()
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	err := executeHint(processor, "memory[ap] = 'abc'", vm, IdsManager{}, types.NewExecutionScopes())
+	if err == nil || !strings.Contains(err.Error(), "Invalid memory value") {
+		t.Errorf("Expected an invalid memory value error, got %v", err)
+	}
+}
+
+func TestExternalHintRunsNativeHintsLocally(t *testing.T) {
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		t.Errorf("The native hint was sent to the worker")
+		return `{"jsonrpc": "2.0", "id": ID, "result": {}}`
+	})
+	defer processor.Close()
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	err := executeHint(processor, ADD_SEGMENT, vm, IdsManager{}, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	value, err := vm.Segments.Memory.GetRelocatable(vm.RunContext.Ap)
+	if err != nil || value != NewRelocatable(2, 0) {
+		t.Errorf("Wrong value at ap: %v, %v", value, err)
+	}
+}
+
+func TestExternalHintTimeoutStopsWorker(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		<-release
+		return `{"jsonrpc": "2.0", "id": ID, "result": {}}`
+	})
+	defer processor.Close()
+	processor.HintTimeout = 20 * time.Millisecond
+	vm := NewVirtualMachine()
+	err := executeHint(processor, "while True: pass", vm, IdsManager{}, types.NewExecutionScopes())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the hint to time out, got %v", err)
+	}
+	err = executeHint(processor, "x = 1", vm, IdsManager{}, types.NewExecutionScopes())
+	if err == nil || !strings.Contains(err.Error(), "The worker was stopped") {
+		t.Errorf("Expected the stopped worker to fail the next hint, got %v", err)
+	}
+}
+
+func TestExternalHintCancelledContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		<-release
+		return `{"jsonrpc": "2.0", "id": ID, "result": {}}`
+	})
+	defer processor.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	hintData := any(HintData{Code: "while True: pass"})
+	err := processor.ExecuteHintWithContext(ctx, NewVirtualMachine(), &hintData, nil, types.NewExecutionScopes())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the hint to be cancelled, got %v", err)
+	}
+}
+
+func TestExternalHintTimeoutKillsWorkerProcess(t *testing.T) {
+	processor, err := external_hints.NewExternalHintProcessor("sleep", "60")
+	if err != nil {
+		t.Skipf("Can't start the worker: %s", err)
+	}
+	processor.HintTimeout = 20 * time.Millisecond
+	err = executeHint(processor, "x = 1", NewVirtualMachine(), IdsManager{}, types.NewExecutionScopes())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the hint to time out, got %v", err)
+	}
+	closed := make(chan error, 1)
+	go func() { closed <- processor.Close() }()
+	select {
+	case err = <-closed:
+		if err != nil {
+			t.Errorf("Close failed with error %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("The worker process wasn't killed")
+	}
+}
+
+func TestExternalHintTimeoutDoesntStopFinishedHints(t *testing.T) {
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		return `{"jsonrpc": "2.0", "id": ID, "result": {}}`
+	})
+	defer processor.Close()
+	// Each hint cancels its timeout once it returns, which mustn't be taken as the hint timing out
+	processor.HintTimeout = time.Hour
+	vm := NewVirtualMachine()
+	for i := 0; i < 5000; i++ {
+		err := executeHint(processor, "x = 1", vm, IdsManager{}, types.NewExecutionScopes())
+		if err != nil {
+			t.Fatalf("Hint %d failed with error %s", i, err)
+		}
+	}
+}
+
+func TestExternalHintTooManyNewSegments(t *testing.T) {
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		return `{"jsonrpc": "2.0", "id": ID, "result": {"new_segments": 18446744073709551615}}`
+	})
+	defer processor.Close()
+	vm := NewVirtualMachine()
+	err := executeHint(processor, "segments.add()", vm, IdsManager{}, types.NewExecutionScopes())
+	if err == nil || !strings.Contains(err.Error(), "Can't add more than 65536 segments") {
+		t.Errorf("Expected the hint to fail, got %v", err)
+	}
+	if vm.Segments.Memory.NumSegments() != 0 {
+		t.Errorf("Expected no segment to be added, got %d", vm.Segments.Memory.NumSegments())
+	}
+}
+
+func TestExternalHintNewSegmentsLimit(t *testing.T) {
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		return `{"jsonrpc": "2.0", "id": ID, "result": {"new_segments": 1000}}`
+	})
+	defer processor.Close()
+	vm := NewVirtualMachine()
+	vm.Segments.Memory.Limits = MemoryLimits{MaxSegments: 3}
+	err := executeHint(processor, "segments.add()", vm, IdsManager{}, types.NewExecutionScopes())
+	if !errors.Is(err, ErrSegmentsLimit) {
+		t.Errorf("Expected the segments limit to be exceeded, got %v", err)
+	}
+	if vm.Segments.Memory.NumSegments() != 3 {
+		t.Errorf("Expected 3 segments, got %d", vm.Segments.Memory.NumSegments())
+	}
+}
+
+func structIdsForTest(t *testing.T) IdsManager {
+	x, err := ParseHintReference(parser.Reference{Value: "[cast(fp, __main__.Uint256*)]"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := ParseHintReference(parser.Reference{Value: "cast([fp + 2], __main__.Pair*)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := NewIdsManager(map[string]HintReference{"x": x, "p": p}, parser.ApTrackingData{}, []string{"__main__"})
+	ids.Identifiers = map[string]Identifier{
+		"__main__.Uint256": {
+			Type: "struct",
+			Members: map[string]any{
+				"low":  map[string]any{"cairo_type": "felt", "offset": float64(0)},
+				"high": map[string]any{"cairo_type": "felt", "offset": float64(1)},
+			},
+		},
+		"__main__.Pair": {
+			Type: "struct",
+			Members: map[string]any{
+				"a":   map[string]any{"cairo_type": "__main__.Uint256", "offset": float64(0)},
+				"ptr": map[string]any{"cairo_type": "__main__.Uint256*", "offset": float64(2)},
+			},
+		},
+	}
+	return ids
+}
+
+func TestExternalHintSendsStructDefinitions(t *testing.T) {
+	var params map[string]any
+	processor := startFakeWorker(t, func(w *fakeWorker, p map[string]any) string {
+		params = p
+		return `{"jsonrpc": "2.0", "id": ID, "result": {}}`
+	})
+	defer processor.Close()
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Fp = NewRelocatable(1, 0)
+
+	err := executeHint(processor, "ids.x.low = 1", vm, structIdsForTest(t), types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	ids := params["ids"].(map[string]any)
+	if ids["x"].(map[string]any)["type"] != "__main__.Uint256" || ids["p"].(map[string]any)["type"] != "__main__.Pair*" {
+		t.Errorf("Wrong ids types: %v", ids)
+	}
+	expectedStructs := map[string]any{
+		"__main__.Uint256": map[string]any{
+			"low":  map[string]any{"offset": float64(0), "type": "felt"},
+			"high": map[string]any{"offset": float64(1), "type": "felt"},
+		},
+		"__main__.Pair": map[string]any{
+			"a":   map[string]any{"offset": float64(0), "type": "__main__.Uint256"},
+			"ptr": map[string]any{"offset": float64(2), "type": "__main__.Uint256*"},
+		},
+	}
+	if !reflect.DeepEqual(params["structs"], expectedStructs) {
+		t.Errorf("Wrong structs. Expected %v, got %v", expectedStructs, params["structs"])
+	}
+}
+
+func TestHintWorkerStructMembers(t *testing.T) {
+	_, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is not available")
+	}
+	processor, err := external_hints.NewExternalHintProcessor("python3", "../../../scripts/hint_worker.py")
+	if err != nil {
+		t.Fatalf("Can't start the worker: %s", err)
+	}
+	defer processor.Close()
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Fp = NewRelocatable(1, 0)
+	// x is a Uint256 at fp whose high member is unknown, and p points to a Pair whose ptr member points to x
+	vm.Segments.Memory.Insert(NewRelocatable(1, 0), NewMaybeRelocatableFelt(FeltFromUint64(1)))
+	vm.Segments.Memory.Insert(NewRelocatable(1, 2), NewMaybeRelocatableRelocatable(NewRelocatable(2, 0)))
+	vm.Segments.Memory.Insert(NewRelocatable(2, 0), NewMaybeRelocatableFelt(FeltFromUint64(3)))
+	vm.Segments.Memory.Insert(NewRelocatable(2, 1), NewMaybeRelocatableFelt(FeltFromUint64(4)))
+	vm.Segments.Memory.Insert(NewRelocatable(2, 2), NewMaybeRelocatableRelocatable(NewRelocatable(1, 0)))
+	ids := structIdsForTest(t)
+
+	code := "assert ids.p.ptr.address_ == ids.x.address_\nids.x.high = ids.x.low + ids.p.a.high + ids.p.ptr.low"
+	err = executeHint(processor, code, vm, ids, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	high, err := vm.Segments.Memory.GetFelt(NewRelocatable(1, 1))
+	if err != nil || high != FeltFromUint64(6) {
+		t.Errorf("Wrong x.high: %v, %v", high, err)
+	}
+
+	err = executeHint(processor, "ids.p.a = 1", vm, ids, types.NewExecutionScopes())
+	if err == nil || !strings.Contains(err.Error(), "which is a struct") {
+		t.Errorf("Expected the assignment of a struct to fail, got %v", err)
+	}
+}
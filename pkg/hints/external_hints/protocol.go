@@ -0,0 +1,198 @@
+package external_hints
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Messages of the JSON-RPC protocol spoken with hint workers, documented in docs/external_hints.md
+
+const jsonRpcVersion = "2.0"
+
+type rpcMessage struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      *uint64         `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSON-RPC error codes
+const (
+	errCodeMethodNotFound = -32601
+	errCodeInvalidParams  = -32602
+)
+
+type jsonRelocatable struct {
+	SegmentIndex int  `json:"segment_index"`
+	Offset       uint `json:"offset"`
+}
+
+type idsEntry struct {
+	Address *jsonRelocatable `json:"address"`
+	Value   any              `json:"value"`
+	Type    string           `json:"type"`
+}
+
+type executeHintParams struct {
+	Code        string              `json:"code"`
+	Ids         map[string]idsEntry `json:"ids"`
+	Constants   map[string]*big.Int `json:"constants"`
+	Ap          jsonRelocatable     `json:"ap"`
+	Fp          jsonRelocatable     `json:"fp"`
+	Pc          jsonRelocatable     `json:"pc"`
+	Prime       *big.Int            `json:"prime"`
+	NumSegments uint                `json:"num_segments"`
+	Scope       map[string]any      `json:"scope"`
+	// Definitions of the structs used by the ids, indexed by cairo type
+	Structs map[string]map[string]structMember `json:"structs"`
+}
+
+type structMember struct {
+	Offset uint   `json:"offset"`
+	Type   string `json:"type"`
+}
+
+type memoryWrite struct {
+	Address jsonRelocatable `json:"address"`
+	Value   json.RawMessage `json:"value"`
+}
+
+type executeHintResult struct {
+	NewSegments  uint                       `json:"new_segments"`
+	MemoryWrites []memoryWrite              `json:"memory_writes"`
+	ScopeUpdates map[string]json.RawMessage `json:"scope_updates"`
+	ExitScope    bool                       `json:"exit_scope"`
+	EnterScope   map[string]json.RawMessage `json:"enter_scope"`
+}
+
+type readMemoryParams struct {
+	Address jsonRelocatable `json:"address"`
+	Size    uint            `json:"size"`
+}
+
+type readMemoryResult struct {
+	Values []any `json:"values"`
+}
+
+func encodeRelocatable(rel Relocatable) jsonRelocatable {
+	return jsonRelocatable{SegmentIndex: rel.SegmentIndex, Offset: rel.Offset}
+}
+
+func (rel jsonRelocatable) relocatable() Relocatable {
+	return NewRelocatable(rel.SegmentIndex, rel.Offset)
+}
+
+func encodeMaybeRelocatable(value *MaybeRelocatable) any {
+	felt, ok := value.GetFelt()
+	if ok {
+		return felt.ToBigInt()
+	}
+	rel, _ := value.GetRelocatable()
+	return encodeRelocatable(rel)
+}
+
+// Decodes a memory value, reducing ints modulo the prime
+func decodeMaybeRelocatable(data json.RawMessage) (*MaybeRelocatable, error) {
+	decoded, err := decodeScopeVar(data)
+	if err != nil {
+		return nil, err
+	}
+	switch decoded := decoded.(type) {
+	case big.Int:
+		felt := lambdaworks.FeltFromBigInt(new(big.Int).Mod(&decoded, lambdaworks.Prime()))
+		return NewMaybeRelocatableFelt(felt), nil
+	case Relocatable:
+		return NewMaybeRelocatableRelocatable(decoded), nil
+	}
+	return nil, errors.Errorf("Invalid memory value %s", string(data))
+}
+
+// Encodes the scope variables that can be represented in JSON. Returns false for the others
+func encodeScopeVar(value any) (any, bool) {
+	switch value := value.(type) {
+	case big.Int:
+		return &value, true
+	case *big.Int:
+		return value, true
+	case lambdaworks.Felt:
+		return value.ToBigInt(), true
+	case int, int64, uint, uint64, bool, string, nil:
+		return value, true
+	case Relocatable:
+		return encodeRelocatable(value), true
+	case MaybeRelocatable:
+		return encodeMaybeRelocatable(&value), true
+	case *MaybeRelocatable:
+		return encodeMaybeRelocatable(value), true
+	case []any:
+		encoded := make([]any, 0, len(value))
+		for _, elem := range value {
+			encodedElem, ok := encodeScopeVar(elem)
+			if !ok {
+				return nil, false
+			}
+			encoded = append(encoded, encodedElem)
+		}
+		return encoded, true
+	}
+	return nil, false
+}
+
+// Decodes a scope variable sent by the worker. Ints are decoded as big.Int, the way go hints store them
+func decodeScopeVar(data json.RawMessage) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var raw any
+	err := decoder.Decode(&raw)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJsonValue(raw)
+}
+
+func decodeJsonValue(raw any) (any, error) {
+	switch raw := raw.(type) {
+	case json.Number:
+		n, ok := new(big.Int).SetString(raw.String(), 10)
+		if !ok {
+			return nil, errors.Errorf("Invalid integer %s", raw)
+		}
+		return *n, nil
+	case map[string]any:
+		segmentIndex, okSegment := raw["segment_index"].(json.Number)
+		offset, okOffset := raw["offset"].(json.Number)
+		if !okSegment || !okOffset || len(raw) != 2 {
+			return nil, errors.New("Objects must be relocatables with segment_index and offset fields")
+		}
+		segment, errSegment := segmentIndex.Int64()
+		off, errOffset := offset.Int64()
+		if errSegment != nil || errOffset != nil || off < 0 {
+			return nil, errors.Errorf("Invalid relocatable %d:%d", segment, off)
+		}
+		return NewRelocatable(int(segment), uint(off)), nil
+	case []any:
+		decoded := make([]any, 0, len(raw))
+		for _, elem := range raw {
+			decodedElem, err := decodeJsonValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			decoded = append(decoded, decodedElem)
+		}
+		return decoded, nil
+	}
+	// bool, string and nil
+	return raw, nil
+}
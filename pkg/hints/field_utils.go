@@ -6,6 +6,7 @@ import (
 
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
 
 	. "github.com/lambdaclass/cairo-vm.go/pkg/types"
@@ -53,3 +54,79 @@ func verifyZeroWithExternalConst(vm VirtualMachine, execScopes ExecutionScopes,
 	quotient := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromBigInt(q))
 	return idsData.Insert("q", quotient, &vm)
 }
+
+/*
+Implements hints:
+%{
+    from starkware.cairo.common.cairo_secp.secp_utils import SECP_P, pack
+
+    x = pack(ids.x, PRIME) % SECP_P
+%}
+%{
+    from starkware.cairo.common.cairo_secp.secp_utils import SECP_P, pack
+    x = pack(ids.x, PRIME) % SECP_P
+%}
+%{
+    from starkware.cairo.common.cairo_secp.secp_utils import pack
+    SECP_P=2**255-19
+
+    x = pack(ids.x, PRIME) % SECP_P
+%}
+*/
+
+func isZeroPack(ids IdsManager, vm *VirtualMachine, execScopes *ExecutionScopes, secpP big.Int) error {
+	xUnpacked, err := BigInt3FromVarName("x", ids, vm)
+	if err != nil {
+		return err
+	}
+	x := xUnpacked.Pack86()
+	execScopes.AssignOrUpdateVariable("x", *new(big.Int).Mod(&x, &secpP))
+	return nil
+}
+
+/*
+Implements hint:
+%{ memory[ap] = to_felt_or_relocatable(x == 0) %}
+*/
+
+func isZeroNondet(vm *VirtualMachine, execScopes *ExecutionScopes) error {
+	x, err := FetchScopeVar[big.Int]("x", execScopes)
+	if err != nil {
+		return err
+	}
+	if x.Sign() == 0 {
+		return vm.Segments.Memory.Insert(vm.RunContext.Ap, memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne()))
+	}
+	return vm.Segments.Memory.Insert(vm.RunContext.Ap, memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero()))
+}
+
+/*
+Implements hints:
+%{
+    from starkware.cairo.common.cairo_secp.secp_utils import SECP_P
+    from starkware.python.math_utils import div_mod
+
+    value = x_inv = div_mod(1, x, SECP_P)
+%}
+%{
+    SECP_P=2**255-19
+    from starkware.python.math_utils import div_mod
+
+    value = x_inv = div_mod(1, x, SECP_P)
+%}
+*/
+
+func isZeroAssignScopeVars(execScopes *ExecutionScopes, secpP big.Int) error {
+	x, err := FetchScopeVar[big.Int]("x", execScopes)
+	if err != nil {
+		return err
+	}
+	xInv, err := utils.DivMod(big.NewInt(1), &x, &secpP)
+	if err != nil {
+		return err
+	}
+	execScopes.AssignOrUpdateVariable("SECP_P", secpP)
+	execScopes.AssignOrUpdateVariable("value", *xInv)
+	execScopes.AssignOrUpdateVariable("x_inv", *xInv)
+	return nil
+}
@@ -33,12 +33,13 @@ x = pack(ids.pt.x, PRIME)
 y = pack(ids.pt.y, PRIME)
 
 value = new_x = (pow(slope, 2, SECP_P) - 2 * x) % SECP_P`
+const EC_DOUBLE_ASSIGN_NEW_Y = "value = new_y = (slope * (x - new_x) - y) % SECP_P"
 const COMPUTE_SLOPE_V2 = "from starkware.python.math_utils import line_slope\nfrom starkware.cairo.common.cairo_secp.secp_utils import pack\nSECP_P = 2**255-19\n# Compute the slope.\nx0 = pack(ids.point0.x, PRIME)\ny0 = pack(ids.point0.y, PRIME)\nx1 = pack(ids.point1.x, PRIME)\ny1 = pack(ids.point1.y, PRIME)\nvalue = slope = line_slope(point1=(x0, y0), point2=(x1, y1), p=SECP_P)"
 const COMPUTE_SLOPE_WHITELIST = "from starkware.cairo.common.cairo_secp.secp_utils import SECP_P, pack\nfrom starkware.python.math_utils import div_mod\n\n# Compute the slope.\nx0 = pack(ids.pt0.x, PRIME)\ny0 = pack(ids.pt0.y, PRIME)\nx1 = pack(ids.pt1.x, PRIME)\ny1 = pack(ids.pt1.y, PRIME)\nvalue = slope = div_mod(y0 - y1, x0 - x1, SECP_P)"
 const EC_DOUBLE_SLOPE_EXTERNAL_CONSTS = "from starkware.cairo.common.cairo_secp.secp_utils import pack\nfrom starkware.python.math_utils import ec_double_slope\n\n# Compute the slope.\nx = pack(ids.point.x, PRIME)\ny = pack(ids.point.y, PRIME)\nvalue = slope = ec_double_slope(point=(x, y), alpha=ALPHA, p=SECP_P)"
 const NONDET_BIGINT3_V1 = "from starkware.cairo.common.cairo_secp.secp_utils import split\n\nsegments.write_arg(ids.res.address_, split(value))"
 const COMPUTE_SLOPE_SECP256R1 = "from starkware.cairo.common.cairo_secp.secp_utils import pack\nfrom starkware.python.math_utils import line_slope\n\n# Compute the slope.\nx0 = pack(ids.point0.x, PRIME)\ny0 = pack(ids.point0.y, PRIME)\nx1 = pack(ids.point1.x, PRIME)\ny1 = pack(ids.point1.y, PRIME)\nvalue = slope = line_slope(point1=(x0, y0), point2=(x1, y1), p=SECP_P)"
-const FAST_EC_ADD_ASSIGN_NEW_X = `"from starkware.cairo.common.cairo_secp.secp_utils import SECP_P, pack
+const FAST_EC_ADD_ASSIGN_NEW_X = `from starkware.cairo.common.cairo_secp.secp_utils import SECP_P, pack
 
 slope = pack(ids.slope, PRIME)
 x0 = pack(ids.point0.x, PRIME)
@@ -49,13 +50,21 @@ value = new_x = (pow(slope, 2, SECP_P) - x0 - x1) % SECP_P`
 
 const FAST_EC_ADD_ASSIGN_NEW_X_V2 = "from starkware.cairo.common.cairo_secp.secp_utils import pack\nSECP_P = 2**255-19\n\nslope = pack(ids.slope, PRIME)\nx0 = pack(ids.point0.x, PRIME)\nx1 = pack(ids.point1.x, PRIME)\ny0 = pack(ids.point0.y, PRIME)\n\nvalue = new_x = (pow(slope, 2, SECP_P) - x0 - x1) % SECP_P"
 
-const FAST_EC_ADD_ASSIGN_NEW_X_V3 = `"from starkware.cairo.common.cairo_secp.secp_utils import SECP_P, pack
+const FAST_EC_ADD_ASSIGN_NEW_X_V3 = `from starkware.cairo.common.cairo_secp.secp_utils import SECP_P, pack
 
 slope = pack(ids.slope, PRIME)
 x0 = pack(ids.pt0.x, PRIME)
 x1 = pack(ids.pt1.x, PRIME)
 y0 = pack(ids.pt0.y, PRIME)
 
-value = new_x = (pow(slope, 2, SECP_P) - x0 - x1) % SECP_P"`
+value = new_x = (pow(slope, 2, SECP_P) - x0 - x1) % SECP_P`
 
 const FAST_EC_ADD_ASSIGN_NEW_Y = "value = new_y = (slope * (x0 - new_x) - y0) % SECP_P"
+
+const EC_MUL_INNER = "memory[ap] = (ids.scalar % PRIME) % 2"
+
+const RECOVER_Y = `from starkware.crypto.signature.signature import ALPHA, BETA, FIELD_PRIME
+from starkware.python.math_utils import recover_y
+ids.p.x = ids.x
+# This raises an exception if ` + "`x`" + ` is not on the curve.
+ids.p.y = recover_y(ids.x, ALPHA, BETA, FIELD_PRIME)`
@@ -0,0 +1,31 @@
+package hint_codes
+
+const SET_TREE_STRUCTURE = `from starkware.python.math_utils import div_ceil
+onchain_data_start = ids.da_start
+onchain_data_size = ids.output_ptr - onchain_data_start
+
+max_page_size = 3800
+n_pages = div_ceil(onchain_data_size, max_page_size)
+for i in range(n_pages):
+    start_offset = i * max_page_size
+    output_builtin.add_page(
+        page_id=1 + i,
+        page_start=onchain_data_start + start_offset,
+        page_size=min(onchain_data_size - start_offset, max_page_size),
+    )
+# Set the tree structure to a root with two children:
+# * A leaf which represents the main part
+# * An inner node for the onchain data part (which contains n_pages children).
+#
+# This is encoded using the following sequence:
+output_builtin.add_attribute('gps_fact_topology', [
+    # Push 1 + n_pages pages (all of the pages).
+    1 + n_pages,
+    # Create a parent node for the last n_pages.
+    n_pages,
+    # Don't push additional pages.
+    0,
+    # Take the first page (the main part) and the node that was created (onchain data)
+    # and use them to construct the root of the fact tree.
+    2,
+])`
@@ -0,0 +1,13 @@
+package hint_codes
+
+const PRINT_FELT = `print(ids.x)`
+
+const PRINT_ARR = `print(bytes.fromhex(f"{ids.name:062x}").decode().replace('\x00',''))
+arr = [memory[ids.arr + i] for i in range(ids.arr_len)]
+print(arr)`
+
+const PRINT_DICT = `print(bytes.fromhex(f"{ids.name:062x}").decode().replace('\x00',''))
+data = __dict_manager.get_dict(ids.dict_ptr)
+print(
+    {k: v if isinstance(v, int) else [memory[v + i] for i in range(ids.pointer_size)] for k, v in data.items()}
+)`
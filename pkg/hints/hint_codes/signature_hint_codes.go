@@ -31,3 +31,39 @@ if ids.v % 2 == y % 2:
     value = y
 else:
     value = (-y) % SECP_P`
+
+const EC_RECOVER_DIV_MOD_N_PACKED = `from starkware.cairo.common.cairo_secp.secp_utils import pack
+from starkware.python.math_utils import div_mod, safe_div
+
+N = pack(ids.n, PRIME)
+x = pack(ids.x, PRIME) % N
+s = pack(ids.s, PRIME) % N
+value = res = div_mod(x, s, N)`
+
+const EC_RECOVER_SUB_A_B = `from starkware.cairo.common.cairo_secp.secp_utils import pack
+from starkware.python.math_utils import div_mod, safe_div
+
+a = pack(ids.a, PRIME)
+b = pack(ids.b, PRIME)
+
+value = res = a - b`
+
+const EC_RECOVER_PRODUCT_MOD = `from starkware.cairo.common.cairo_secp.secp_utils import pack
+from starkware.python.math_utils import div_mod, safe_div
+
+a = pack(ids.a, PRIME)
+b = pack(ids.b, PRIME)
+product = a * b
+m = pack(ids.m, PRIME)
+
+value = res = product % m`
+
+const EC_RECOVER_PRODUCT_DIV_M = "value = k = product // m"
+
+const DIV_MOD_N_PACKED_DIVMOD_ED25519 = `from starkware.cairo.common.cairo_secp.secp_utils import pack
+from starkware.python.math_utils import div_mod, safe_div
+
+N = 2**252 + 27742317777372353535851937790883648493
+a = pack(ids.a, PRIME)
+b = pack(ids.b, PRIME)
+value = res = div_mod(a, b, N)`
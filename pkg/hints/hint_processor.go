@@ -1,6 +1,9 @@
 package hints
 
 import (
+	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
@@ -19,9 +22,42 @@ type HintData struct {
 }
 
 type CairoVmHintProcessor struct {
+	// Writer the debug print hints write to. Defaults to os.Stdout
+	DebugOutput io.Writer
+	// Skip unknown hints with a warning instead of failing the run.
+	// Only safe for hints that don't affect the execution, such as prints or python-side assertions
+	SkipUnknownHints bool
+	// Writer the warnings about skipped hints are written to. Defaults to os.Stderr
+	WarningOutput io.Writer
+	// Fail the bootloader inputs that make hints read or write files, see bootloader.SimpleBootloaderInput.CheckNoFilePaths
+	DisableFilePathInputs bool
+}
+
+func (p *CairoVmHintProcessor) debugOutput() io.Writer {
+	if p.DebugOutput == nil {
+		return os.Stdout
+	}
+	return p.DebugOutput
+}
+
+func (p *CairoVmHintProcessor) warningOutput() io.Writer {
+	if p.WarningOutput == nil {
+		return os.Stderr
+	}
+	return p.WarningOutput
+}
+
+// The compiled hint data doesn't depend on the processor's options, so it is shared by every CairoVmHintProcessor
+func (p *CairoVmHintProcessor) HintDataCacheKey() string {
+	return "cairo_vm"
 }
 
 func (p *CairoVmHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	return p.CompileHintWithIdentifiers(hintParams, referenceManager, nil)
+}
+
+// Compiles the hint, giving its IdsManager the program's identifiers so that hints can access ids members by name
+func (p *CairoVmHintProcessor) CompileHintWithIdentifiers(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager, identifiers map[string]vm.Identifier) (any, error) {
 	references := make(map[string]HintReference, 0)
 	for name, n := range hintParams.FlowTrackingData.ReferenceIds {
 		if int(n) >= len(referenceManager.References) {
@@ -29,9 +65,14 @@ func (p *CairoVmHintProcessor) CompileHint(hintParams *parser.HintParams, refere
 		}
 		split := strings.Split(name, ".")
 		name = split[len(split)-1]
-		references[name] = ParseHintReference(referenceManager.References[n])
+		reference, err := ParseHintReference(referenceManager.References[n])
+		if err != nil {
+			return nil, err
+		}
+		references[name] = reference
 	}
 	ids := NewIdsManager(references, hintParams.FlowTrackingData.APTracking, hintParams.AccessibleScopes)
+	ids.Identifiers = identifiers
 	return HintData{Ids: ids, Code: hintParams.Code}, nil
 }
 
@@ -93,8 +134,14 @@ func (p *CairoVmHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any,
 		return ecNegateImportSecpP(vm, *execScopes, data.Ids)
 	case EC_NEGATE_EMBEDDED_SECP:
 		return ecNegateEmbeddedSecpP(vm, *execScopes, data.Ids)
-	case EC_DOUBLE_ASSIGN_NEW_X_V1, EC_DOUBLE_ASSIGN_NEW_X_V2, EC_DOUBLE_ASSIGN_NEW_X_V3, EC_DOUBLE_ASSIGN_NEW_X_V4:
-		return ecDoubleAssignNewX(vm, *execScopes, data.Ids, SECP_P_V2())
+	case EC_DOUBLE_ASSIGN_NEW_X_V1:
+		return ecDoubleAssignNewX(vm, *execScopes, data.Ids, "point", SECP_P())
+	case EC_DOUBLE_ASSIGN_NEW_X_V2, EC_DOUBLE_ASSIGN_NEW_X_V3:
+		return ecDoubleAssignNewX(vm, *execScopes, data.Ids, "point", SECP_P_V2())
+	case EC_DOUBLE_ASSIGN_NEW_X_V4:
+		return ecDoubleAssignNewX(vm, *execScopes, data.Ids, "pt", SECP_P())
+	case EC_DOUBLE_ASSIGN_NEW_Y:
+		return ecDoubleAssignNewY(execScopes)
 	case POW:
 		return pow(data.Ids, vm)
 	case SQRT:
@@ -209,18 +256,68 @@ func (p *CairoVmHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any,
 		return uint256ExpandedUnsignedDivRem(data.Ids, vm)
 	case UINT256_MUL_DIV_MOD:
 		return uint256MulDivMod(data.Ids, vm)
+	case ADD_NO_UINT384_CHECK:
+		return addNoUint384Check(data.Ids, vm)
+	case UINT384_SIGNED_NN:
+		return uint384SignedNN(data.Ids, vm)
+	case UINT384_SPLIT_128:
+		return uint384Split128(data.Ids, vm)
+	case UINT384_UNSIGNED_DIV_REM:
+		return uint384UnsignedDivRem(data.Ids, vm)
+	case UINT384_UNSIGNED_DIV_REM_EXPANDED:
+		return uint384UnsignedDivRemExpanded(data.Ids, vm)
+	case UINT384_SQRT:
+		return uint384Sqrt(data.Ids, vm)
+	case UINT384_DIV:
+		return uint384Div(data.Ids, vm)
+	case UNSIGNED_DIV_REM_UINT768_BY_UINT384, UNSIGNED_DIV_REM_UINT768_BY_UINT384_STRIPPED:
+		return unsignedDivRemUint768ByUint384(data.Ids, vm, false)
+	case UNSIGNED_DIV_REM_UINT768_BY_UINT384_EXPAND:
+		return unsignedDivRemUint768ByUint384(data.Ids, vm, true)
+	case UINT512_UNSIGNED_DIV_REM:
+		return uint512UnsignedDivRem(data.Ids, vm)
+	case INV_MOD_P_UINT512:
+		return invModPUint512(data.Ids, vm)
+	case INV_MOD_P_UINT256:
+		return invModPUint256(data.Ids, vm)
+	case UINT256_SUB_MOD_P, UINT256_SUB_REDUCED_MOD_P:
+		return uint256SubModP(data.Ids, vm)
 	case DIV_MOD_N_PACKED_DIVMOD_V1:
 		return divModNPackedDivMod(data.Ids, vm, execScopes)
 	case DIV_MOD_N_PACKED_DIVMOD_EXTERNAL_N:
 		return divModNPackedDivModExternalN(data.Ids, vm, execScopes)
+	case DIV_MOD_N_PACKED_DIVMOD_ED25519:
+		return divModNPackedDivModED25519(data.Ids, vm, execScopes)
 	case XS_SAFE_DIV:
 		return divModNSafeDiv(data.Ids, execScopes, "x", "s", false)
 	case DIV_MOD_N_SAFE_DIV:
 		return divModNSafeDiv(data.Ids, execScopes, "a", "b", false)
 	case DIV_MOD_N_SAFE_DIV_PLUS_ONE:
 		return divModNSafeDiv(data.Ids, execScopes, "a", "b", true)
+	case EC_RECOVER_DIV_MOD_N_PACKED:
+		return ecRecoverDivModNPacked(data.Ids, vm, execScopes)
+	case EC_RECOVER_SUB_A_B:
+		return ecRecoverSubAB(data.Ids, vm, execScopes)
+	case EC_RECOVER_PRODUCT_MOD:
+		return ecRecoverProductMod(data.Ids, vm, execScopes)
+	case EC_RECOVER_PRODUCT_DIV_M:
+		return ecRecoverProductDivM(execScopes)
 	case GET_POINT_FROM_X:
 		return getPointFromX(data.Ids, vm, execScopes, constants)
+	case IS_ZERO_PACK_V1, IS_ZERO_PACK_V2:
+		return isZeroPack(data.Ids, vm, execScopes, SECP_P())
+	case IS_ZERO_PACK_ED25519:
+		return isZeroPack(data.Ids, vm, execScopes, SECP_P_V2())
+	case IS_ZERO_NONDET:
+		return isZeroNondet(vm, execScopes)
+	case IS_ZERO_ASSIGN_SCOPE_VARS:
+		return isZeroAssignScopeVars(execScopes, SECP_P())
+	case IS_ZERO_ASSIGN_SCOPE_VARS_ED25519:
+		return isZeroAssignScopeVars(execScopes, SECP_P_V2())
+	case EC_MUL_INNER:
+		return ecMulInner(data.Ids, vm)
+	case RECOVER_Y:
+		return recoverY(data.Ids, vm)
 	case VERIFY_ZERO_EXTERNAL_SECP:
 		return verifyZeroWithExternalConst(*vm, *execScopes, data.Ids)
 	case FAST_EC_ADD_ASSIGN_NEW_X:
@@ -251,11 +348,229 @@ func (p *CairoVmHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any,
 		return blake2sFinalize(data.Ids, vm)
 	case BLAKE2S_FINALIZE_V3:
 		return blake2sFinalizeV3(data.Ids, vm)
+	case PRINT_FELT:
+		return printFelt(data.Ids, vm, p.debugOutput())
+	case PRINT_ARR:
+		return printArray(data.Ids, vm, p.debugOutput())
+	case PRINT_DICT:
+		return printDict(data.Ids, vm, execScopes, p.debugOutput())
 	case SHA256_INPUT:
 		return sha256Input(data.Ids, vm)
+	case SHA256_MAIN_CONSTANT_INPUT_LENGTH:
+		return sha256MainConstantInputLength(data.Ids, vm, constants)
+	case SHA256_MAIN_ARBITRARY_INPUT_LENGTH:
+		return sha256MainArbitraryInputLength(data.Ids, vm, constants)
+	case SHA256_FINALIZE:
+		return sha256Finalize(data.Ids, vm, constants)
 	case EXAMPLE_BLAKE2S_COMPRESS:
 		return exampleBlake2sCompress(data.Ids, vm)
+	case SET_TREE_STRUCTURE:
+		return setTreeStructure(data.Ids, vm)
+	case SIMPLE_BOOTLOADER_LOAD_INPUT:
+		return simpleBootloaderLoadInput(execScopes, p.DisableFilePathInputs)
+	case SIMPLE_BOOTLOADER_PREPARE_TASK_RANGE_CHECKS:
+		return simpleBootloaderPrepareTaskRangeChecks(data.Ids, vm, execScopes)
+	case SIMPLE_BOOTLOADER_SET_TASKS_VARIABLE:
+		return simpleBootloaderSetTasksVariable(execScopes)
+	case SIMPLE_BOOTLOADER_SET_CURRENT_TASK:
+		return simpleBootloaderSetCurrentTask(data.Ids, vm, execScopes)
+	case SIMPLE_BOOTLOADER_CONFIGURE_FACT_TOPOLOGIES:
+		return simpleBootloaderConfigureFactTopologies(vm, execScopes)
+	case EXECUTE_TASK_ALLOCATE_PROGRAM_DATA_SEGMENT:
+		return executeTaskAllocateProgramDataSegment(data.Ids, vm, execScopes)
+	case EXECUTE_TASK_LOAD_PROGRAM:
+		return executeTaskLoadProgram(data.Ids, vm, execScopes)
+	case EXECUTE_TASK_VALIDATE_HASH:
+		return executeTaskValidateHash(data.Ids, vm, execScopes, false)
+	case EXECUTE_TASK_VALIDATE_HASH_POSEIDON:
+		return executeTaskValidateHash(data.Ids, vm, execScopes, true)
+	case EXECUTE_TASK_ASSERT_PROGRAM_ADDRESS:
+		return executeTaskAssertProgramAddress(data.Ids, vm, execScopes)
+	case EXECUTE_TASK_CALL_TASK:
+		return executeTaskCallTask(data.Ids, vm, execScopes, p)
+	case EXECUTE_TASK_EXIT_SCOPE:
+		return vm_exit_scope(execScopes)
+	case EXECUTE_TASK_WRITE_RETURN_BUILTINS:
+		return executeTaskWriteReturnBuiltins(data.Ids, vm, execScopes)
+	case EXECUTE_TASK_APPEND_FACT_TOPOLOGIES:
+		return executeTaskAppendFactTopologies(data.Ids, vm, execScopes)
+	case SELECT_BUILTINS_ENTER_SCOPE:
+		return selectBuiltinsEnterScope(data.Ids, vm, execScopes)
+	case INNER_SELECT_BUILTINS_SELECT_BUILTIN:
+		return innerSelectBuiltinsSelectBuiltin(data.Ids, vm, execScopes)
 	default:
+		if p.SkipUnknownHints {
+			_, err := fmt.Fprintf(p.warningOutput(), "Warning: skipping unknown hint at pc %s: %s\n", vm.RunContext.Pc.ToString(), data.Code)
+			return err
+		}
 		return errors.Errorf("Unknown Hint: %s", data.Code)
 	}
 }
+
+// Codes of the hints executed by CairoVmHintProcessor.ExecuteHint, which must list every code of its switch
+var supportedHintCodes = map[string]struct{}{
+	ADD_SEGMENT:                            {},
+	ASSERT_NN:                              {},
+	VERIFY_ECDSA_SIGNATURE:                 {},
+	IS_POSITIVE:                            {},
+	ASSERT_NOT_ZERO:                        {},
+	IS_QUAD_RESIDUE:                        {},
+	DEFAULT_DICT_NEW:                       {},
+	DICT_READ:                              {},
+	DICT_WRITE:                             {},
+	DICT_UPDATE:                            {},
+	SQUASH_DICT:                            {},
+	SQUASH_DICT_INNER_SKIP_LOOP:            {},
+	SQUASH_DICT_INNER_FIRST_ITERATION:      {},
+	SQUASH_DICT_INNER_CHECK_ACCESS_INDEX:   {},
+	SQUASH_DICT_INNER_CONTINUE_LOOP:        {},
+	SQUASH_DICT_INNER_ASSERT_LEN_KEYS:      {},
+	SQUASH_DICT_INNER_LEN_ASSERT:           {},
+	SQUASH_DICT_INNER_USED_ACCESSES_ASSERT: {},
+	SQUASH_DICT_INNER_NEXT_KEY:             {},
+	DICT_SQUASH_COPY_DICT:                  {},
+	DICT_SQUASH_UPDATE_PTR:                 {},
+	DICT_NEW:                               {},
+	VM_EXIT_SCOPE:                          {},
+	ASSERT_NOT_EQUAL:                       {},
+	EC_NEGATE:                              {},
+	EC_NEGATE_EMBEDDED_SECP:                {},
+	EC_DOUBLE_ASSIGN_NEW_X_V1:              {},
+	EC_DOUBLE_ASSIGN_NEW_X_V2:              {}, EC_DOUBLE_ASSIGN_NEW_X_V3: {},
+	EC_DOUBLE_ASSIGN_NEW_X_V4:                {},
+	EC_DOUBLE_ASSIGN_NEW_Y:                   {},
+	POW:                                      {},
+	SQRT:                                     {},
+	MEMCPY_ENTER_SCOPE:                       {},
+	MEMSET_ENTER_SCOPE:                       {},
+	MEMCPY_CONTINUE_COPYING:                  {},
+	MEMSET_CONTINUE_LOOP:                     {},
+	VM_ENTER_SCOPE:                           {},
+	USORT_ENTER_SCOPE:                        {},
+	USORT_BODY:                               {},
+	USORT_VERIFY:                             {},
+	USORT_VERIFY_MULTIPLICITY_ASSERT:         {},
+	USORT_VERIFY_MULTIPLICITY_BODY:           {},
+	SET_ADD:                                  {},
+	FIND_ELEMENT:                             {},
+	SEARCH_SORTED_LOWER:                      {},
+	COMPUTE_SLOPE_V1:                         {},
+	COMPUTE_SLOPE_V2:                         {},
+	COMPUTE_SLOPE_WHITELIST:                  {},
+	COMPUTE_SLOPE_SECP256R1:                  {},
+	EC_DOUBLE_SLOPE_V1:                       {},
+	UNSAFE_KECCAK:                            {},
+	UNSAFE_KECCAK_FINALIZE:                   {},
+	COMPARE_BYTES_IN_WORD_NONDET:             {},
+	COMPARE_KECCAK_FULL_RATE_IN_BYTES_NONDET: {},
+	BLOCK_PERMUTATION:                        {},
+	CAIRO_KECCAK_FINALIZE_V1:                 {},
+	CAIRO_KECCAK_FINALIZE_V2:                 {},
+	KECCAK_WRITE_ARGS:                        {},
+	UNSIGNED_DIV_REM:                         {},
+	SIGNED_DIV_REM:                           {},
+	ASSERT_LE_FELT:                           {},
+	ASSERT_LE_FELT_EXCLUDED_0:                {},
+	ASSERT_LE_FELT_EXCLUDED_1:                {},
+	ASSERT_LE_FELT_EXCLUDED_2:                {},
+	ASSERT_LT_FELT:                           {},
+	IS_NN:                                    {},
+	IS_NN_OUT_OF_RANGE:                       {},
+	IS_LE_FELT:                               {},
+	ASSERT_250_BITS:                          {},
+	SPLIT_FELT:                               {},
+	IMPORT_SECP256R1_ALPHA:                   {},
+	IMPORT_SECP256R1_N:                       {},
+	IMPORT_SECP256R1_P:                       {},
+	EC_DOUBLE_SLOPE_EXTERNAL_CONSTS:          {},
+	NONDET_BIGINT3_V1:                        {},
+	SPLIT_INT:                                {},
+	SPLIT_INT_ASSERT_RANGE:                   {},
+	UINT256_ADD:                              {},
+	UINT256_ADD_LOW:                          {},
+	UINT256_SUB:                              {},
+	SPLIT_64:                                 {},
+	UINT256_SQRT:                             {},
+	UINT256_SQRT_FELT:                        {},
+	UINT256_SIGNED_NN:                        {},
+	UINT256_UNSIGNED_DIV_REM:                 {},
+	UINT256_EXPANDED_UNSIGNED_DIV_REM:        {},
+	UINT256_MUL_DIV_MOD:                      {},
+	ADD_NO_UINT384_CHECK:                     {},
+	UINT384_SIGNED_NN:                        {},
+	UINT384_SPLIT_128:                        {},
+	UINT384_UNSIGNED_DIV_REM:                 {},
+	UINT384_UNSIGNED_DIV_REM_EXPANDED:        {},
+	UINT384_SQRT:                             {},
+	UINT384_DIV:                              {},
+	UNSIGNED_DIV_REM_UINT768_BY_UINT384:      {}, UNSIGNED_DIV_REM_UINT768_BY_UINT384_STRIPPED: {},
+	UNSIGNED_DIV_REM_UINT768_BY_UINT384_EXPAND: {},
+	UINT512_UNSIGNED_DIV_REM:                   {},
+	INV_MOD_P_UINT512:                          {},
+	INV_MOD_P_UINT256:                          {},
+	UINT256_SUB_MOD_P:                          {}, UINT256_SUB_REDUCED_MOD_P: {},
+	DIV_MOD_N_PACKED_DIVMOD_V1:         {},
+	DIV_MOD_N_PACKED_DIVMOD_EXTERNAL_N: {},
+	DIV_MOD_N_PACKED_DIVMOD_ED25519:    {},
+	XS_SAFE_DIV:                        {},
+	DIV_MOD_N_SAFE_DIV:                 {},
+	DIV_MOD_N_SAFE_DIV_PLUS_ONE:        {},
+	EC_RECOVER_DIV_MOD_N_PACKED:        {},
+	EC_RECOVER_SUB_A_B:                 {},
+	EC_RECOVER_PRODUCT_MOD:             {},
+	EC_RECOVER_PRODUCT_DIV_M:           {},
+	GET_POINT_FROM_X:                   {},
+	IS_ZERO_PACK_V1:                    {}, IS_ZERO_PACK_V2: {},
+	IS_ZERO_PACK_ED25519:              {},
+	IS_ZERO_NONDET:                    {},
+	IS_ZERO_ASSIGN_SCOPE_VARS:         {},
+	IS_ZERO_ASSIGN_SCOPE_VARS_ED25519: {},
+	EC_MUL_INNER:                      {},
+	RECOVER_Y:                         {},
+	VERIFY_ZERO_EXTERNAL_SECP:         {},
+	FAST_EC_ADD_ASSIGN_NEW_X:          {},
+	FAST_EC_ADD_ASSIGN_NEW_X_V2:       {},
+	FAST_EC_ADD_ASSIGN_NEW_X_V3:       {},
+	FAST_EC_ADD_ASSIGN_NEW_Y:          {},
+	BLAKE2S_COMPUTE:                   {},
+	BLAKE2S_ADD_UINT256:               {},
+	REDUCE_V1:                         {},
+	REDUCE_V2:                         {},
+	REDUCE_ED25519:                    {},
+	VERIFY_ZERO_V1:                    {}, VERIFY_ZERO_V2: {},
+	VERIFY_ZERO_V3:             {},
+	BLAKE2S_ADD_UINT256_BIGEND: {},
+	BLAKE2S_FINALIZE:           {}, BLAKE2S_FINALIZE_V2: {},
+	BLAKE2S_FINALIZE_V3:                {},
+	PRINT_FELT:                         {},
+	PRINT_ARR:                          {},
+	PRINT_DICT:                         {},
+	SHA256_INPUT:                       {},
+	SHA256_MAIN_CONSTANT_INPUT_LENGTH:  {},
+	SHA256_MAIN_ARBITRARY_INPUT_LENGTH: {},
+	SHA256_FINALIZE:                    {},
+	EXAMPLE_BLAKE2S_COMPRESS:           {},
+	SET_TREE_STRUCTURE:                 {},
+	SIMPLE_BOOTLOADER_LOAD_INPUT:       {},
+	SIMPLE_BOOTLOADER_PREPARE_TASK_RANGE_CHECKS: {},
+	SIMPLE_BOOTLOADER_SET_TASKS_VARIABLE:        {},
+	SIMPLE_BOOTLOADER_SET_CURRENT_TASK:          {},
+	SIMPLE_BOOTLOADER_CONFIGURE_FACT_TOPOLOGIES: {},
+	EXECUTE_TASK_ALLOCATE_PROGRAM_DATA_SEGMENT:  {},
+	EXECUTE_TASK_LOAD_PROGRAM:                   {},
+	EXECUTE_TASK_VALIDATE_HASH:                  {},
+	EXECUTE_TASK_VALIDATE_HASH_POSEIDON:         {},
+	EXECUTE_TASK_ASSERT_PROGRAM_ADDRESS:         {},
+	EXECUTE_TASK_CALL_TASK:                      {},
+	EXECUTE_TASK_EXIT_SCOPE:                     {},
+	EXECUTE_TASK_WRITE_RETURN_BUILTINS:          {},
+	EXECUTE_TASK_APPEND_FACT_TOPOLOGIES:         {},
+	SELECT_BUILTINS_ENTER_SCOPE:                 {},
+	INNER_SELECT_BUILTINS_SELECT_BUILTIN:        {},
+}
+
+// Returns true if the processor implements the hint with the given code
+func (p *CairoVmHintProcessor) IsHintSupported(code string) bool {
+	_, ok := supportedHintCodes[code]
+	return ok
+}
@@ -1,10 +1,16 @@
 package hints_test
 
 import (
+	"bytes"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
 	"reflect"
+	"sort"
 	"testing"
 
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
@@ -106,3 +112,119 @@ func TestExecuteHintUnknownHint(t *testing.T) {
 		t.Errorf("Should have failed")
 	}
 }
+
+func TestExecuteHintSkipUnknownHint(t *testing.T) {
+	var warnings bytes.Buffer
+	hintProcessor := &CairoVmHintProcessor{SkipUnknownHints: true, WarningOutput: &warnings}
+	hintData := any(HintData{Code: "print(Hello World)"})
+	vm := vm.NewVirtualMachine()
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("Unknown hint should have been skipped, got error: %s", err)
+	}
+	expected := "Warning: skipping unknown hint at pc {0:0}: print(Hello World)\n"
+	if warnings.String() != expected {
+		t.Errorf("Wrong warning. Expected %q, got %q", expected, warnings.String())
+	}
+}
+
+func TestIsHintSupported(t *testing.T) {
+	hintProcessor := &CairoVmHintProcessor{}
+	if !hintProcessor.IsHintSupported(ADD_SEGMENT) {
+		t.Errorf("ADD_SEGMENT should be supported")
+	}
+	if hintProcessor.IsHintSupported("print(Hello World)") {
+		t.Errorf("Unknown hint shouldn't be supported")
+	}
+}
+
+// IsHintSupported reads a list kept apart from the switch of ExecuteHint, so both must name the same hint codes
+func TestSupportedHintCodesMatchExecuteHint(t *testing.T) {
+	file, err := goparser.ParseFile(token.NewFileSet(), "hint_processor.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse hint_processor.go: %s", err)
+	}
+	var switchCodes, supportedCodes []string
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Name.Name != "ExecuteHint" {
+				continue
+			}
+			ast.Inspect(decl.Body, func(node ast.Node) bool {
+				if clause, ok := node.(*ast.CaseClause); ok {
+					for _, code := range clause.List {
+						switchCodes = append(switchCodes, code.(*ast.Ident).Name)
+					}
+				}
+				return true
+			})
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				value, ok := spec.(*ast.ValueSpec)
+				if !ok || value.Names[0].Name != "supportedHintCodes" {
+					continue
+				}
+				for _, element := range value.Values[0].(*ast.CompositeLit).Elts {
+					supportedCodes = append(supportedCodes, element.(*ast.KeyValueExpr).Key.(*ast.Ident).Name)
+				}
+			}
+		}
+	}
+	sort.Strings(switchCodes)
+	sort.Strings(supportedCodes)
+	if len(switchCodes) == 0 || !reflect.DeepEqual(switchCodes, supportedCodes) {
+		t.Errorf("The hint codes of ExecuteHint and supportedHintCodes differ.\nExecuteHint: %v\nsupportedHintCodes: %v", switchCodes, supportedCodes)
+	}
+}
+
+func TestUnknownHints(t *testing.T) {
+	hintProcessor := &CairoVmHintProcessor{}
+	location := parser.Location{InputFile: map[string]string{"filename": "test.cairo"}, StartLine: 3, StartCol: 5}
+	program := vm.Program{
+		Hints: map[uint][]parser.HintParams{
+			0: {{Code: ADD_SEGMENT}, {Code: "unknown_b"}},
+			4: {{Code: "unknown_c"}},
+			2: {{Code: "unknown_a"}},
+		},
+		InstructionLocations: map[uint]parser.InstructionLocation{
+			0: {Hints: []parser.HintLocation{{}, {Location: location}}},
+			4: {Inst: location},
+		},
+	}
+	unknownHints := hintProcessor.UnknownHints(&program)
+	expected := []UnknownHint{
+		{Pc: 0, Code: "unknown_b", Location: &location},
+		{Pc: 2, Code: "unknown_a"},
+		{Pc: 4, Code: "unknown_c", Location: &location},
+	}
+	if !reflect.DeepEqual(unknownHints, expected) {
+		t.Errorf("Wrong unknown hints. Expected %+v, got %+v", expected, unknownHints)
+	}
+
+	var report bytes.Buffer
+	err := WriteHintsReport(unknownHints[:2], &report)
+	if err != nil {
+		t.Fatalf("WriteHintsReport failed with error: %s", err)
+	}
+	expectedReport := "Found 2 unsupported hints:\n\npc 0 (test.cairo:3:5):\nunknown_b\n\npc 2 (unknown location):\nunknown_a\n"
+	if report.String() != expectedReport {
+		t.Errorf("Wrong report. Expected %q, got %q", expectedReport, report.String())
+	}
+}
+
+func TestCompileProgramHintPassesIdentifiers(t *testing.T) {
+	hintProcessor := &CairoVmHintProcessor{}
+	program := &vm.Program{
+		Identifiers: map[string]vm.Identifier{
+			"__main__.Point": {Type: "struct", Members: map[string]any{"x": map[string]any{"cairo_type": "felt", "offset": float64(0)}}},
+		},
+	}
+	data, err := vm.CompileProgramHint(hintProcessor, &parser.HintParams{Code: "ids.point.x = 1"}, program)
+	if err != nil {
+		t.Fatalf("Error in test: %s", err)
+	}
+	if !reflect.DeepEqual(data.(HintData).Ids.Identifiers, program.Identifiers) {
+		t.Errorf("The program's identifiers were not passed to the IdsManager")
+	}
+}
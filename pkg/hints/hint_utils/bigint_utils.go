@@ -49,7 +49,7 @@ func limbsPack(limbs []Felt) big.Int {
 	sum := big.NewInt(0)
 	for i := 0; i < len(limbs); i++ {
 		felt := limbs[i]
-		shifed := new(big.Int).Lsh(felt.ToSigned(), uint(i*128))
+		shifed := new(big.Int).Lsh(felt.ToBigInt(), uint(i*128))
 		sum.Add(sum, shifed)
 	}
 	return *sum
@@ -72,8 +72,10 @@ func limbsInsertFromVarName(limbs []Felt, name string, ids IdsManager, vm *Virtu
 func splitIntoLimbs(num *big.Int, numLimbs int) []Felt {
 	limbs := make([]Felt, 0, numLimbs)
 	bitmask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	num = new(big.Int).Set(num)
 	for i := 0; i < numLimbs; i++ {
-		limbs[i] = FeltFromBigInt(new(big.Int).Lsh(new(big.Int).And(num, bitmask), 128))
+		limbs = append(limbs, FeltFromBigInt(new(big.Int).And(num, bitmask)))
+		num.Rsh(num, 128)
 	}
 	return limbs
 }
@@ -107,3 +109,79 @@ type Uint384 = BigInt3
 func Uint384FromVarName(name string, ids IdsManager, vm *VirtualMachine) (Uint384, error) {
 	return BigInt3FromVarName(name, ids, vm)
 }
+
+// Uint384 limbs are 128 bits long (the secp hints use BigInt3's 86 bit packing instead)
+func (b *BigInt3) Pack() big.Int {
+	return limbsPack(b.Limbs)
+}
+
+func (b *BigInt3) InsertFromVarName(name string, ids IdsManager, vm *VirtualMachine) error {
+	return limbsInsertFromVarName(b.Limbs, name, ids, vm)
+}
+
+// Splits a number into 128 bit limbs, higher bits are discarded
+func ToUint384(num *big.Int) Uint384 {
+	return Uint384{Limbs: splitIntoLimbs(num, 3)}
+}
+
+// Uint384_expand
+
+// The members of a Uint384_expand are (B0, b01, b12, b23, b34, b45, b5), the number itself is stored in b01, b23 and b45
+type Uint384Expand struct {
+	Limbs []Felt
+}
+
+func Uint384ExpandFromVarName(name string, ids IdsManager, vm *VirtualMachine) (Uint384Expand, error) {
+	limbs, err := limbsFromVarName(7, name, ids, vm)
+	return Uint384Expand{Limbs: limbs}, err
+}
+
+func (u *Uint384Expand) Pack() big.Int {
+	return limbsPack([]Felt{u.Limbs[1], u.Limbs[3], u.Limbs[5]})
+}
+
+// Uint768
+
+type Uint768 struct {
+	Limbs []Felt
+}
+
+func Uint768FromVarName(name string, ids IdsManager, vm *VirtualMachine) (Uint768, error) {
+	limbs, err := limbsFromVarName(6, name, ids, vm)
+	return Uint768{Limbs: limbs}, err
+}
+
+func (u *Uint768) Pack() big.Int {
+	return limbsPack(u.Limbs)
+}
+
+func (u *Uint768) InsertFromVarName(name string, ids IdsManager, vm *VirtualMachine) error {
+	return limbsInsertFromVarName(u.Limbs, name, ids, vm)
+}
+
+func ToUint768(num *big.Int) Uint768 {
+	return Uint768{Limbs: splitIntoLimbs(num, 6)}
+}
+
+// Uint512
+
+type Uint512 struct {
+	Limbs []Felt
+}
+
+func Uint512FromVarName(name string, ids IdsManager, vm *VirtualMachine) (Uint512, error) {
+	limbs, err := limbsFromVarName(4, name, ids, vm)
+	return Uint512{Limbs: limbs}, err
+}
+
+func (u *Uint512) Pack() big.Int {
+	return limbsPack(u.Limbs)
+}
+
+func (u *Uint512) InsertFromVarName(name string, ids IdsManager, vm *VirtualMachine) error {
+	return limbsInsertFromVarName(u.Limbs, name, ids, vm)
+}
+
+func ToUint512(num *big.Int) Uint512 {
+	return Uint512{Limbs: splitIntoLimbs(num, 4)}
+}
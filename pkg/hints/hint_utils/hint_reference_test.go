@@ -1,12 +1,16 @@
 package hint_utils_test
 
 import (
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
 )
 
 // ParseHintReference tests
@@ -18,8 +22,9 @@ func TestParseHintReferenceImmediate(t *testing.T) {
 		ValueType: "felt",
 	}
 
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -30,8 +35,9 @@ func TestParseHintReferenceImmediateCustomType(t *testing.T) {
 		ValueType: "cat",
 	}
 
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -42,8 +48,9 @@ func TestParseHintReferenceDereferenceSimpleApBasedPositive(t *testing.T) {
 		ValueType:   "felt",
 		Dereference: true,
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 func TestParseHintReferenceSimpleApBasedPositive(t *testing.T) {
@@ -52,8 +59,9 @@ func TestParseHintReferenceSimpleApBasedPositive(t *testing.T) {
 		Offset1:   OffsetValue{ValueType: Reference, Value: 1},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -63,8 +71,9 @@ func TestParseHintReferenceSimpleApBasedTypePointer(t *testing.T) {
 		Offset1:   OffsetValue{ValueType: Reference, Value: 1},
 		ValueType: "felt*",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -74,8 +83,9 @@ func TestParseHintReferenceSimpleFpBasedPositive(t *testing.T) {
 		Offset1:   OffsetValue{ValueType: Reference, Value: 1, Register: vm.FP},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -85,8 +95,9 @@ func TestParseHintReferenceSimpleApBasedNegative(t *testing.T) {
 		Offset1:   OffsetValue{ValueType: Reference, Value: -1},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -97,8 +108,9 @@ func TestParseHintReferenceTwoOffsetsPositives(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Value, Value: 2},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -109,8 +121,9 @@ func TestParseHintReferenceTwoOffsetsNegatives(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Value, Value: -2},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -121,8 +134,9 @@ func TestParseHintReferenceTwoOffsetsPosNeg(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Value, Value: -2},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -133,8 +147,9 @@ func TestParseHintReferenceTwoOffsetsNegPos(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Value, Value: 2},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -144,8 +159,9 @@ func TestParseHintReferenceDerefOneOffset(t *testing.T) {
 		Offset1:   OffsetValue{ValueType: Reference, Value: 1, Dereference: true},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -155,8 +171,9 @@ func TestParseHintReferenceDerefOneOffsetNegative(t *testing.T) {
 		Offset1:   OffsetValue{ValueType: Reference, Value: -1, Dereference: true},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -167,8 +184,9 @@ func TestParseHintReferenceDerefTwoOffsetsNegatives(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Value, Value: -2},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -179,8 +197,9 @@ func TestParseHintReferenceDerefTwoOffsetsPositives(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Value, Value: 2},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -191,8 +210,9 @@ func TestParseHintReferenceDerefTwoOffsetsNegPos(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Value, Value: 2},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -203,8 +223,9 @@ func TestParseHintReferenceDerefTwoOffsetsPosNeg(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Value, Value: -2},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -215,8 +236,9 @@ func TestParseHintTwoDereferencesPositives(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Reference, Value: 2, Dereference: true},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -227,8 +249,9 @@ func TestParseHintTwoDereferencesPositivesDiffRegister(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Reference, Value: 2, Dereference: true, Register: vm.FP},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -239,8 +262,9 @@ func TestParseHintTwoDereferencesPosNeg(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Reference, Value: -2, Dereference: true},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -250,8 +274,9 @@ func TestParseHintReferenceOneOffsetOmitted(t *testing.T) {
 		Offset1:   OffsetValue{ValueType: Reference, Value: 0},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -261,8 +286,9 @@ func TestParseHintReferenceDerefOneOffsetOmitted(t *testing.T) {
 		Offset1:   OffsetValue{ValueType: Reference, Value: 0, Dereference: true},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -273,8 +299,9 @@ func TestParseHintReferenceDerefTwoOffsetOmitted(t *testing.T) {
 		Offset2:   OffsetValue{Value: 1},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -285,8 +312,9 @@ func TestParseHintReferenceDoubleDerefFirstOffOmitted(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Reference, Value: 1, Dereference: true},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -297,8 +325,9 @@ func TestParseHintReferenceDoubleDerefSecondOffOmitted(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Reference, Value: 0, Dereference: true},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -309,8 +338,9 @@ func TestParseHintReferenceDoubleDerefBothOffOmitted(t *testing.T) {
 		Offset2:   OffsetValue{ValueType: Reference, Value: 0, Dereference: true},
 		ValueType: "felt",
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -322,8 +352,9 @@ func TestParseHintDereferenceReferenceDoubleDerefBothOffOmitted(t *testing.T) {
 		ValueType:   "felt",
 		Dereference: true,
 	}
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
 
@@ -336,7 +367,171 @@ func TestParseHintDereferenceValueMinusValPlusVal(t *testing.T) {
 		Dereference: true,
 	}
 
-	if ParseHintReference(reference) != expected {
-		t.Errorf("Wrong parsed reference, %+v", ParseHintReference(reference))
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
 	}
 }
+
+func TestParseHintReferenceNestedDereference(t *testing.T) {
+	reference := parser.Reference{Value: "[cast([fp + 3] + 2, felt*)]"}
+	expected := HintReference{
+		Offset1:     OffsetValue{ValueType: Reference, Value: 3, Register: vm.FP, Dereference: true},
+		Offset2:     OffsetValue{ValueType: Value, Value: 2},
+		ValueType:   "felt*",
+		Dereference: true,
+	}
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
+	}
+}
+
+func TestParseHintReferenceNegativeImmediate(t *testing.T) {
+	reference := parser.Reference{Value: "cast(-1, felt)"}
+	expected := HintReference{
+		Offset1:   OffsetValue{ValueType: Immediate, Immediate: lambdaworks.FeltZero().Sub(lambdaworks.FeltOne())},
+		ValueType: "felt",
+	}
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
+	}
+}
+
+func TestParseHintReferenceTupleType(t *testing.T) {
+	reference := parser.Reference{Value: "[cast(fp + (-4), (x: felt, y: (felt, felt))*)]"}
+	expected := HintReference{
+		Offset1:     OffsetValue{ValueType: Reference, Value: -4, Register: vm.FP},
+		ValueType:   "(x: felt, y: (felt, felt))*",
+		Dereference: true,
+	}
+	parsed, err := ParseHintReference(reference)
+	if err != nil || parsed != expected {
+		t.Errorf("Wrong parsed reference, %+v, %v", parsed, err)
+	}
+}
+
+func TestParseHintReferenceInvalid(t *testing.T) {
+	values := []string{
+		"",
+		"cast(ap + 1, felt",
+		"cast(bp + 1, felt)",
+		"[cast(ap + 1, felt)",
+		"cast(ap + 1, )",
+		"cast(ap + 1 felt)",
+		"cast(ap + 1, felt)]",
+		"cast(ap + * 1, felt)",
+	}
+	for _, value := range values {
+		_, err := ParseHintReference(parser.Reference{Value: value})
+		if err == nil {
+			t.Errorf("Expected an error when parsing %q", value)
+		}
+	}
+}
+
+func TestParseHintReferenceDoubleDereference(t *testing.T) {
+	reference, err := ParseHintReference(parser.Reference{Value: "[cast([[fp] + 1], felt)]"})
+	if err != nil {
+		t.Fatalf("Failed to parse reference: %s", err)
+	}
+	ids := NewIdsManager(map[string]HintReference{"a": reference}, parser.ApTrackingData{}, nil)
+	vm := vm.NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Fp = memory.NewRelocatable(1, 0)
+	vm.Segments.Memory.Insert(memory.NewRelocatable(1, 0), memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 3)))
+	vm.Segments.Memory.Insert(memory.NewRelocatable(1, 4), memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 6)))
+	vm.Segments.Memory.Insert(memory.NewRelocatable(1, 6), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(17)))
+
+	addr, err := ids.GetAddr("a", vm)
+	if err != nil || addr != memory.NewRelocatable(1, 6) {
+		t.Errorf("Wrong address: %v, %v", addr, err)
+	}
+	value, err := ids.GetFelt("a", vm)
+	if err != nil || value != lambdaworks.FeltFromUint64(17) {
+		t.Errorf("Wrong value: %v, %v", value, err)
+	}
+}
+
+func TestParseHintReferenceProduct(t *testing.T) {
+	reference, err := ParseHintReference(parser.Reference{Value: "cast([fp] * [fp + 1], felt)"})
+	if err != nil {
+		t.Fatalf("Failed to parse reference: %s", err)
+	}
+	ids := NewIdsManager(map[string]HintReference{"a": reference}, parser.ApTrackingData{}, nil)
+	vm := vm.NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Fp = memory.NewRelocatable(1, 0)
+	vm.Segments.Memory.Insert(memory.NewRelocatable(1, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3)))
+	vm.Segments.Memory.Insert(memory.NewRelocatable(1, 1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+
+	value, err := ids.GetFelt("a", vm)
+	if err != nil || value != lambdaworks.FeltFromUint64(15) {
+		t.Errorf("Wrong value: %v, %v", value, err)
+	}
+	_, err = ids.GetAddr("a", vm)
+	if err == nil {
+		t.Errorf("A product shouldn't have an address")
+	}
+}
+
+// Seeds the fuzzer with the references of the compiled programs, if they were compiled
+func referenceSeeds(f *testing.F) {
+	seeds := []string{
+		"cast(17, felt)",
+		"[cast(ap + (-1), felt*)]",
+		"cast([ap + 1] + [fp + (-2)], felt)",
+		"[cast(ap - 0 + (-1), felt*)]",
+		"[cast([fp + 3] + 2, felt*)]",
+		"[cast([[fp + 3] + 2], felt)]",
+		"cast([fp + (-3)] * [fp + (-4)], felt)",
+		"[cast(fp + (-4), (x: felt, y: felt)*)]",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	paths, _ := filepath.Glob("../../../cairo_programs/*.json")
+	proofPaths, _ := filepath.Glob("../../../cairo_programs/proof_programs/*.json")
+	for _, path := range append(paths, proofPaths...) {
+		program, err := parser.Parse(path)
+		if err != nil {
+			continue
+		}
+		for _, reference := range program.ReferenceManager.References {
+			f.Add(reference.Value)
+		}
+	}
+}
+
+func FuzzParseHintReference(f *testing.F) {
+	referenceSeeds(f)
+	f.Fuzz(func(t *testing.T, value string) {
+		reference, err := ParseHintReference(parser.Reference{Value: value})
+		if err != nil {
+			if !strings.HasPrefix(err.Error(), "Invalid reference") {
+				t.Errorf("Unexpected error: %s", err)
+			}
+			return
+		}
+		again, err := ParseHintReference(parser.Reference{Value: value})
+		if err != nil || !reflect.DeepEqual(reference, again) {
+			t.Errorf("Parsing %q again gave a different result", value)
+		}
+		// Evaluating the reference must not panic, whether it succeeds or not
+		ids := NewIdsManager(map[string]HintReference{"a": reference}, parser.ApTrackingData{}, nil)
+		vm := vm.NewVirtualMachine()
+		vm.Segments.AddSegment()
+		vm.Segments.AddSegment()
+		vm.RunContext.Ap = memory.NewRelocatable(1, 10)
+		vm.RunContext.Fp = memory.NewRelocatable(1, 5)
+		for i := uint(0); i < 20; i++ {
+			vm.Segments.Memory.Insert(memory.NewRelocatable(1, i), memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, i/2)))
+		}
+		ids.Get("a", vm)
+		ids.GetAddr("a", vm)
+		ids.GetStructFieldFelt("a", 1, vm)
+	})
+}
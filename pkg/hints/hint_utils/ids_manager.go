@@ -1,6 +1,9 @@
 package hint_utils
 
 import (
+	"math/big"
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
@@ -15,6 +18,8 @@ type IdsManager struct {
 	References       map[string]HintReference
 	HintApTracking   parser.ApTrackingData
 	AccessibleScopes []string
+	// Identifiers of the program, used to resolve struct members by name. Nil if the hint processor didn't provide them
+	Identifiers map[string]Identifier
 }
 
 func ErrIdsManager(err error) error {
@@ -53,6 +58,77 @@ func (ids *IdsManager) GetConst(name string, constants *map[string]lambdaworks.F
 	return lambdaworks.FeltZero(), errors.Errorf("Missing constant %s", name)
 }
 
+// Returns the size of a struct used by the hint (<name>.SIZE)
+// Searches inner modules first for name-matching structs, following aliases and type definitions
+func (ids *IdsManager) GetStructSize(name string) (uint, error) {
+	identifier, err := ids.accessibleStruct(name)
+	if err != nil {
+		return 0, err
+	}
+	if identifier.Size < 0 {
+		return 0, ErrIdsManager(errors.Errorf("Invalid size of struct %s", name))
+	}
+	return uint(identifier.Size), nil
+}
+
+// Returns the offset of a member of a struct defined by the program, looking up the struct name as GetStructSize does
+func (ids *IdsManager) GetStructMemberOffset(name string, member string) (uint, error) {
+	identifier, err := ids.accessibleStruct(name)
+	if err != nil {
+		return 0, err
+	}
+	offset, _, err := structIdentifierMember(identifier, name, member)
+	if err != nil {
+		return 0, ErrIdsManager(err)
+	}
+	return offset, nil
+}
+
+// Member of a struct defined by the program
+type StructMember struct {
+	Offset    uint
+	CairoType string
+}
+
+// Returns the members of a struct given its cairo type, such as the type of a reference or of another struct's
+// member, following aliases and type definitions
+func (ids *IdsManager) GetStructMembers(cairoType string) (map[string]StructMember, error) {
+	identifier, err := ids.structIdentifier(cairoType)
+	if err != nil {
+		return nil, ErrIdsManager(err)
+	}
+	members := make(map[string]StructMember, len(identifier.Members))
+	for name := range identifier.Members {
+		offset, memberType, err := structIdentifierMember(identifier, cairoType, name)
+		if err != nil {
+			return nil, ErrIdsManager(err)
+		}
+		members[name] = StructMember{Offset: offset, CairoType: memberType}
+	}
+	return members, nil
+}
+
+// Returns the identifier of a struct accessible from the hint's scopes, given its name
+func (ids *IdsManager) accessibleStruct(name string) (Identifier, error) {
+	if ids.Identifiers == nil {
+		return Identifier{}, ErrIdsManager(errors.New("The struct definitions of the program are not available"))
+	}
+	// Accessible scopes are listed from outer to inner
+	for i := len(ids.AccessibleScopes) - 1; i >= 0; i-- {
+		fullName := ids.AccessibleScopes[i] + "." + name
+		_, ok := ids.Identifiers[fullName]
+		if !ok {
+			continue
+		}
+		identifier, err := ids.structIdentifier(fullName)
+		if err != nil {
+			return Identifier{}, ErrIdsManager(err)
+		}
+		return identifier, nil
+	}
+	return Identifier{}, ErrIdsManager(errors.Errorf("Missing struct %s", name))
+}
+
 // Inserts value into memory given its identifier name
 func (ids *IdsManager) Insert(name string, value *MaybeRelocatable, vm *VirtualMachine) error {
 
@@ -233,6 +309,190 @@ func (ids *IdsManager) InsertStructField(name string, field_off uint, value *May
 	return vm.Segments.Memory.Insert(addr.AddUint(field_off), value)
 }
 
+/*
+	 Returns the address of a member of an ids struct given its path, resolving the member offsets from
+	 the struct definitions of the program. Nested structs and pointers to structs are followed:
+
+		struct Point {
+			x: BigInt3,
+			y: BigInt3,
+		}
+
+		addr, err := ids.GetMemberAddr("point.y.d0", vm)
+
+	 works whether point is a Point or a Point*
+*/
+func (ids *IdsManager) GetMemberAddr(path string, vm *VirtualMachine) (Relocatable, error) {
+	if !strings.Contains(path, ".") {
+		return ids.GetAddr(path, vm)
+	}
+	addr, _, err := ids.ResolveMember(path, vm)
+	return addr, err
+}
+
+// Returns the address and cairo type of a member of an ids struct given its path, see GetMemberAddr
+func (ids *IdsManager) ResolveMember(path string, vm *VirtualMachine) (Relocatable, string, error) {
+	name, members, hasMembers := strings.Cut(path, ".")
+	reference, ok := ids.References[name]
+	if !ok {
+		return Relocatable{}, "", ErrUnknownIdentifier(name)
+	}
+	cairoType := reference.ValueType
+	var addr Relocatable
+	if reference.Dereference {
+		// [cast(addr, T*)] is a T located at addr
+		cairoType = strings.TrimSuffix(cairoType, "*")
+		addr, ok = getAddressFromReference(&reference, ids.HintApTracking, vm)
+	} else if hasMembers {
+		// cast(addr, T*) is a pointer to a T, so its members are located at addr
+		if !strings.HasSuffix(cairoType, "*") {
+			return Relocatable{}, "", ErrIdsManager(errors.Errorf("Identifier %s of type %s has no members", name, cairoType))
+		}
+		cairoType = strings.TrimSuffix(cairoType, "*")
+		var value *MaybeRelocatable
+		value, ok = getValueFromReference(&reference, ids.HintApTracking, vm)
+		if ok {
+			addr, ok = value.GetRelocatable()
+		}
+	} else {
+		addr, ok = getAddressFromReference(&reference, ids.HintApTracking, vm)
+	}
+	if !ok {
+		return Relocatable{}, "", ErrUnknownIdentifier(name)
+	}
+	if !hasMembers {
+		return addr, cairoType, nil
+	}
+	for _, member := range strings.Split(members, ".") {
+		// Members of pointers to structs are accessed through the pointer
+		if strings.HasSuffix(cairoType, "*") {
+			ptr, err := vm.Segments.Memory.GetRelocatable(addr)
+			if err != nil {
+				return Relocatable{}, "", ErrIdsManager(errors.Wrapf(err, "Failed to follow the pointer to %s", path))
+			}
+			addr = ptr
+			cairoType = strings.TrimSuffix(cairoType, "*")
+		}
+		offset, memberType, err := ids.structMember(cairoType, member)
+		if err != nil {
+			return Relocatable{}, "", ErrIdsManager(err)
+		}
+		addr = addr.AddUint(offset)
+		cairoType = memberType
+	}
+	return addr, cairoType, nil
+}
+
+// Returns the value of a member of an ids struct given its path, see GetMemberAddr
+func (ids *IdsManager) GetMember(path string, vm *VirtualMachine) (*MaybeRelocatable, error) {
+	if !strings.Contains(path, ".") {
+		return ids.Get(path, vm)
+	}
+	addr, err := ids.GetMemberAddr(path, vm)
+	if err != nil {
+		return nil, err
+	}
+	value, err := vm.Segments.Memory.Get(addr)
+	if err != nil {
+		return nil, ErrUnknownIdentifier(path)
+	}
+	return value, nil
+}
+
+// Returns the value of a member of an ids struct given its path as a Felt, see GetMemberAddr
+func (ids *IdsManager) GetMemberFelt(path string, vm *VirtualMachine) (lambdaworks.Felt, error) {
+	value, err := ids.GetMember(path, vm)
+	if err != nil {
+		return lambdaworks.Felt{}, err
+	}
+	felt, ok := value.GetFelt()
+	if !ok {
+		return lambdaworks.Felt{}, ErrIdentifierNotFelt(path)
+	}
+	return felt, nil
+}
+
+// Returns the value of a member of an ids struct given its path as a Relocatable, see GetMemberAddr
+func (ids *IdsManager) GetMemberRelocatable(path string, vm *VirtualMachine) (Relocatable, error) {
+	value, err := ids.GetMember(path, vm)
+	if err != nil {
+		return Relocatable{}, err
+	}
+	rel, ok := value.GetRelocatable()
+	if !ok {
+		return Relocatable{}, ErrIdsManager(errors.Errorf("Identifier %s is not a Relocatable", path))
+	}
+	return rel, nil
+}
+
+// Inserts value into a member of an ids struct given its path, see GetMemberAddr
+func (ids *IdsManager) InsertMember(path string, value *MaybeRelocatable, vm *VirtualMachine) error {
+	addr, err := ids.GetMemberAddr(path, vm)
+	if err != nil {
+		return err
+	}
+	return vm.Segments.Memory.Insert(addr, value)
+}
+
+// Returns the offset and type of a struct's member, as listed in the struct's identifier
+func (ids *IdsManager) structMember(structType string, member string) (uint, string, error) {
+	identifier, err := ids.structIdentifier(structType)
+	if err != nil {
+		return 0, "", err
+	}
+	return structIdentifierMember(identifier, structType, member)
+}
+
+// Returns the offset and type of a member of the struct with the given identifier
+func structIdentifierMember(identifier Identifier, structType string, member string) (uint, string, error) {
+	definition, ok := identifier.Members[member].(map[string]any)
+	if !ok {
+		return 0, "", errors.Errorf("Struct %s has no member %s", structType, member)
+	}
+	memberType, okType := definition["cairo_type"].(string)
+	var offset int
+	var okOffset bool
+	switch value := definition["offset"].(type) {
+	case float64:
+		// Members decoded from the compiled program's json
+		offset, okOffset = int(value), value == float64(int(value))
+	case int:
+		offset, okOffset = value, true
+	}
+	if !okType || !okOffset || offset < 0 {
+		return 0, "", errors.Errorf("Invalid definition of member %s of struct %s", member, structType)
+	}
+	return uint(offset), memberType, nil
+}
+
+// Maximum amount of aliases and type definitions followed when resolving a type
+const maxTypeResolutionDepth = 100
+
+// Returns the identifier of a struct given its type, following aliases and type definitions
+func (ids *IdsManager) structIdentifier(cairoType string) (Identifier, error) {
+	if ids.Identifiers == nil {
+		return Identifier{}, errors.New("The struct definitions of the program are not available")
+	}
+	name := cairoType
+	for i := 0; i < maxTypeResolutionDepth; i++ {
+		identifier, ok := ids.Identifiers[name]
+		if !ok {
+			break
+		}
+		switch identifier.Type {
+		case "struct":
+			return identifier, nil
+		case "alias":
+			name = identifier.Destination
+		case "type_definition":
+			name = identifier.CairoType
+		default:
+			return Identifier{}, errors.Errorf("Type %s is not a struct", cairoType)
+		}
+	}
+	return Identifier{}, errors.Errorf("Type %s is not a struct", cairoType)
+}
+
 // Inserts Uint256 value into an ids field (given the identifier is a Uint256)
 func (ids *IdsManager) InsertUint256(name string, val Uint256, vm *VirtualMachine) error {
 	baseAddr, err := ids.GetAddr(name, vm)
@@ -260,6 +520,10 @@ func getValueFromReference(reference *HintReference, apTracking parser.ApTrackin
 	if reference.Offset1.ValueType == Immediate {
 		return NewMaybeRelocatableFelt(reference.Offset1.Immediate), true
 	}
+	// References that aren't dereferenced can evaluate to felts, such as [fp + (-3)] * [fp + (-4)]
+	if reference.expression != nil && !reference.Dereference {
+		return evalReferenceExpr(reference.expression, reference.ApTrackingData, apTracking, vm)
+	}
 	addr, ok := getAddressFromReference(reference, apTracking, vm)
 	if ok {
 		if reference.Dereference {
@@ -289,6 +553,13 @@ func getStructFieldFromReference(reference *HintReference, field_off uint, apTra
 
 // Returns the addr indicated by the reference
 func getAddressFromReference(reference *HintReference, apTracking parser.ApTrackingData, vm *VirtualMachine) (Relocatable, bool) {
+	if reference.expression != nil {
+		value, ok := evalReferenceExpr(reference.expression, reference.ApTrackingData, apTracking, vm)
+		if ok {
+			return value.GetRelocatable()
+		}
+		return Relocatable{}, false
+	}
 	if reference.Offset1.ValueType != Reference {
 		return Relocatable{}, false
 	}
@@ -343,6 +614,61 @@ func getOffsetValueReference(offsetValue OffsetValue, refApTracking parser.ApTra
 	return nil
 }
 
+// Returns the value of a reference expression, or false if it can't be computed
+func evalReferenceExpr(expr referenceExpr, refApTracking parser.ApTrackingData, hintApTracking parser.ApTrackingData, vm *VirtualMachine) (*MaybeRelocatable, bool) {
+	switch e := expr.(type) {
+	case *registerExpr:
+		value := getOffsetValueReference(OffsetValue{ValueType: Reference, Register: e.register}, refApTracking, hintApTracking, vm)
+		return value, value != nil
+	case *intExpr:
+		return NewMaybeRelocatableFelt(lambdaworks.FeltFromBigInt(new(big.Int).Mod(e.value, lambdaworks.Prime()))), true
+	case *derefExpr:
+		inner, ok := evalReferenceExpr(e.inner, refApTracking, hintApTracking, vm)
+		if !ok {
+			return nil, false
+		}
+		addr, ok := inner.GetRelocatable()
+		if !ok {
+			return nil, false
+		}
+		value, err := vm.Segments.Memory.Get(addr)
+		return value, err == nil
+	case *negExpr:
+		operand, ok := evalReferenceExpr(e.operand, refApTracking, hintApTracking, vm)
+		if !ok {
+			return nil, false
+		}
+		felt, ok := operand.GetFelt()
+		return NewMaybeRelocatableFelt(lambdaworks.FeltZero().Sub(felt)), ok
+	case *binOpExpr:
+		left, ok := evalReferenceExpr(e.left, refApTracking, hintApTracking, vm)
+		if !ok {
+			return nil, false
+		}
+		right, ok := evalReferenceExpr(e.right, refApTracking, hintApTracking, vm)
+		if !ok {
+			return nil, false
+		}
+		var result MaybeRelocatable
+		var err error
+		switch e.op {
+		case '+':
+			result, err = left.Add(*right)
+		case '-':
+			result, err = left.Sub(*right)
+		default:
+			leftFelt, leftOk := left.GetFelt()
+			rightFelt, rightOk := right.GetFelt()
+			if !leftOk || !rightOk {
+				return nil, false
+			}
+			result = *NewMaybeRelocatableFelt(leftFelt.Mul(rightFelt))
+		}
+		return &result, err == nil
+	}
+	return nil, false
+}
+
 func applyApTrackingCorrection(addr Relocatable, refApTracking parser.ApTrackingData, hintApTracking parser.ApTrackingData) (Relocatable, bool) {
 	// Reference & Hint ApTracking must belong to the same group
 	if refApTracking.Group == hintApTracking.Group {
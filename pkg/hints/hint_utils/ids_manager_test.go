@@ -1,6 +1,7 @@
 package hint_utils_test
 
 import (
+	"reflect"
 	"testing"
 
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
@@ -337,3 +338,132 @@ func TestIdsManagerGetConstNoMConst(t *testing.T) {
 		t.Errorf("IdsManager.GetConst should have failed")
 	}
 }
+
+// Struct definitions as decoded from a compiled program
+func pointIdentifiers() map[string]vm.Identifier {
+	return map[string]vm.Identifier{
+		"__main__.BigInt3": {
+			Type: "struct",
+			Members: map[string]any{
+				"d0": map[string]any{"cairo_type": "felt", "offset": float64(0)},
+				"d1": map[string]any{"cairo_type": "felt", "offset": float64(1)},
+				"d2": map[string]any{"cairo_type": "felt", "offset": float64(2)},
+			},
+		},
+		"__main__.Point": {
+			Type: "struct",
+			Members: map[string]any{
+				"x":    map[string]any{"cairo_type": "__main__.BigInt3", "offset": float64(0)},
+				"y":    map[string]any{"cairo_type": "__main__.BigInt3", "offset": float64(3)},
+				"next": map[string]any{"cairo_type": "__main__.Point*", "offset": float64(6)},
+			},
+		},
+		"__main__.P": {Type: "alias", Destination: "__main__.Point"},
+	}
+}
+
+func TestIdsManagerGetStructMemberOffset(t *testing.T) {
+	ids := IdsManager{AccessibleScopes: []string{"__main__", "__main__.main"}, Identifiers: pointIdentifiers()}
+	offset, err := ids.GetStructMemberOffset("P", "next")
+	if err != nil || offset != 6 {
+		t.Errorf("Wrong offset of P.next: %d, %v", offset, err)
+	}
+	_, err = ids.GetStructMemberOffset("Point", "z")
+	if err == nil {
+		t.Errorf("GetStructMemberOffset should fail for a missing member")
+	}
+	_, err = ids.GetStructMemberOffset("Line", "x")
+	if err == nil {
+		t.Errorf("GetStructMemberOffset should fail for a missing struct")
+	}
+}
+
+func TestIdsManagerGetMemberNestedStruct(t *testing.T) {
+	reference, _ := ParseHintReference(parser.Reference{Value: "[cast(fp, __main__.Point*)]"})
+	ids := IdsManager{References: map[string]HintReference{"point": reference}, Identifiers: pointIdentifiers()}
+	vm := vm.NewVirtualMachine()
+	vm.Segments.AddSegment()
+	for i := uint(0); i < 6; i++ {
+		vm.Segments.Memory.Insert(vm.RunContext.Fp.AddUint(i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i*10))))
+	}
+	d1, err := ids.GetMemberFelt("point.y.d1", vm)
+	if err != nil || d1 != lambdaworks.FeltFromUint64(40) {
+		t.Errorf("Wrong point.y.d1: %v, %v", d1, err)
+	}
+	addr, err := ids.GetMemberAddr("point.y", vm)
+	if err != nil || addr != vm.RunContext.Fp.AddUint(3) {
+		t.Errorf("Wrong address of point.y: %v, %v", addr, err)
+	}
+}
+
+func TestIdsManagerGetMemberThroughPointers(t *testing.T) {
+	// ptr is a Point* whose next member points to another Point
+	reference, _ := ParseHintReference(parser.Reference{Value: "cast([fp], __main__.P*)"})
+	ids := IdsManager{References: map[string]HintReference{"ptr": reference}, Identifiers: pointIdentifiers()}
+	vm := vm.NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	first := memory.NewRelocatable(1, 0)
+	second := memory.NewRelocatable(1, 10)
+	vm.Segments.Memory.Insert(vm.RunContext.Fp, memory.NewMaybeRelocatableRelocatable(first))
+	vm.Segments.Memory.Insert(first.AddUint(6), memory.NewMaybeRelocatableRelocatable(second))
+	vm.Segments.Memory.Insert(second.AddUint(2), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)))
+
+	d2, err := ids.GetMemberFelt("ptr.next.x.d2", vm)
+	if err != nil || d2 != lambdaworks.FeltFromUint64(7) {
+		t.Errorf("Wrong ptr.next.x.d2: %v, %v", d2, err)
+	}
+	next, err := ids.GetMemberRelocatable("ptr.next", vm)
+	if err != nil || next != second {
+		t.Errorf("Wrong ptr.next: %v, %v", next, err)
+	}
+	err = ids.InsertMember("ptr.y.d0", memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)), vm)
+	if err != nil {
+		t.Errorf("InsertMember failed: %s", err)
+	}
+	d0, err := vm.Segments.Memory.GetFelt(first.AddUint(3))
+	if err != nil || d0 != lambdaworks.FeltFromUint64(5) {
+		t.Errorf("Wrong value inserted into ptr.y.d0: %v, %v", d0, err)
+	}
+}
+
+func TestIdsManagerGetMemberErrors(t *testing.T) {
+	reference, _ := ParseHintReference(parser.Reference{Value: "[cast(fp, __main__.Point*)]"})
+	ids := IdsManager{References: map[string]HintReference{"point": reference}, Identifiers: pointIdentifiers()}
+	vm := vm.NewVirtualMachine()
+	vm.Segments.AddSegment()
+
+	_, err := ids.GetMemberAddr("point.z", vm)
+	if err == nil || err.Error() != "IdsManager error: Struct __main__.Point has no member z" {
+		t.Errorf("Wrong error for an unknown member: %v", err)
+	}
+	_, err = ids.GetMemberAddr("point.x.d0.low", vm)
+	if err == nil || err.Error() != "IdsManager error: Type felt is not a struct" {
+		t.Errorf("Wrong error for a member of a felt: %v", err)
+	}
+	ids.Identifiers = nil
+	_, err = ids.GetMemberAddr("point.x", vm)
+	if err == nil || err.Error() != "IdsManager error: The struct definitions of the program are not available" {
+		t.Errorf("Wrong error without identifiers: %v", err)
+	}
+}
+
+func TestIdsManagerGetStructMembers(t *testing.T) {
+	ids := IdsManager{Identifiers: pointIdentifiers()}
+	members, err := ids.GetStructMembers("__main__.P")
+	if err != nil {
+		t.Fatalf("GetStructMembers failed with error: %s", err)
+	}
+	expected := map[string]StructMember{
+		"x":    {Offset: 0, CairoType: "__main__.BigInt3"},
+		"y":    {Offset: 3, CairoType: "__main__.BigInt3"},
+		"next": {Offset: 6, CairoType: "__main__.Point*"},
+	}
+	if !reflect.DeepEqual(members, expected) {
+		t.Errorf("Wrong members. Expected %v, got %v", expected, members)
+	}
+	_, err = ids.GetStructMembers("__main__.Point*")
+	if err == nil {
+		t.Errorf("GetStructMembers should fail for a pointer type")
+	}
+}
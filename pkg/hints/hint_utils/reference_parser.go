@@ -0,0 +1,381 @@
+package hint_utils
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
+)
+
+// Expressions found in the value of a reference, such as [cast([fp + (-3)] + 2, felt*)]
+
+type referenceExpr interface{}
+
+type registerExpr struct {
+	register vm.Register
+}
+
+type intExpr struct {
+	value *big.Int
+}
+
+type derefExpr struct {
+	inner referenceExpr
+}
+
+type castExpr struct {
+	inner     referenceExpr
+	valueType string
+}
+
+type negExpr struct {
+	operand referenceExpr
+}
+
+type binOpExpr struct {
+	op    byte
+	left  referenceExpr
+	right referenceExpr
+}
+
+// Maximum nesting of brackets and parentheses in a reference
+const maxReferenceDepth = 100
+
+func ErrInvalidReference(value string, err error) error {
+	return errors.Wrapf(err, "Invalid reference %q", value)
+}
+
+// Recursive descent parser for reference values:
+//
+//	expr  := term (('+' | '-') term)*
+//	term  := unary ('*' unary)*
+//	unary := '-' unary | atom
+//	atom  := int | 'ap' | 'fp' | '[' expr ']' | '(' expr ')' | 'cast' '(' expr ',' type ')'
+type referenceParser struct {
+	input string
+	pos   int
+	depth int
+}
+
+func parseReferenceExpr(input string) (referenceExpr, error) {
+	p := referenceParser{input: input}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return nil, p.unexpected()
+	}
+	return expr, nil
+}
+
+func (p *referenceParser) skipSpaces() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// Skips spaces and returns the next character, or 0 at the end of the input
+func (p *referenceParser) peek() byte {
+	p.skipSpaces()
+	if p.pos == len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *referenceParser) unexpected() error {
+	if p.pos >= len(p.input) {
+		return errors.New("unexpected end of reference")
+	}
+	return errors.Errorf("unexpected %q at position %d", p.input[p.pos], p.pos)
+}
+
+func (p *referenceParser) expect(c byte) error {
+	if p.peek() != c {
+		return p.unexpected()
+	}
+	p.pos++
+	return nil
+}
+
+func (p *referenceParser) enter() error {
+	p.depth++
+	if p.depth > maxReferenceDepth {
+		return errors.New("reference is nested too deeply")
+	}
+	return nil
+}
+
+func (p *referenceParser) parseExpr() (referenceExpr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &binOpExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *referenceParser) parseTerm() (referenceExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == '*' {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binOpExpr{op: '*', left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *referenceParser) parseUnary() (referenceExpr, error) {
+	if p.peek() != '-' {
+		return p.parseAtom()
+	}
+	p.pos++
+	err := p.enter()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	// Negative immediates are folded, so that (-1) is an immediate like 1
+	if immediate, ok := operand.(*intExpr); ok {
+		return &intExpr{value: new(big.Int).Neg(immediate.value)}, nil
+	}
+	return &negExpr{operand: operand}, nil
+}
+
+func (p *referenceParser) parseAtom() (referenceExpr, error) {
+	c := p.peek()
+	switch {
+	case c == '[' || c == '(':
+		p.pos++
+		err := p.enter()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { p.depth-- }()
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if c == '(' {
+			return inner, p.expect(')')
+		}
+		return &derefExpr{inner: inner}, p.expect(']')
+	case '0' <= c && c <= '9':
+		start := p.pos
+		for p.pos < len(p.input) && '0' <= p.input[p.pos] && p.input[p.pos] <= '9' {
+			p.pos++
+		}
+		value, _ := new(big.Int).SetString(p.input[start:p.pos], 10)
+		return &intExpr{value: value}, nil
+	case isIdentifierChar(c):
+		start := p.pos
+		for p.pos < len(p.input) && isIdentifierChar(p.input[p.pos]) {
+			p.pos++
+		}
+		switch name := p.input[start:p.pos]; name {
+		case "ap":
+			return &registerExpr{register: vm.AP}, nil
+		case "fp":
+			return &registerExpr{register: vm.FP}, nil
+		case "cast":
+			return p.parseCast()
+		default:
+			return nil, errors.Errorf("unknown identifier %s at position %d", name, start)
+		}
+	}
+	return nil, p.unexpected()
+}
+
+// Parses the arguments of cast(expr, type). The type is kept as written, as it can be a pointer (felt*)
+// or a tuple ((x: felt, y: felt))
+func (p *referenceParser) parseCast() (referenceExpr, error) {
+	err := p.expect('(')
+	if err != nil {
+		return nil, err
+	}
+	err = p.enter()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+	inner, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	err = p.expect(',')
+	if err != nil {
+		return nil, err
+	}
+	start := p.pos
+	depth := 0
+	for ; p.pos < len(p.input); p.pos++ {
+		if p.input[p.pos] == '(' {
+			depth++
+		} else if p.input[p.pos] == ')' {
+			if depth == 0 {
+				break
+			}
+			depth--
+		}
+	}
+	valueType := strings.TrimSpace(p.input[start:p.pos])
+	if valueType == "" {
+		return nil, errors.Errorf("missing cast type at position %d", start)
+	}
+	err = p.expect(')')
+	if err != nil {
+		return nil, err
+	}
+	return &castExpr{inner: inner, valueType: valueType}, nil
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// Returns the type of the outermost cast of the reference, looking through its dereferences
+func referenceValueType(expr referenceExpr) string {
+	for {
+		switch e := expr.(type) {
+		case *derefExpr:
+			expr = e.inner
+		case *castExpr:
+			return e.valueType
+		default:
+			return ""
+		}
+	}
+}
+
+// Removes the casts of the expression, which don't affect its value
+func stripCasts(expr referenceExpr) referenceExpr {
+	switch e := expr.(type) {
+	case *castExpr:
+		return stripCasts(e.inner)
+	case *derefExpr:
+		return &derefExpr{inner: stripCasts(e.inner)}
+	case *negExpr:
+		return &negExpr{operand: stripCasts(e.operand)}
+	case *binOpExpr:
+		return &binOpExpr{op: e.op, left: stripCasts(e.left), right: stripCasts(e.right)}
+	}
+	return expr
+}
+
+type sumTerm struct {
+	expr     referenceExpr
+	negative bool
+}
+
+// Flattens a sum such as a - b + c into its terms
+func sumTerms(expr referenceExpr, negative bool, terms []sumTerm) []sumTerm {
+	if e, ok := expr.(*binOpExpr); ok && e.op != '*' {
+		terms = sumTerms(e.left, negative, terms)
+		return sumTerms(e.right, negative != (e.op == '-'), terms)
+	}
+	return append(terms, sumTerm{expr: expr, negative: negative})
+}
+
+// Returns the value of an immediate term as an int
+func termInt(term sumTerm) (int, bool) {
+	immediate, ok := term.expr.(*intExpr)
+	// Bigger offsets are left to the general evaluation, so that adding them up can't overflow
+	if !ok || immediate.value.CmpAbs(big.NewInt(math.MaxInt32)) > 0 {
+		return 0, false
+	}
+	value := int(immediate.value.Int64())
+	if term.negative {
+		value = -value
+	}
+	return value, true
+}
+
+// Returns the register and offset of reg, reg + off or reg - off
+func registerOffset(expr referenceExpr) (vm.Register, int, bool) {
+	terms := sumTerms(expr, false, nil)
+	register, ok := terms[0].expr.(*registerExpr)
+	if !ok || terms[0].negative || len(terms) > 2 {
+		return 0, 0, false
+	}
+	if len(terms) == 1 {
+		return register.register, 0, true
+	}
+	offset, ok := termInt(terms[1])
+	return register.register, offset, ok
+}
+
+// Returns the offset value of a [reg + off] term
+func derefOffset(term sumTerm) (OffsetValue, bool) {
+	deref, ok := term.expr.(*derefExpr)
+	if !ok || term.negative {
+		return OffsetValue{}, false
+	}
+	register, offset, ok := registerOffset(deref.inner)
+	return OffsetValue{ValueType: Reference, Register: register, Value: offset, Dereference: true}, ok
+}
+
+// Fits a cast-free expression into the two offsets of a HintReference:
+// off1 (+ off2), where off1 is reg + off or [reg + off], and off2 is an int or [reg + off]
+func lowerReferenceExpr(expr referenceExpr, reference *HintReference) bool {
+	terms := sumTerms(expr, false, nil)
+	if register, ok := terms[0].expr.(*registerExpr); ok && !terms[0].negative {
+		reference.Offset1 = OffsetValue{ValueType: Reference, Register: register.register}
+		// The first immediate is the register's offset, and the following ones are added to off2
+		for i, term := range terms[1:] {
+			value, ok := termInt(term)
+			if !ok {
+				return false
+			}
+			if i == 0 {
+				reference.Offset1.Value = value
+			} else {
+				reference.Offset2.Value += value
+			}
+		}
+		return true
+	}
+	offset1, ok := derefOffset(terms[0])
+	if !ok {
+		return false
+	}
+	reference.Offset1 = offset1
+	if len(terms) == 2 {
+		offset2, ok := derefOffset(terms[1])
+		if ok {
+			reference.Offset2 = offset2
+			return true
+		}
+	}
+	for _, term := range terms[1:] {
+		value, ok := termInt(term)
+		if !ok {
+			return false
+		}
+		reference.Offset2.Value += value
+	}
+	return true
+}
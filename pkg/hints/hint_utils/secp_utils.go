@@ -54,3 +54,9 @@ func Bigint3Split(integer big.Int) ([]big.Int, error) {
 
 	return canonicalRepr, nil
 }
+
+// Order of the ed25519 (curve25519) base point's group
+func ED25519_N() big.Int {
+	n, _ := new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+	return *n
+}
@@ -0,0 +1,68 @@
+package hints
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+)
+
+// A hint of a program that the hint processor doesn't implement
+type UnknownHint struct {
+	Pc   uint
+	Code string
+	// Location of the hint in the cairo source. Nil if the program has no debug info
+	Location *parser.Location
+}
+
+func (h *UnknownHint) LocationString() string {
+	if h.Location == nil {
+		return "unknown location"
+	}
+	return fmt.Sprintf("%s:%d:%d", h.Location.InputFile["filename"], h.Location.StartLine, h.Location.StartCol)
+}
+
+// Scans the program's hints before running it and returns the ones the processor doesn't implement, sorted by pc
+func (p *CairoVmHintProcessor) UnknownHints(program *vm.Program) []UnknownHint {
+	unknownHints := make([]UnknownHint, 0)
+	for pc, hints := range program.Hints {
+		for i, hint := range hints {
+			if p.IsHintSupported(hint.Code) {
+				continue
+			}
+			unknownHint := UnknownHint{Pc: pc, Code: hint.Code}
+			instructionLocation, ok := program.InstructionLocations[pc]
+			if ok && i < len(instructionLocation.Hints) {
+				unknownHint.Location = &instructionLocation.Hints[i].Location
+			} else if ok {
+				unknownHint.Location = &instructionLocation.Inst
+			}
+			unknownHints = append(unknownHints, unknownHint)
+		}
+	}
+	sort.SliceStable(unknownHints, func(i, j int) bool {
+		return unknownHints[i].Pc < unknownHints[j].Pc
+	})
+	return unknownHints
+}
+
+// Writes a human readable report of the unknown hints, as returned by UnknownHints
+func WriteHintsReport(unknownHints []UnknownHint, output io.Writer) error {
+	if len(unknownHints) == 0 {
+		_, err := fmt.Fprintln(output, "All hints are supported")
+		return err
+	}
+	_, err := fmt.Fprintf(output, "Found %d unsupported hints:\n", len(unknownHints))
+	if err != nil {
+		return err
+	}
+	for _, hint := range unknownHints {
+		_, err = fmt.Fprintf(output, "\npc %d (%s):\n%s\n", hint.Pc, hint.LocationString(), hint.Code)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
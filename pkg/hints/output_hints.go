@@ -0,0 +1,49 @@
+package hints
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+)
+
+// Maximum size of each onchain data page added by the SET_TREE_STRUCTURE hint
+const MAX_OUTPUT_PAGE_SIZE = 3800
+
+// Splits the output written since ids.da_start into pages of at most MAX_OUTPUT_PAGE_SIZE cells,
+// and sets the fact topology to a root with two children: the main part of the output (page 0) and a node containing the new pages
+func setTreeStructure(ids IdsManager, vm *VirtualMachine) error {
+	outputBuiltin, err := vm.GetOutputBuiltin()
+	if err != nil {
+		return err
+	}
+	onchainDataStart, err := ids.GetRelocatable("da_start", vm)
+	if err != nil {
+		return err
+	}
+	outputPtr, err := ids.GetRelocatable("output_ptr", vm)
+	if err != nil {
+		return err
+	}
+	onchainDataSizeFelt, err := outputPtr.Sub(onchainDataStart)
+	if err != nil {
+		return err
+	}
+	onchainDataSize, err := onchainDataSizeFelt.ToUint()
+	if err != nil {
+		return err
+	}
+
+	nPages := (onchainDataSize + MAX_OUTPUT_PAGE_SIZE - 1) / MAX_OUTPUT_PAGE_SIZE
+	for i := uint(0); i < nPages; i++ {
+		startOffset := i * MAX_OUTPUT_PAGE_SIZE
+		pageSize := onchainDataSize - startOffset
+		if pageSize > MAX_OUTPUT_PAGE_SIZE {
+			pageSize = MAX_OUTPUT_PAGE_SIZE
+		}
+		err = outputBuiltin.AddPage(1+i, onchainDataStart.AddUint(startOffset), pageSize)
+		if err != nil {
+			return err
+		}
+	}
+	return outputBuiltin.AddAttribute(builtins.GPS_FACT_TOPOLOGY, []uint{1 + nPages, nPages, 0, 2})
+}
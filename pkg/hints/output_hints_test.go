@@ -0,0 +1,67 @@
+package hints_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestSetTreeStructure(t *testing.T) {
+	vm := NewVirtualMachine()
+	outputBuiltin := builtins.NewOutputBuiltinRunner()
+	outputBuiltin.InitializeSegments(&vm.Segments)
+	vm.BuiltinRunners = []builtins.BuiltinRunner{outputBuiltin}
+	vm.Segments.AddSegment()
+	vm.RunContext.Fp = NewRelocatable(1, 0)
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"da_start":   {NewMaybeRelocatableRelocatable(NewRelocatable(0, 2))},
+			"output_ptr": {NewMaybeRelocatableRelocatable(NewRelocatable(0, 5))},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SET_TREE_STRUCTURE,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Fatalf("SET_TREE_STRUCTURE hint test failed with error %s", err)
+	}
+	expectedPages := map[uint]builtins.PublicMemoryPage{1: {Start: 2, Size: 3}}
+	if !reflect.DeepEqual(outputBuiltin.GetPages(), expectedPages) {
+		t.Errorf("Wrong pages. Expected %v, got %v", expectedPages, outputBuiltin.GetPages())
+	}
+	expectedAttributes := map[string][]uint{builtins.GPS_FACT_TOPOLOGY: {2, 1, 0, 2}}
+	if !reflect.DeepEqual(outputBuiltin.GetAttributes(), expectedAttributes) {
+		t.Errorf("Wrong attributes. Expected %v, got %v", expectedAttributes, outputBuiltin.GetAttributes())
+	}
+}
+
+func TestSetTreeStructureNoOutputBuiltin(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"da_start":   {NewMaybeRelocatableRelocatable(NewRelocatable(0, 0))},
+			"output_ptr": {NewMaybeRelocatableRelocatable(NewRelocatable(0, 1))},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SET_TREE_STRUCTURE,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err == nil {
+		t.Errorf("SET_TREE_STRUCTURE hint test should have failed")
+	}
+}
@@ -0,0 +1,194 @@
+package hints
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/types"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Formats a value the way python's print does: felts as (unsigned) integers and relocatables as segment:offset
+func formatPrintValue(value *MaybeRelocatable) string {
+	felt, ok := value.GetFelt()
+	if ok {
+		return felt.ToBigInt().String()
+	}
+	rel, _ := value.GetRelocatable()
+	return fmt.Sprintf("%d:%d", rel.SegmentIndex, rel.Offset)
+}
+
+// Formats the memory cells [ptr, ptr + size) as a python list
+func formatPrintList(ptr Relocatable, size uint, vm *VirtualMachine) (string, error) {
+	values := make([]string, 0, size)
+	for i := uint(0); i < size; i++ {
+		value, err := vm.Segments.Memory.Get(ptr.AddUint(i))
+		if err != nil {
+			return "", err
+		}
+		values = append(values, formatPrintValue(value))
+	}
+	return "[" + strings.Join(values, ", ") + "]", nil
+}
+
+/*
+Implements hint:
+
+	bytes.fromhex(f"{ids.name:062x}").decode().replace('\x00','')
+
+Decodes the short string stored in ids.name
+*/
+func printName(ids IdsManager, vm *VirtualMachine, output io.Writer) error {
+	name, err := ids.GetFelt("name", vm)
+	if err != nil {
+		return err
+	}
+	nameBytes := name.ToBeBytes()
+	if nameBytes[0] != 0 {
+		return errors.Errorf("Name %s doesn't fit in 31 bytes", name.ToHexString())
+	}
+	decoded := strings.ReplaceAll(string(nameBytes[1:]), "\x00", "")
+	if !utf8.ValidString(decoded) {
+		return errors.Errorf("Name %s is not a valid utf-8 string", name.ToHexString())
+	}
+	_, err = fmt.Fprintln(output, decoded)
+	return err
+}
+
+/*
+Implements hint:
+%{ print(ids.x) %}
+*/
+
+func printFelt(ids IdsManager, vm *VirtualMachine, output io.Writer) error {
+	x, err := ids.Get("x", vm)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(output, formatPrintValue(x))
+	return err
+}
+
+/*
+Implements hint:
+%{
+    print(bytes.fromhex(f"{ids.name:062x}").decode().replace('\x00',''))
+    arr = [memory[ids.arr + i] for i in range(ids.arr_len)]
+    print(arr)
+%}
+*/
+
+func printArray(ids IdsManager, vm *VirtualMachine, output io.Writer) error {
+	err := printName(ids, vm, output)
+	if err != nil {
+		return err
+	}
+	arr, err := ids.GetRelocatable("arr", vm)
+	if err != nil {
+		return err
+	}
+	arrLenFelt, err := ids.GetFelt("arr_len", vm)
+	if err != nil {
+		return err
+	}
+	arrLen, err := arrLenFelt.ToUint()
+	if err != nil {
+		return err
+	}
+	list, err := formatPrintList(arr, arrLen, vm)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(output, list)
+	return err
+}
+
+/*
+Implements hint:
+%{
+    print(bytes.fromhex(f"{ids.name:062x}").decode().replace('\x00',''))
+    data = __dict_manager.get_dict(ids.dict_ptr)
+    print(
+        {k: v if isinstance(v, int) else [memory[v + i] for i in range(ids.pointer_size)] for k, v in data.items()}
+    )
+%}
+As go maps are unordered, the entries are printed sorted by key
+*/
+
+func printDict(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes, output io.Writer) error {
+	err := printName(ids, vm, output)
+	if err != nil {
+		return err
+	}
+	dictManager, ok := FetchDictManager(scopes)
+	if !ok {
+		return errors.New("Variable __dict_manager not present in current execution scope")
+	}
+	dictPtr, err := ids.GetRelocatable("dict_ptr", vm)
+	if err != nil {
+		return err
+	}
+	pointerSizeFelt, err := ids.GetFelt("pointer_size", vm)
+	if err != nil {
+		return err
+	}
+	pointerSize, err := pointerSizeFelt.ToUint()
+	if err != nil {
+		return err
+	}
+	tracker, err := dictManager.GetTracker(dictPtr)
+	if err != nil {
+		return err
+	}
+	dict := tracker.CopyDictionary()
+
+	keys := make([]MaybeRelocatable, 0, len(dict))
+	for key := range dict {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return compareMaybeRelocatables(&keys[i], &keys[j]) < 0
+	})
+
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := dict[key]
+		formattedValue := formatPrintValue(&value)
+		ptr, isRelocatable := value.GetRelocatable()
+		if isRelocatable {
+			formattedValue, err = formatPrintList(ptr, pointerSize, vm)
+			if err != nil {
+				return err
+			}
+		}
+		entries = append(entries, formatPrintValue(&key)+": "+formattedValue)
+	}
+	_, err = fmt.Fprintln(output, "{"+strings.Join(entries, ", ")+"}")
+	return err
+}
+
+// Orders felts before relocatables, felts by value and relocatables by segment and offset
+func compareMaybeRelocatables(a *MaybeRelocatable, b *MaybeRelocatable) int {
+	aFelt, aIsFelt := a.GetFelt()
+	bFelt, bIsFelt := b.GetFelt()
+	switch {
+	case aIsFelt && bIsFelt:
+		return aFelt.Cmp(bFelt)
+	case aIsFelt:
+		return -1
+	case bIsFelt:
+		return 1
+	}
+	aRel, _ := a.GetRelocatable()
+	bRel, _ := b.GetRelocatable()
+	if aRel.SegmentIndex != bRel.SegmentIndex {
+		return aRel.SegmentIndex - bRel.SegmentIndex
+	}
+	return int(aRel.Offset) - int(bRel.Offset)
+}
@@ -0,0 +1,122 @@
+package hints_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/dict_manager"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func runPrintHint(code string, vm *VirtualMachine, ids map[string][]*MaybeRelocatable, scopes *types.ExecutionScopes) (string, error) {
+	idsManager := SetupIdsForTest(ids, vm)
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: code,
+	})
+	var output bytes.Buffer
+	hintProcessor := CairoVmHintProcessor{DebugOutput: &output}
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	return output.String(), err
+}
+
+func TestPrintFelt(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	output, err := runPrintHint(PRINT_FELT, vm, map[string][]*MaybeRelocatable{
+		"x": {NewMaybeRelocatableFelt(FeltZero().Sub(FeltOne()))},
+	}, nil)
+	if err != nil {
+		t.Fatalf("PRINT_FELT hint test failed with error %s", err)
+	}
+	expected := "3618502788666131213697322783095070105623107215331596699973092056135872020480\n"
+	if output != expected {
+		t.Errorf("Wrong output. Expected %q, got %q", expected, output)
+	}
+}
+
+func TestPrintArray(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	arr := vm.Segments.AddSegment()
+	vm.Segments.LoadData(arr, &[]MaybeRelocatable{
+		*NewMaybeRelocatableFelt(FeltFromUint64(1)),
+		*NewMaybeRelocatableRelocatable(NewRelocatable(2, 3)),
+	})
+	output, err := runPrintHint(PRINT_ARR, vm, map[string][]*MaybeRelocatable{
+		"name":    {NewMaybeRelocatableFelt(FeltFromHex("0x4a4f484e"))},
+		"arr":     {NewMaybeRelocatableRelocatable(arr)},
+		"arr_len": {NewMaybeRelocatableFelt(FeltFromUint64(2))},
+	}, nil)
+	if err != nil {
+		t.Fatalf("PRINT_ARR hint test failed with error %s", err)
+	}
+	expected := "JOHN\n[1, 2:3]\n"
+	if output != expected {
+		t.Errorf("Wrong output. Expected %q, got %q", expected, output)
+	}
+}
+
+func TestPrintArrayMissingElement(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	arr := vm.Segments.AddSegment()
+	_, err := runPrintHint(PRINT_ARR, vm, map[string][]*MaybeRelocatable{
+		"name":    {NewMaybeRelocatableFelt(FeltFromHex("0x4a4f484e"))},
+		"arr":     {NewMaybeRelocatableRelocatable(arr)},
+		"arr_len": {NewMaybeRelocatableFelt(FeltFromUint64(1))},
+	}, nil)
+	if err == nil {
+		t.Errorf("PRINT_ARR hint test should have failed")
+	}
+}
+
+func TestPrintDict(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	values := vm.Segments.AddSegment()
+	vm.Segments.LoadData(values, &[]MaybeRelocatable{
+		*NewMaybeRelocatableFelt(FeltFromUint64(7)),
+		*NewMaybeRelocatableFelt(FeltFromUint64(8)),
+	})
+	dict := map[MaybeRelocatable]MaybeRelocatable{
+		*NewMaybeRelocatableFelt(FeltFromUint64(3)): *NewMaybeRelocatableFelt(FeltFromUint64(4)),
+		*NewMaybeRelocatableFelt(FeltFromUint64(1)): *NewMaybeRelocatableRelocatable(values),
+	}
+	dictManager := dict_manager.NewDictManager()
+	dictPtr := dictManager.NewDictionary(&dict, vm)
+	scopes := types.NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
+
+	output, err := runPrintHint(PRINT_DICT, vm, map[string][]*MaybeRelocatable{
+		"name":         {NewMaybeRelocatableFelt(FeltFromHex("0x44494354"))},
+		"dict_ptr":     {NewMaybeRelocatableRelocatable(dictPtr)},
+		"pointer_size": {NewMaybeRelocatableFelt(FeltFromUint64(2))},
+	}, scopes)
+	if err != nil {
+		t.Fatalf("PRINT_DICT hint test failed with error %s", err)
+	}
+	expected := "DICT\n{1: [7, 8], 3: 4}\n"
+	if output != expected {
+		t.Errorf("Wrong output. Expected %q, got %q", expected, output)
+	}
+}
+
+func TestPrintDictNoDictManager(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	_, err := runPrintHint(PRINT_DICT, vm, map[string][]*MaybeRelocatable{
+		"name":         {NewMaybeRelocatableFelt(FeltFromHex("0x44494354"))},
+		"dict_ptr":     {NewMaybeRelocatableRelocatable(NewRelocatable(1, 0))},
+		"pointer_size": {NewMaybeRelocatableFelt(FeltFromUint64(1))},
+	}, types.NewExecutionScopes())
+	if err == nil {
+		t.Errorf("PRINT_DICT hint test should have failed")
+	}
+}
@@ -0,0 +1,149 @@
+package python_interpreter
+
+// Statements
+
+type stmt interface {
+	stmtLine() int
+}
+
+type exprStmt struct {
+	line int
+	expr expr
+}
+
+// targets[0] = targets[1] = ... = value
+type assignStmt struct {
+	line    int
+	targets []expr
+	value   expr
+}
+
+// target op= value
+type augAssignStmt struct {
+	line   int
+	target expr
+	op     string
+	value  expr
+}
+
+type ifStmt struct {
+	line   int
+	cond   expr
+	body   []stmt
+	orElse []stmt
+}
+
+type whileStmt struct {
+	line int
+	cond expr
+	body []stmt
+}
+
+type forStmt struct {
+	line   int
+	target expr
+	iter   expr
+	body   []stmt
+}
+
+type assertStmt struct {
+	line int
+	cond expr
+	// Nil if the assertion has no message
+	msg expr
+}
+
+// from module import names[0] as aliases[0], ...
+type importStmt struct {
+	line    int
+	module  string
+	names   []string
+	aliases []string
+}
+
+type passStmt struct{ line int }
+
+type breakStmt struct{ line int }
+
+type continueStmt struct{ line int }
+
+func (s *exprStmt) stmtLine() int      { return s.line }
+func (s *assignStmt) stmtLine() int    { return s.line }
+func (s *augAssignStmt) stmtLine() int { return s.line }
+func (s *ifStmt) stmtLine() int        { return s.line }
+func (s *whileStmt) stmtLine() int     { return s.line }
+func (s *forStmt) stmtLine() int       { return s.line }
+func (s *assertStmt) stmtLine() int    { return s.line }
+func (s *importStmt) stmtLine() int    { return s.line }
+func (s *passStmt) stmtLine() int      { return s.line }
+func (s *breakStmt) stmtLine() int     { return s.line }
+func (s *continueStmt) stmtLine() int  { return s.line }
+
+// Expressions
+
+type expr interface{}
+
+type nameExpr struct{ name string }
+
+// Int, string, bool and None literals
+type constExpr struct{ value any }
+
+type attrExpr struct {
+	obj  expr
+	name string
+}
+
+type subscriptExpr struct {
+	obj   expr
+	index expr
+}
+
+type callExpr struct {
+	fn         expr
+	args       []expr
+	kwargNames []string
+	kwargs     []expr
+}
+
+type binaryExpr struct {
+	op          string
+	left, right expr
+}
+
+type unaryExpr struct {
+	op      string
+	operand expr
+}
+
+// Short circuit and / or
+type boolExpr struct {
+	op          string
+	left, right expr
+}
+
+// Chained comparison: operands[0] ops[0] operands[1] ops[1] ...
+type compareExpr struct {
+	ops      []string
+	operands []expr
+}
+
+// body if cond else orElse
+type ifExpr struct {
+	cond, body, orElse expr
+}
+
+type listExpr struct{ elts []expr }
+
+type tupleExpr struct{ elts []expr }
+
+type dictExpr struct {
+	keys, values []expr
+}
+
+// [elt for target in iter if conds[0] if conds[1] ...]
+type listCompExpr struct {
+	elt    expr
+	target expr
+	iter   expr
+	conds  []expr
+}
@@ -0,0 +1,546 @@
+package python_interpreter
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Functions that can be imported, by module and name, mapped to the builtin implementing them
+var importable = map[string]string{
+	"starkware.cairo.common.math_utils.as_int":         "as_int",
+	"starkware.cairo.common.math_utils.assert_integer": "assert_integer",
+	"starkware.python.math_utils.div_mod":              "div_mod",
+	"starkware.python.math_utils.safe_div":             "safe_div",
+	"starkware.python.math_utils.div_ceil":             "div_ceil",
+	"starkware.python.math_utils.isqrt":                "isqrt",
+}
+
+// Checks the amount of positional arguments and rejects keyword arguments
+func checkArgs(name string, args []value, kwargs map[string]value, min int, max int) error {
+	if len(kwargs) != 0 {
+		return errors.Errorf("%s() takes no keyword arguments", name)
+	}
+	if len(args) < min || len(args) > max {
+		if min == max {
+			return errors.Errorf("%s() takes %d arguments (%d given)", name, min, len(args))
+		}
+		return errors.Errorf("%s() takes from %d to %d arguments (%d given)", name, min, max, len(args))
+	}
+	return nil
+}
+
+// Checks that there are exactly count arguments, all of them ints
+func intArgs(name string, args []value, kwargs map[string]value, count int) ([]*big.Int, error) {
+	err := checkArgs(name, args, kwargs, count, count)
+	if err != nil {
+		return nil, err
+	}
+	ints := make([]*big.Int, 0, count)
+	for _, arg := range args {
+		n, err := expectInt(arg, name+"()")
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, n)
+	}
+	return ints, nil
+}
+
+func (in *interpreter) newBuiltins() map[string]value {
+	functions := map[string]func(args []value, kwargs map[string]value) (value, error){
+		"range":                  builtinRange,
+		"len":                    builtinLen,
+		"int":                    builtinInt,
+		"abs":                    builtinAbs,
+		"pow":                    builtinPow,
+		"divmod":                 builtinDivmod,
+		"bool":                   builtinBool,
+		"str":                    builtinStr,
+		"hex":                    builtinHex,
+		"to_felt_or_relocatable": builtinToFeltOrRelocatable,
+		"as_int":                 builtinAsInt,
+		"assert_integer":         builtinAssertInteger,
+		"div_mod":                builtinDivMod,
+		"safe_div":               builtinSafeDiv,
+		"div_ceil":               builtinDivCeil,
+		"isqrt":                  builtinIsqrt,
+		"min": func(args []value, kwargs map[string]value) (value, error) {
+			return in.minMax("min", args, kwargs, -1)
+		},
+		"max": func(args []value, kwargs map[string]value) (value, error) {
+			return in.minMax("max", args, kwargs, 1)
+		},
+		"sum":            in.builtinSum,
+		"list":           in.builtinList,
+		"tuple":          in.builtinTuple,
+		"enumerate":      in.builtinEnumerate,
+		"print":          in.builtinPrint,
+		"vm_enter_scope": in.builtinVmEnterScope,
+		"vm_exit_scope":  in.builtinVmExitScope,
+	}
+	builtins := make(map[string]value, len(functions))
+	for name, fn := range functions {
+		builtins[name] = &builtinFunc{name: name, fn: fn}
+	}
+	return builtins
+}
+
+func builtinRange(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("range", args, kwargs, 1, 3)
+	if err != nil {
+		return nil, err
+	}
+	bounds := make([]*big.Int, 0, len(args))
+	for _, arg := range args {
+		n, err := expectInt(arg, "range()")
+		if err != nil {
+			return nil, err
+		}
+		bounds = append(bounds, n)
+	}
+	switch len(bounds) {
+	case 1:
+		return &rangeValue{start: big.NewInt(0), stop: bounds[0], step: big.NewInt(1)}, nil
+	case 2:
+		return &rangeValue{start: bounds[0], stop: bounds[1], step: big.NewInt(1)}, nil
+	}
+	if bounds[2].Sign() == 0 {
+		return nil, errors.New("ValueError: range() arg 3 must not be zero")
+	}
+	return &rangeValue{start: bounds[0], stop: bounds[1], step: bounds[2]}, nil
+}
+
+func builtinLen(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("len", args, kwargs, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	switch arg := args[0].(type) {
+	case string:
+		return big.NewInt(int64(len(arg))), nil
+	case *listValue:
+		return big.NewInt(int64(len(arg.elts))), nil
+	case *tupleValue:
+		return big.NewInt(int64(len(arg.elts))), nil
+	case *dictValue:
+		return big.NewInt(int64(len(arg.keys))), nil
+	case *rangeValue:
+		return arg.length(), nil
+	}
+	return nil, errors.Errorf("object of type '%s' has no len()", typeName(args[0]))
+}
+
+func builtinInt(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("int", args, kwargs, 0, 2)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return big.NewInt(0), nil
+	}
+	if s, ok := args[0].(string); ok {
+		base := big.NewInt(10)
+		if len(args) == 2 {
+			base, err = expectInt(args[1], "int()")
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !base.IsInt64() || base.Int64() < 0 || base.Int64() > 36 || base.Int64() == 1 {
+			return nil, errors.New("ValueError: int() base must be >= 2 and <= 36, or 0")
+		}
+		n, ok := new(big.Int).SetString(strings.ReplaceAll(strings.TrimSpace(s), "_", ""), int(base.Int64()))
+		if !ok {
+			return nil, errors.Errorf("ValueError: invalid literal for int() with base %s: %s", base, repr(s))
+		}
+		return n, nil
+	}
+	if len(args) == 2 {
+		return nil, errors.New("int() can't convert non-string with explicit base")
+	}
+	n, err := expectInt(args[0], "int()")
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(n), nil
+}
+
+func builtinAbs(args []value, kwargs map[string]value) (value, error) {
+	ints, err := intArgs("abs", args, kwargs, 1)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Abs(ints[0]), nil
+}
+
+func builtinPow(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("pow", args, kwargs, 2, 3)
+	if err != nil {
+		return nil, err
+	}
+	ints, err := intArgs("pow", args, nil, len(args))
+	if err != nil {
+		return nil, err
+	}
+	if len(ints) == 2 {
+		return intPow(ints[0], ints[1], nil)
+	}
+	return intPow(ints[0], ints[1], ints[2])
+}
+
+func builtinDivmod(args []value, kwargs map[string]value) (value, error) {
+	ints, err := intArgs("divmod", args, kwargs, 2)
+	if err != nil {
+		return nil, err
+	}
+	if ints[1].Sign() == 0 {
+		return nil, errors.New("ZeroDivisionError: integer division or modulo by zero")
+	}
+	quotient, remainder := floorDivMod(ints[0], ints[1])
+	return &tupleValue{elts: []value{quotient, remainder}}, nil
+}
+
+func builtinBool(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("bool", args, kwargs, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	return len(args) == 1 && truthy(args[0]), nil
+}
+
+func builtinStr(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("str", args, kwargs, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return "", nil
+	}
+	return str(args[0]), nil
+}
+
+func builtinHex(args []value, kwargs map[string]value) (value, error) {
+	ints, err := intArgs("hex", args, kwargs, 1)
+	if err != nil {
+		return nil, err
+	}
+	if ints[0].Sign() < 0 {
+		return "-0x" + new(big.Int).Neg(ints[0]).Text(16), nil
+	}
+	return "0x" + ints[0].Text(16), nil
+}
+
+func builtinToFeltOrRelocatable(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("to_felt_or_relocatable", args, kwargs, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if rel, ok := args[0].(Relocatable); ok {
+		return rel, nil
+	}
+	n, err := expectInt(args[0], "to_felt_or_relocatable()")
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Mod(n, lambdaworks.Prime()), nil
+}
+
+// as_int(value, prime): the representative of value in the range (-prime/2, prime/2)
+func builtinAsInt(args []value, kwargs map[string]value) (value, error) {
+	ints, err := intArgs("as_int", args, kwargs, 2)
+	if err != nil {
+		return nil, err
+	}
+	n := new(big.Int).Mod(ints[0], ints[1])
+	if n.Cmp(new(big.Int).Rsh(ints[1], 1)) > 0 {
+		n.Sub(n, ints[1])
+	}
+	return n, nil
+}
+
+func builtinAssertInteger(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("assert_integer", args, kwargs, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := args[0].(*big.Int); !ok {
+		return nil, errors.Errorf("Expected integer, found: %s", repr(args[0]))
+	}
+	return none, nil
+}
+
+func builtinDivMod(args []value, kwargs map[string]value) (value, error) {
+	ints, err := intArgs("div_mod", args, kwargs, 3)
+	if err != nil {
+		return nil, err
+	}
+	return utils.DivMod(ints[0], ints[1], ints[2])
+}
+
+func builtinSafeDiv(args []value, kwargs map[string]value) (value, error) {
+	ints, err := intArgs("safe_div", args, kwargs, 2)
+	if err != nil {
+		return nil, err
+	}
+	return utils.SafeDivBig(ints[0], ints[1])
+}
+
+func builtinDivCeil(args []value, kwargs map[string]value) (value, error) {
+	ints, err := intArgs("div_ceil", args, kwargs, 2)
+	if err != nil {
+		return nil, err
+	}
+	if ints[1].Sign() == 0 {
+		return nil, errors.New("ZeroDivisionError: integer division or modulo by zero")
+	}
+	// -((-a) // b)
+	quotient, _ := floorDivMod(new(big.Int).Neg(ints[0]), ints[1])
+	return quotient.Neg(quotient), nil
+}
+
+func builtinIsqrt(args []value, kwargs map[string]value) (value, error) {
+	ints, err := intArgs("isqrt", args, kwargs, 1)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ISqrt(ints[0])
+}
+
+// Returns the smallest (sign -1) or largest (sign 1) of the arguments, or of the elements of a single iterable argument
+func (in *interpreter) minMax(name string, args []value, kwargs map[string]value, sign int) (value, error) {
+	err := checkArgs(name, args, kwargs, 1, len(args))
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 1 {
+		args, err = in.toList(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 0 {
+			return nil, errors.Errorf("ValueError: %s() arg is an empty sequence", name)
+		}
+	}
+	result := args[0]
+	for _, arg := range args[1:] {
+		cmp, err := order(arg, result)
+		if err != nil {
+			return nil, errors.Errorf("%s() can't compare '%s' and '%s'", name, typeName(arg), typeName(result))
+		}
+		if cmp*sign > 0 {
+			result = arg
+		}
+	}
+	return result, nil
+}
+
+func (in *interpreter) builtinSum(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("sum", args, kwargs, 1, 2)
+	if err != nil {
+		return nil, err
+	}
+	var total value = big.NewInt(0)
+	if len(args) == 2 {
+		total = args[1]
+	}
+	err = in.iterate(args[0], func(elt value) error {
+		total, err = binaryOp("+", total, elt)
+		return err
+	})
+	return total, err
+}
+
+func (in *interpreter) builtinList(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("list", args, kwargs, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return &listValue{elts: make([]value, 0)}, nil
+	}
+	elts, err := in.toList(args[0])
+	return &listValue{elts: elts}, err
+}
+
+func (in *interpreter) builtinTuple(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("tuple", args, kwargs, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return &tupleValue{}, nil
+	}
+	elts, err := in.toList(args[0])
+	return &tupleValue{elts: elts}, err
+}
+
+func (in *interpreter) builtinEnumerate(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("enumerate", args, kwargs, 1, 2)
+	if err != nil {
+		return nil, err
+	}
+	start := big.NewInt(0)
+	if len(args) == 2 {
+		start, err = expectInt(args[1], "enumerate()")
+		if err != nil {
+			return nil, err
+		}
+	}
+	elts, err := in.toList(args[0])
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]value, 0, len(elts))
+	for i, elt := range elts {
+		index := new(big.Int).Add(start, big.NewInt(int64(i)))
+		pairs = append(pairs, &tupleValue{elts: []value{index, elt}})
+	}
+	return &listValue{elts: pairs}, nil
+}
+
+func (in *interpreter) builtinPrint(args []value, kwargs map[string]value) (value, error) {
+	sep, end := " ", "\n"
+	for name, kwarg := range kwargs {
+		s, ok := kwarg.(string)
+		switch {
+		case name == "sep" && ok:
+			sep = s
+		case name == "end" && ok:
+			end = s
+		default:
+			return nil, errors.Errorf("print() got an unsupported keyword argument '%s'", name)
+		}
+	}
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		parts = append(parts, str(arg))
+	}
+	_, err := fmt.Fprint(in.ctx.Output, strings.Join(parts, sep)+end)
+	return none, err
+}
+
+// vm_enter_scope(new_scope_locals=None): enters a new scope, optionally initialized with a dict of variables
+func (in *interpreter) builtinVmEnterScope(args []value, kwargs map[string]value) (value, error) {
+	var locals value = none
+	if v, ok := kwargs["new_scope_locals"]; ok && len(kwargs) == 1 && len(args) == 0 {
+		locals = v
+	} else {
+		err := checkArgs("vm_enter_scope", args, kwargs, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 1 {
+			locals = args[0]
+		}
+	}
+	scope := make(map[string]interface{})
+	switch locals := locals.(type) {
+	case noneType:
+	case *dictValue:
+		for i, key := range locals.keys {
+			name, ok := key.(string)
+			if !ok {
+				return nil, errors.New("vm_enter_scope() expects a dict with str keys")
+			}
+			scope[name] = toScopeVar(locals.values[i])
+		}
+	default:
+		return nil, errors.Errorf("vm_enter_scope() expects a dict, got %s", typeName(locals))
+	}
+	in.ctx.Scopes.EnterScope(scope)
+	return none, nil
+}
+
+func (in *interpreter) builtinVmExitScope(args []value, kwargs map[string]value) (value, error) {
+	err := checkArgs("vm_exit_scope", args, kwargs, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return none, in.ctx.Scopes.ExitScope()
+}
+
+func listMethod(list *listValue, name string) (value, error) {
+	var fn func(args []value, kwargs map[string]value) (value, error)
+	switch name {
+	case "append":
+		fn = func(args []value, kwargs map[string]value) (value, error) {
+			err := checkArgs("append", args, kwargs, 1, 1)
+			if err != nil {
+				return nil, err
+			}
+			list.elts = append(list.elts, args[0])
+			return none, nil
+		}
+	case "pop":
+		fn = func(args []value, kwargs map[string]value) (value, error) {
+			err := checkArgs("pop", args, kwargs, 0, 1)
+			if err != nil {
+				return nil, err
+			}
+			if len(list.elts) == 0 {
+				return nil, errors.New("IndexError: pop from empty list")
+			}
+			i := len(list.elts) - 1
+			if len(args) == 1 {
+				i, err = sequenceIndex(args[0], len(list.elts))
+				if err != nil {
+					return nil, err
+				}
+			}
+			elt := list.elts[i]
+			list.elts = append(list.elts[:i], list.elts[i+1:]...)
+			return elt, nil
+		}
+	default:
+		return nil, errors.Errorf("'list' object has no attribute '%s'", name)
+	}
+	return &builtinFunc{name: "list." + name, fn: fn}, nil
+}
+
+func dictMethod(dict *dictValue, name string) (value, error) {
+	var fn func(args []value, kwargs map[string]value) (value, error)
+	switch name {
+	case "get":
+		fn = func(args []value, kwargs map[string]value) (value, error) {
+			err := checkArgs("get", args, kwargs, 1, 2)
+			if err != nil {
+				return nil, err
+			}
+			if v, ok := dict.get(args[0]); ok {
+				return v, nil
+			}
+			if len(args) == 2 {
+				return args[1], nil
+			}
+			return none, nil
+		}
+	case "keys", "values", "items":
+		fn = func(args []value, kwargs map[string]value) (value, error) {
+			err := checkArgs(name, args, kwargs, 0, 0)
+			if err != nil {
+				return nil, err
+			}
+			elts := make([]value, 0, len(dict.keys))
+			for i, key := range dict.keys {
+				switch name {
+				case "keys":
+					elts = append(elts, key)
+				case "values":
+					elts = append(elts, dict.values[i])
+				default:
+					elts = append(elts, &tupleValue{elts: []value{key, dict.values[i]}})
+				}
+			}
+			return &listValue{elts: elts}, nil
+		}
+	default:
+		return nil, errors.Errorf("'dict' object has no attribute '%s'", name)
+	}
+	return &builtinFunc{name: "dict." + name, fn: fn}, nil
+}
@@ -0,0 +1,831 @@
+package python_interpreter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Everything a hint can access while it runs
+type Context struct {
+	Ids       IdsManager
+	Vm        *vm.VirtualMachine
+	Constants *map[string]lambdaworks.Felt
+	Scopes    *types.ExecutionScopes
+	// Writer print writes to
+	Output io.Writer
+	// Stops the loops of the hint once done. Nil if the hint can't be cancelled
+	Cancellation context.Context
+	// Maximum amount of loop iterations the hint can run, counting the elements iterated by builtins and
+	// comprehensions. Default: DefaultMaxLoopIterations
+	MaxLoopIterations uint
+}
+
+const DefaultMaxLoopIterations = 10_000_000
+
+// Amount of loop iterations between two checks of the hint's Cancellation context
+const cancellationCheckInterval = 1024
+
+var ErrLoopLimitExceeded = errors.New("Python hint exceeded its maximum amount of loop iterations")
+
+type interpreter struct {
+	ctx *Context
+	// Names bound by import statements
+	imports  map[string]value
+	builtins map[string]value
+	// Loop iterations run so far, bounded by the context's MaxLoopIterations
+	iterations    uint
+	maxIterations uint
+}
+
+var (
+	errBreak    = errors.New("'break' outside loop")
+	errContinue = errors.New("'continue' not properly in loop")
+)
+
+// An error annotated with the line of the statement that caused it
+type lineError struct {
+	line int
+	err  error
+}
+
+func (e *lineError) Error() string {
+	return fmt.Sprintf("Python hint error at line %d: %s", e.line, e.err)
+}
+
+func (e *lineError) Unwrap() error {
+	return e.err
+}
+
+// Executes the program. Variables assigned by the program are written to the current execution scope
+func (program *Program) Execute(ctx *Context) error {
+	in := &interpreter{ctx: ctx, imports: make(map[string]value), maxIterations: ctx.MaxLoopIterations}
+	if in.maxIterations == 0 {
+		in.maxIterations = DefaultMaxLoopIterations
+	}
+	in.builtins = in.newBuiltins()
+	err := in.execBlock(program.body)
+	if err == errBreak || err == errContinue {
+		return errors.New(err.Error())
+	}
+	return err
+}
+
+// Counts a loop iteration, failing once the hint runs out of iterations or is cancelled
+func (in *interpreter) loopIteration() error {
+	in.iterations++
+	if in.iterations > in.maxIterations {
+		return errors.Wrapf(ErrLoopLimitExceeded, "limit: %d", in.maxIterations)
+	}
+	if in.ctx.Cancellation != nil && in.iterations%cancellationCheckInterval == 0 {
+		return in.ctx.Cancellation.Err()
+	}
+	return nil
+}
+
+func (in *interpreter) execBlock(body []stmt) error {
+	for _, s := range body {
+		err := in.execStmt(s)
+		if err != nil {
+			if _, ok := err.(*lineError); ok || err == errBreak || err == errContinue {
+				return err
+			}
+			return &lineError{line: s.stmtLine(), err: err}
+		}
+	}
+	return nil
+}
+
+func (in *interpreter) execStmt(s stmt) error {
+	switch s := s.(type) {
+	case *exprStmt:
+		_, err := in.eval(s.expr)
+		return err
+	case *assignStmt:
+		v, err := in.eval(s.value)
+		if err != nil {
+			return err
+		}
+		for _, target := range s.targets {
+			err = in.assign(target, v)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case *augAssignStmt:
+		current, err := in.eval(s.target)
+		if err != nil {
+			return err
+		}
+		operand, err := in.eval(s.value)
+		if err != nil {
+			return err
+		}
+		var result value
+		if list, ok := current.(*listValue); ok && s.op == "+" {
+			// In place extension, like python's list.__iadd__
+			err = in.iterate(operand, func(elt value) error {
+				list.elts = append(list.elts, elt)
+				return nil
+			})
+			result = list
+		} else {
+			result, err = binaryOp(s.op, current, operand)
+		}
+		if err != nil {
+			return err
+		}
+		return in.assign(s.target, result)
+	case *ifStmt:
+		cond, err := in.eval(s.cond)
+		if err != nil {
+			return err
+		}
+		if truthy(cond) {
+			return in.execBlock(s.body)
+		}
+		return in.execBlock(s.orElse)
+	case *whileStmt:
+		for {
+			err := in.loopIteration()
+			if err != nil {
+				return err
+			}
+			cond, err := in.eval(s.cond)
+			if err != nil {
+				return err
+			}
+			if !truthy(cond) {
+				return nil
+			}
+			err = in.execBlock(s.body)
+			if err == errBreak {
+				return nil
+			}
+			if err != nil && err != errContinue {
+				return err
+			}
+		}
+	case *forStmt:
+		iter, err := in.eval(s.iter)
+		if err != nil {
+			return err
+		}
+		err = in.iterate(iter, func(elt value) error {
+			err := in.assign(s.target, elt)
+			if err != nil {
+				return err
+			}
+			err = in.execBlock(s.body)
+			if err == errContinue {
+				return nil
+			}
+			return err
+		})
+		if err == errBreak {
+			return nil
+		}
+		return err
+	case *assertStmt:
+		cond, err := in.eval(s.cond)
+		if err != nil {
+			return err
+		}
+		if truthy(cond) {
+			return nil
+		}
+		if s.msg == nil {
+			return errors.New("AssertionError")
+		}
+		msg, err := in.eval(s.msg)
+		if err != nil {
+			return err
+		}
+		return errors.Errorf("AssertionError: %s", str(msg))
+	case *importStmt:
+		for i, name := range s.names {
+			imported, ok := importable[s.module+"."+name]
+			if !ok {
+				return errors.Errorf("Unsupported import: %s from %s", name, s.module)
+			}
+			in.imports[s.aliases[i]] = in.builtins[imported]
+		}
+		return nil
+	case *passStmt:
+		return nil
+	case *breakStmt:
+		return errBreak
+	case *continueStmt:
+		return errContinue
+	}
+	return errors.Errorf("Unsupported statement %T", s)
+}
+
+// Binds a value to an assignment target
+func (in *interpreter) assign(target expr, v value) error {
+	switch target := target.(type) {
+	case *nameExpr:
+		delete(in.imports, target.name)
+		in.ctx.Scopes.AssignOrUpdateVariable(target.name, toScopeVar(v))
+		return nil
+	case *tupleExpr:
+		return in.unpack(target.elts, v)
+	case *listExpr:
+		return in.unpack(target.elts, v)
+	case *attrExpr:
+		obj, err := in.eval(target.obj)
+		if err != nil {
+			return err
+		}
+		switch obj := obj.(type) {
+		case idsValue:
+			return in.setIds(target.name, v)
+		case *structValue:
+			cell, err := toMaybeRelocatable(v)
+			if err != nil {
+				return err
+			}
+			return in.ctx.Ids.InsertMember(obj.path+"."+target.name, cell, in.ctx.Vm)
+		}
+		return errors.Errorf("can't set attribute %s of %s", target.name, typeName(obj))
+	case *subscriptExpr:
+		obj, err := in.eval(target.obj)
+		if err != nil {
+			return err
+		}
+		index, err := in.eval(target.index)
+		if err != nil {
+			return err
+		}
+		return in.setItem(obj, index, v)
+	}
+	return errors.New("cannot assign to expression")
+}
+
+func (in *interpreter) unpack(targets []expr, v value) error {
+	elts := make([]value, 0, len(targets))
+	err := in.iterate(v, func(elt value) error {
+		elts = append(elts, elt)
+		if len(elts) > len(targets) {
+			return errors.Errorf("too many values to unpack (expected %d)", len(targets))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(elts) < len(targets) {
+		return errors.Errorf("not enough values to unpack (expected %d, got %d)", len(targets), len(elts))
+	}
+	for i, target := range targets {
+		err = in.assign(target, elts[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *interpreter) setItem(obj value, index value, v value) error {
+	switch obj := obj.(type) {
+	case memoryValue:
+		addr, ok := index.(Relocatable)
+		if !ok {
+			return errors.Errorf("memory addresses must be relocatable, got %s", typeName(index))
+		}
+		cell, err := toMaybeRelocatable(v)
+		if err != nil {
+			return err
+		}
+		return in.ctx.Vm.Segments.Memory.Insert(addr, cell)
+	case *listValue:
+		i, err := sequenceIndex(index, len(obj.elts))
+		if err != nil {
+			return err
+		}
+		obj.elts[i] = v
+		return nil
+	case *dictValue:
+		return obj.set(index, v)
+	}
+	return errors.Errorf("'%s' object does not support item assignment", typeName(obj))
+}
+
+// Calls fn with each element of an iterable value, stopping at the first error
+func (in *interpreter) iterate(iterable value, iterationFn func(value) error) error {
+	fn := func(elt value) error {
+		err := in.loopIteration()
+		if err != nil {
+			return err
+		}
+		return iterationFn(elt)
+	}
+	var elts []value
+	switch iterable := iterable.(type) {
+	case *listValue:
+		// Copy so that appending inside the loop doesn't affect it
+		elts = append([]value{}, iterable.elts...)
+	case *tupleValue:
+		elts = iterable.elts
+	case *dictValue:
+		elts = append([]value{}, iterable.keys...)
+	case string:
+		for _, c := range iterable {
+			err := fn(string(c))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case *rangeValue:
+		i := new(big.Int).Set(iterable.start)
+		for (iterable.step.Sign() > 0 && i.Cmp(iterable.stop) < 0) || (iterable.step.Sign() < 0 && i.Cmp(iterable.stop) > 0) {
+			err := fn(new(big.Int).Set(i))
+			if err != nil {
+				return err
+			}
+			i.Add(i, iterable.step)
+		}
+		return nil
+	default:
+		return errors.Errorf("'%s' object is not iterable", typeName(iterable))
+	}
+	for _, elt := range elts {
+		err := fn(elt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *interpreter) toList(iterable value) ([]value, error) {
+	elts := make([]value, 0)
+	err := in.iterate(iterable, func(elt value) error {
+		elts = append(elts, elt)
+		return nil
+	})
+	return elts, err
+}
+
+func (in *interpreter) lookup(name string) (value, error) {
+	scopeVar, err := in.ctx.Scopes.Get(name)
+	if err == nil {
+		return fromScopeVar(scopeVar), nil
+	}
+	if v, ok := in.imports[name]; ok {
+		return v, nil
+	}
+	switch name {
+	case "ids":
+		return idsValue{}, nil
+	case "memory":
+		return memoryValue{}, nil
+	case "segments":
+		return segmentsValue{}, nil
+	case "ap":
+		return in.ctx.Vm.RunContext.Ap, nil
+	case "fp":
+		return in.ctx.Vm.RunContext.Fp, nil
+	case "pc":
+		return in.ctx.Vm.RunContext.Pc, nil
+	case "PRIME":
+		return lambdaworks.Prime(), nil
+	}
+	if v, ok := in.builtins[name]; ok {
+		return v, nil
+	}
+	return nil, errors.Errorf("name '%s' is not defined", name)
+}
+
+func (in *interpreter) eval(e expr) (value, error) {
+	switch e := e.(type) {
+	case *constExpr:
+		return e.value, nil
+	case *nameExpr:
+		return in.lookup(e.name)
+	case *attrExpr:
+		obj, err := in.eval(e.obj)
+		if err != nil {
+			return nil, err
+		}
+		return in.getAttr(obj, e.name)
+	case *subscriptExpr:
+		obj, err := in.eval(e.obj)
+		if err != nil {
+			return nil, err
+		}
+		index, err := in.eval(e.index)
+		if err != nil {
+			return nil, err
+		}
+		return in.getItem(obj, index)
+	case *callExpr:
+		return in.evalCall(e)
+	case *binaryExpr:
+		left, err := in.eval(e.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := in.eval(e.right)
+		if err != nil {
+			return nil, err
+		}
+		return binaryOp(e.op, left, right)
+	case *unaryExpr:
+		operand, err := in.eval(e.operand)
+		if err != nil {
+			return nil, err
+		}
+		return unaryOp(e.op, operand)
+	case *boolExpr:
+		left, err := in.eval(e.left)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(left) == (e.op == "or") {
+			return left, nil
+		}
+		return in.eval(e.right)
+	case *compareExpr:
+		left, err := in.eval(e.operands[0])
+		if err != nil {
+			return nil, err
+		}
+		for i, op := range e.ops {
+			right, err := in.eval(e.operands[i+1])
+			if err != nil {
+				return nil, err
+			}
+			result, err := in.compare(op, left, right)
+			if err != nil {
+				return nil, err
+			}
+			if !result {
+				return false, nil
+			}
+			left = right
+		}
+		return true, nil
+	case *ifExpr:
+		cond, err := in.eval(e.cond)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(cond) {
+			return in.eval(e.body)
+		}
+		return in.eval(e.orElse)
+	case *listExpr:
+		elts, err := in.evalAll(e.elts)
+		return &listValue{elts: elts}, err
+	case *tupleExpr:
+		elts, err := in.evalAll(e.elts)
+		return &tupleValue{elts: elts}, err
+	case *dictExpr:
+		dict := &dictValue{}
+		for i := range e.keys {
+			key, err := in.eval(e.keys[i])
+			if err != nil {
+				return nil, err
+			}
+			v, err := in.eval(e.values[i])
+			if err != nil {
+				return nil, err
+			}
+			err = dict.set(key, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+	case *listCompExpr:
+		iter, err := in.eval(e.iter)
+		if err != nil {
+			return nil, err
+		}
+		result := &listValue{elts: make([]value, 0)}
+		err = in.iterate(iter, func(elt value) error {
+			err := in.assign(e.target, elt)
+			if err != nil {
+				return err
+			}
+			for _, cond := range e.conds {
+				condValue, err := in.eval(cond)
+				if err != nil {
+					return err
+				}
+				if !truthy(condValue) {
+					return nil
+				}
+			}
+			v, err := in.eval(e.elt)
+			if err != nil {
+				return err
+			}
+			result.elts = append(result.elts, v)
+			return nil
+		})
+		return result, err
+	}
+	return nil, errors.Errorf("Unsupported expression %T", e)
+}
+
+func (in *interpreter) evalAll(exprs []expr) ([]value, error) {
+	values := make([]value, 0, len(exprs))
+	for _, e := range exprs {
+		v, err := in.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func (in *interpreter) evalCall(e *callExpr) (value, error) {
+	fnValue, err := in.eval(e.fn)
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := fnValue.(*builtinFunc)
+	if !ok {
+		return nil, errors.Errorf("'%s' object is not callable", typeName(fnValue))
+	}
+	args, err := in.evalAll(e.args)
+	if err != nil {
+		return nil, err
+	}
+	kwargs := make(map[string]value, len(e.kwargs))
+	for i, name := range e.kwargNames {
+		kwargs[name], err = in.eval(e.kwargs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fn.fn(args, kwargs)
+}
+
+func (in *interpreter) getAttr(obj value, name string) (value, error) {
+	switch obj := obj.(type) {
+	case idsValue:
+		return in.getIds(name)
+	case segmentsValue:
+		if name == "add" {
+			return &builtinFunc{name: "segments.add", fn: func(args []value, kwargs map[string]value) (value, error) {
+				err := checkArgs("segments.add", args, kwargs, 0, 0)
+				if err != nil {
+					return nil, err
+				}
+				return in.ctx.Vm.Segments.AddSegment(), nil
+			}}, nil
+		}
+	case Relocatable:
+		switch name {
+		case "segment_index":
+			return big.NewInt(int64(obj.SegmentIndex)), nil
+		case "offset":
+			return new(big.Int).SetUint64(uint64(obj.Offset)), nil
+		}
+	case *structValue:
+		if name == "address_" {
+			return obj.address, nil
+		}
+		return in.getMember(obj.path + "." + name)
+	case *listValue:
+		return listMethod(obj, name)
+	case *dictValue:
+		return dictMethod(obj, name)
+	}
+	return nil, errors.Errorf("'%s' object has no attribute '%s'", typeName(obj), name)
+}
+
+// Returns true if the reference points to a struct rather than a felt or a pointer
+func isStructReference(reference *HintReference) bool {
+	valueType := reference.ValueType
+	if reference.Dereference {
+		// [cast(addr, T*)] is a T located at addr
+		valueType = strings.TrimSuffix(valueType, "*")
+	}
+	return valueType != "" && valueType != "felt" && valueType[len(valueType)-1] != '*'
+}
+
+// Returns a member of an ids struct, resolving its offset from the struct definitions of the program
+func (in *interpreter) getMember(path string) (value, error) {
+	addr, cairoType, err := in.ctx.Ids.ResolveMember(path, in.ctx.Vm)
+	if err != nil {
+		return nil, err
+	}
+	if cairoType != "felt" && !strings.HasSuffix(cairoType, "*") {
+		return &structValue{path: path, address: addr}, nil
+	}
+	cell, err := in.ctx.Vm.Segments.Memory.Get(addr)
+	if err != nil {
+		return nil, err
+	}
+	return fromMaybeRelocatable(cell), nil
+}
+
+func (in *interpreter) getIds(name string) (value, error) {
+	reference, ok := in.ctx.Ids.References[name]
+	if !ok {
+		if in.ctx.Constants != nil {
+			constant, err := in.ctx.Ids.GetConst(name, in.ctx.Constants)
+			if err == nil {
+				return constant.ToBigInt(), nil
+			}
+		}
+		return nil, ErrUnknownIdentifier(name)
+	}
+	if isStructReference(&reference) {
+		addr, err := in.ctx.Ids.GetAddr(name, in.ctx.Vm)
+		if err != nil {
+			return nil, err
+		}
+		return &structValue{path: name, address: addr}, nil
+	}
+	v, err := in.ctx.Ids.Get(name, in.ctx.Vm)
+	if err != nil {
+		return nil, err
+	}
+	return fromMaybeRelocatable(v), nil
+}
+
+func (in *interpreter) setIds(name string, v value) error {
+	cell, err := toMaybeRelocatable(v)
+	if err != nil {
+		return err
+	}
+	return in.ctx.Ids.Insert(name, cell, in.ctx.Vm)
+}
+
+func (in *interpreter) getItem(obj value, index value) (value, error) {
+	switch obj := obj.(type) {
+	case memoryValue:
+		addr, ok := index.(Relocatable)
+		if !ok {
+			return nil, errors.Errorf("memory addresses must be relocatable, got %s", typeName(index))
+		}
+		cell, err := in.ctx.Vm.Segments.Memory.Get(addr)
+		if err != nil {
+			return nil, err
+		}
+		return fromMaybeRelocatable(cell), nil
+	case *listValue:
+		i, err := sequenceIndex(index, len(obj.elts))
+		if err != nil {
+			return nil, err
+		}
+		return obj.elts[i], nil
+	case *tupleValue:
+		i, err := sequenceIndex(index, len(obj.elts))
+		if err != nil {
+			return nil, err
+		}
+		return obj.elts[i], nil
+	case string:
+		i, err := sequenceIndex(index, len(obj))
+		if err != nil {
+			return nil, err
+		}
+		return obj[i : i+1], nil
+	case *dictValue:
+		v, ok := obj.get(index)
+		if !ok {
+			return nil, errors.Errorf("KeyError: %s", repr(index))
+		}
+		return v, nil
+	}
+	return nil, errors.Errorf("'%s' object is not subscriptable", typeName(obj))
+}
+
+// Resolves a (possibly negative) index into a sequence of the given length
+func sequenceIndex(index value, length int) (int, error) {
+	n, err := expectInt(index, "indexing")
+	if err != nil {
+		return 0, err
+	}
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, big.NewInt(int64(length)))
+	}
+	if n.Sign() < 0 || n.Cmp(big.NewInt(int64(length))) >= 0 {
+		return 0, errors.New("IndexError: index out of range")
+	}
+	return int(n.Int64()), nil
+}
+
+func (in *interpreter) compare(op string, left value, right value) (bool, error) {
+	switch op {
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return !valuesEqual(left, right), nil
+	case "is":
+		return isSame(left, right), nil
+	case "is not":
+		return !isSame(left, right), nil
+	case "in", "not in":
+		found := false
+		var err error
+		switch container := right.(type) {
+		case string:
+			sub, ok := left.(string)
+			if !ok {
+				return false, errors.New("'in <string>' requires string as left operand")
+			}
+			found = strings.Contains(container, sub)
+		case *dictValue:
+			found = container.index(left) >= 0
+		default:
+			err = in.iterate(right, func(elt value) error {
+				if valuesEqual(left, elt) {
+					found = true
+				}
+				return nil
+			})
+		}
+		return found == (op == "in"), err
+	}
+	cmp, err := order(left, right)
+	if err != nil {
+		return false, errors.Errorf("'%s' not supported between instances of '%s' and '%s'", op, typeName(left), typeName(right))
+	}
+	switch op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+// Identity comparison, only meaningful for None and bools
+func isSame(left value, right value) bool {
+	switch left := left.(type) {
+	case noneType:
+		_, ok := right.(noneType)
+		return ok
+	case bool:
+		right, ok := right.(bool)
+		return ok && left == right
+	case *listValue, *dictValue:
+		return left == right
+	}
+	return valuesEqual(left, right)
+}
+
+// Orders ints, strings, relocatables of the same segment, and lists or tuples of those
+func order(left value, right value) (int, error) {
+	if leftInt, ok := toInt(left); ok {
+		if rightInt, ok := toInt(right); ok {
+			return leftInt.Cmp(rightInt), nil
+		}
+	}
+	switch left := left.(type) {
+	case string:
+		if right, ok := right.(string); ok {
+			switch {
+			case left < right:
+				return -1, nil
+			case left > right:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case Relocatable:
+		if right, ok := right.(Relocatable); ok && left.SegmentIndex == right.SegmentIndex {
+			return int(left.Offset) - int(right.Offset), nil
+		}
+	case *listValue:
+		if right, ok := right.(*listValue); ok {
+			return orderElts(left.elts, right.elts)
+		}
+	case *tupleValue:
+		if right, ok := right.(*tupleValue); ok {
+			return orderElts(left.elts, right.elts)
+		}
+	}
+	return 0, errors.New("unorderable values")
+}
+
+func orderElts(left []value, right []value) (int, error) {
+	for i := 0; i < len(left) && i < len(right); i++ {
+		cmp, err := order(left[i], right[i])
+		if err != nil || cmp != 0 {
+			return cmp, err
+		}
+	}
+	return len(left) - len(right), nil
+}
@@ -0,0 +1,209 @@
+package python_interpreter
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNewline
+	tokenIndent
+	tokenDedent
+	tokenName
+	tokenInt
+	tokenString
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	// Operator, name or decoded string literal
+	text string
+	// Value of int literals
+	value *big.Int
+	line  int
+}
+
+// Operators sorted so that longer operators are matched first
+var operators = []string{
+	"**=", "//=", "<<=", ">>=",
+	"**", "//", "<<", ">>", "<=", ">=", "==", "!=", "+=", "-=", "*=", "%=", "&=", "|=", "^=",
+	"+", "-", "*", "/", "%", "&", "|", "^", "~", "<", ">", "=", "(", ")", "[", "]", "{", "}", ",", ":", ".", ";",
+}
+
+func ErrSyntax(line int, format string, args ...any) error {
+	return errors.Errorf("Syntax error at line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// Splits python code into tokens, emitting INDENT and DEDENT tokens when the indentation of a logical line changes
+func tokenize(code string) ([]token, error) {
+	tokens := make([]token, 0)
+	indents := []int{0}
+	// Open brackets, newlines and indentation are ignored inside them
+	depth := 0
+	lines := strings.Split(strings.ReplaceAll(code, "\r\n", "\n"), "\n")
+	continuation := false
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		lineNumber := i + 1
+		pos := 0
+
+		if depth == 0 && !continuation {
+			indent := 0
+			for pos < len(line) && (line[pos] == ' ' || line[pos] == '\t') {
+				if line[pos] == '\t' {
+					indent += 8 - indent%8
+				} else {
+					indent++
+				}
+				pos++
+			}
+			// Blank and comment-only lines don't affect indentation
+			if pos == len(line) || line[pos] == '#' {
+				continue
+			}
+			if indent > indents[len(indents)-1] {
+				indents = append(indents, indent)
+				tokens = append(tokens, token{kind: tokenIndent, line: lineNumber})
+			}
+			for indent < indents[len(indents)-1] {
+				indents = indents[:len(indents)-1]
+				tokens = append(tokens, token{kind: tokenDedent, line: lineNumber})
+			}
+			if indent != indents[len(indents)-1] {
+				return nil, ErrSyntax(lineNumber, "unindent does not match any outer indentation level")
+			}
+		}
+		continuation = false
+
+		for pos < len(line) {
+			c := line[pos]
+			switch {
+			case c == ' ' || c == '\t':
+				pos++
+			case c == '#':
+				pos = len(line)
+			case c == '\\' && pos == len(line)-1:
+				continuation = true
+				pos++
+			case isNameStart(c):
+				start := pos
+				for pos < len(line) && isNameChar(line[pos]) {
+					pos++
+				}
+				tokens = append(tokens, token{kind: tokenName, text: line[start:pos], line: lineNumber})
+			case isDigit(c):
+				start := pos
+				for pos < len(line) && isNameChar(line[pos]) {
+					pos++
+				}
+				value, ok := new(big.Int).SetString(line[start:pos], 0)
+				if !ok {
+					return nil, ErrSyntax(lineNumber, "invalid number literal %s", line[start:pos])
+				}
+				tokens = append(tokens, token{kind: tokenInt, value: value, line: lineNumber})
+			case c == '\'' || c == '"':
+				text, length, err := readString(line[pos:], lineNumber)
+				if err != nil {
+					return nil, err
+				}
+				tokens = append(tokens, token{kind: tokenString, text: text, line: lineNumber})
+				pos += length
+			default:
+				op := ""
+				for _, candidate := range operators {
+					if strings.HasPrefix(line[pos:], candidate) {
+						op = candidate
+						break
+					}
+				}
+				if op == "" {
+					return nil, ErrSyntax(lineNumber, "unexpected character %q", c)
+				}
+				switch op {
+				case "(", "[", "{":
+					depth++
+				case ")", "]", "}":
+					if depth == 0 {
+						return nil, ErrSyntax(lineNumber, "unmatched %s", op)
+					}
+					depth--
+				}
+				tokens = append(tokens, token{kind: tokenOp, text: op, line: lineNumber})
+				pos += len(op)
+			}
+		}
+
+		if depth == 0 && !continuation && len(tokens) > 0 && tokens[len(tokens)-1].kind != tokenNewline {
+			tokens = append(tokens, token{kind: tokenNewline, line: lineNumber})
+		}
+	}
+	if depth != 0 {
+		return nil, ErrSyntax(len(lines), "unexpected end of code inside brackets")
+	}
+	for len(indents) > 1 {
+		indents = indents[:len(indents)-1]
+		tokens = append(tokens, token{kind: tokenDedent, line: len(lines)})
+	}
+	return append(tokens, token{kind: tokenEOF, line: len(lines)}), nil
+}
+
+// Reads a single line string literal at the start of s. Returns its decoded value and its length in s
+func readString(s string, line int) (string, int, error) {
+	quote := s[0]
+	var builder strings.Builder
+	for pos := 1; pos < len(s); pos++ {
+		c := s[pos]
+		if c == quote {
+			return builder.String(), pos + 1, nil
+		}
+		if c != '\\' {
+			builder.WriteByte(c)
+			continue
+		}
+		pos++
+		if pos == len(s) {
+			break
+		}
+		switch s[pos] {
+		case 'n':
+			builder.WriteByte('\n')
+		case 't':
+			builder.WriteByte('\t')
+		case '0':
+			builder.WriteByte(0)
+		case 'x':
+			if pos+2 >= len(s) {
+				return "", 0, ErrSyntax(line, "truncated \\x escape")
+			}
+			value, ok := new(big.Int).SetString(s[pos+1:pos+3], 16)
+			if !ok {
+				return "", 0, ErrSyntax(line, "invalid \\x escape")
+			}
+			builder.WriteByte(byte(value.Uint64()))
+			pos += 2
+		default:
+			builder.WriteByte(s[pos])
+		}
+	}
+	return "", 0, ErrSyntax(line, "unterminated string literal")
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,233 @@
+package python_interpreter
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+func errUnsupportedOperands(op string, left value, right value) error {
+	return errors.Errorf("unsupported operand type(s) for %s: '%s' and '%s'", op, typeName(left), typeName(right))
+}
+
+func binaryOp(op string, left value, right value) (value, error) {
+	leftInt, leftIsInt := toInt(left)
+	rightInt, rightIsInt := toInt(right)
+	if leftIsInt && rightIsInt {
+		return intOp(op, leftInt, rightInt)
+	}
+
+	switch left := left.(type) {
+	case Relocatable:
+		return relocatableOp(op, left, right)
+	case string:
+		switch right := right.(type) {
+		case string:
+			if op == "+" {
+				return left + right, nil
+			}
+		case *big.Int:
+			if op == "*" {
+				return repeatString(left, right)
+			}
+		}
+	case *listValue:
+		switch right := right.(type) {
+		case *listValue:
+			if op == "+" {
+				return &listValue{elts: append(append([]value{}, left.elts...), right.elts...)}, nil
+			}
+		case *big.Int:
+			if op == "*" {
+				elts, err := repeatElts(left.elts, right)
+				return &listValue{elts: elts}, err
+			}
+		}
+	case *tupleValue:
+		switch right := right.(type) {
+		case *tupleValue:
+			if op == "+" {
+				return &tupleValue{elts: append(append([]value{}, left.elts...), right.elts...)}, nil
+			}
+		case *big.Int:
+			if op == "*" {
+				elts, err := repeatElts(left.elts, right)
+				return &tupleValue{elts: elts}, err
+			}
+		}
+	case *big.Int:
+		// int + relocatable
+		if rel, ok := right.(Relocatable); ok && op == "+" {
+			return relocatableOp(op, rel, left)
+		}
+	}
+	return nil, errUnsupportedOperands(op, left, right)
+}
+
+// Maximum length of a string or list built by repetition
+const maxRepeat = 1 << 20
+
+// Maximum size in bits of the result of ** without a modulus
+const maxPowBits = 1 << 20
+
+func repeatElts(elts []value, times *big.Int) ([]value, error) {
+	if times.Sign() <= 0 {
+		return make([]value, 0), nil
+	}
+	if !times.IsInt64() || times.Int64()*int64(len(elts)) > maxRepeat {
+		return nil, errors.New("repeated sequence is too long")
+	}
+	result := make([]value, 0, int(times.Int64())*len(elts))
+	for i := int64(0); i < times.Int64(); i++ {
+		result = append(result, elts...)
+	}
+	return result, nil
+}
+
+func repeatString(s string, times *big.Int) (value, error) {
+	if times.Sign() <= 0 {
+		return "", nil
+	}
+	if !times.IsInt64() || times.Int64()*int64(len(s)) > maxRepeat {
+		return nil, errors.New("repeated sequence is too long")
+	}
+	return strings.Repeat(s, int(times.Int64())), nil
+}
+
+func intOp(op string, left *big.Int, right *big.Int) (value, error) {
+	switch op {
+	case "+":
+		return new(big.Int).Add(left, right), nil
+	case "-":
+		return new(big.Int).Sub(left, right), nil
+	case "*":
+		return new(big.Int).Mul(left, right), nil
+	case "//", "%":
+		if right.Sign() == 0 {
+			return nil, errors.New("ZeroDivisionError: integer division or modulo by zero")
+		}
+		quotient, remainder := floorDivMod(left, right)
+		if op == "//" {
+			return quotient, nil
+		}
+		return remainder, nil
+	case "/":
+		return nil, errors.New("true division is not supported, use // or div_mod")
+	case "**":
+		return intPow(left, right, nil)
+	case "<<", ">>":
+		if right.Sign() < 0 {
+			return nil, errors.New("ValueError: negative shift count")
+		}
+		if !right.IsUint64() || (op == "<<" && right.Uint64() > 1<<16) {
+			return nil, errors.New("shift count is too large")
+		}
+		if op == "<<" {
+			return new(big.Int).Lsh(left, uint(right.Uint64())), nil
+		}
+		// big.Int.Rsh rounds towards negative infinity, like python
+		return new(big.Int).Rsh(left, uint(right.Uint64())), nil
+	case "&":
+		return new(big.Int).And(left, right), nil
+	case "|":
+		return new(big.Int).Or(left, right), nil
+	case "^":
+		return new(big.Int).Xor(left, right), nil
+	}
+	return nil, errUnsupportedOperands(op, left, right)
+}
+
+// Division rounding towards negative infinity, with a remainder with the sign of the divisor, like python's divmod
+func floorDivMod(left *big.Int, right *big.Int) (*big.Int, *big.Int) {
+	quotient, remainder := new(big.Int).QuoRem(left, right, new(big.Int))
+	if remainder.Sign() != 0 && remainder.Sign() != right.Sign() {
+		quotient.Sub(quotient, big.NewInt(1))
+		remainder.Add(remainder, right)
+	}
+	return quotient, remainder
+}
+
+// Computes base ** exponent, or base ** exponent % modulus if modulus is not nil.
+// Negative exponents are only supported with a modulus, using the modular inverse of the base
+func intPow(base *big.Int, exponent *big.Int, modulus *big.Int) (value, error) {
+	if modulus == nil {
+		if exponent.Sign() < 0 {
+			return nil, errors.New("negative exponents are only supported with a modulus")
+		}
+		// Bases 0, 1 and -1 can be raised to any power, other results must stay reasonably small
+		if base.BitLen() > 1 && (!exponent.IsInt64() || int64(base.BitLen())*exponent.Int64() > maxPowBits) {
+			return nil, errors.New("exponent is too large")
+		}
+		return new(big.Int).Exp(base, exponent, nil), nil
+	}
+	if modulus.Sign() == 0 {
+		return nil, errors.New("ValueError: pow() 3rd argument cannot be 0")
+	}
+	absModulus := new(big.Int).Abs(modulus)
+	reducedBase := new(big.Int).Mod(base, absModulus)
+	if exponent.Sign() < 0 {
+		inverse := new(big.Int).ModInverse(reducedBase, absModulus)
+		if inverse == nil {
+			return nil, errors.New("ValueError: base is not invertible for the given modulus")
+		}
+		reducedBase = inverse
+		exponent = new(big.Int).Neg(exponent)
+	}
+	result := new(big.Int).Exp(reducedBase, exponent, absModulus)
+	// The result has the sign of the modulus
+	if modulus.Sign() < 0 && result.Sign() != 0 {
+		result.Add(result, modulus)
+	}
+	return result, nil
+}
+
+// Pointer arithmetic: relocatable + int, relocatable - int and relocatable - relocatable
+func relocatableOp(op string, left Relocatable, right value) (value, error) {
+	if n, ok := toInt(right); ok {
+		if op == "-" {
+			n = new(big.Int).Neg(n)
+		} else if op != "+" {
+			return nil, errUnsupportedOperands(op, left, right)
+		}
+		offset := new(big.Int).Add(new(big.Int).SetUint64(uint64(left.Offset)), n)
+		if offset.Sign() < 0 {
+			return nil, errors.Errorf("offset of %s would be negative", repr(left))
+		}
+		if !offset.IsUint64() {
+			// Offsets are felts in cairo-lang, so adding a large felt is a subtraction
+			offset.Mod(offset, lambdaworks.Prime())
+			if !offset.IsUint64() {
+				return nil, errors.Errorf("offset of %s would overflow", repr(left))
+			}
+		}
+		return NewRelocatable(left.SegmentIndex, uint(offset.Uint64())), nil
+	}
+	rightRel, ok := right.(Relocatable)
+	if ok && op == "-" {
+		if left.SegmentIndex != rightRel.SegmentIndex {
+			return nil, errors.Errorf("can't subtract relocatables of different segments: %s and %s", repr(left), repr(rightRel))
+		}
+		return new(big.Int).Sub(new(big.Int).SetUint64(uint64(left.Offset)), new(big.Int).SetUint64(uint64(rightRel.Offset))), nil
+	}
+	return nil, errUnsupportedOperands(op, left, right)
+}
+
+func unaryOp(op string, operand value) (value, error) {
+	if op == "not" {
+		return !truthy(operand), nil
+	}
+	n, ok := toInt(operand)
+	if !ok {
+		return nil, errors.Errorf("bad operand type for unary %s: '%s'", op, typeName(operand))
+	}
+	switch op {
+	case "-":
+		return new(big.Int).Neg(n), nil
+	case "+":
+		return new(big.Int).Set(n), nil
+	}
+	return new(big.Int).Not(n), nil
+}
@@ -0,0 +1,787 @@
+package python_interpreter
+
+// A parsed python snippet, ready to be executed
+type Program struct {
+	body []stmt
+}
+
+type pyParser struct {
+	tokens []token
+	pos    int
+}
+
+// Parses the restricted python subset supported by the interpreter:
+// assignments (including tuple unpacking and augmented assignments), if / elif / else, for and while loops,
+// assert, pass, break, continue and `from module import name` statements, and expressions made of
+// int, string, bool and None literals, lists, tuples, dicts, list comprehensions, arithmetic, bitwise,
+// comparison and boolean operators, conditional expressions, attributes, subscripts and function calls
+func Parse(code string) (*Program, error) {
+	tokens, err := tokenize(code)
+	if err != nil {
+		return nil, err
+	}
+	p := &pyParser{tokens: tokens}
+	body := make([]stmt, 0)
+	for p.peek().kind != tokenEOF {
+		stmts, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, stmts...)
+	}
+	return &Program{body: body}, nil
+}
+
+func (p *pyParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *pyParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *pyParser) isOp(op string) bool {
+	tok := p.peek()
+	return tok.kind == tokenOp && tok.text == op
+}
+
+func (p *pyParser) isKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenName && tok.text == keyword
+}
+
+// Consumes the operator if it is the next token
+func (p *pyParser) acceptOp(op string) bool {
+	if p.isOp(op) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *pyParser) acceptKeyword(keyword string) bool {
+	if p.isKeyword(keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *pyParser) expectOp(op string) error {
+	if !p.acceptOp(op) {
+		return p.unexpected("expected '" + op + "'")
+	}
+	return nil
+}
+
+func (p *pyParser) expectKeyword(keyword string) error {
+	if !p.acceptKeyword(keyword) {
+		return p.unexpected("expected '" + keyword + "'")
+	}
+	return nil
+}
+
+func (p *pyParser) expectName() (string, error) {
+	tok := p.peek()
+	if tok.kind != tokenName || keywords[tok.text] {
+		return "", p.unexpected("expected a name")
+	}
+	p.pos++
+	return tok.text, nil
+}
+
+func (p *pyParser) unexpected(reason string) error {
+	tok := p.peek()
+	var found string
+	switch tok.kind {
+	case tokenEOF:
+		found = "end of code"
+	case tokenNewline:
+		found = "end of line"
+	case tokenIndent:
+		found = "indent"
+	case tokenDedent:
+		found = "dedent"
+	case tokenInt:
+		found = tok.value.String()
+	case tokenString:
+		found = "string literal"
+	default:
+		found = "'" + tok.text + "'"
+	}
+	return ErrSyntax(tok.line, "%s, found %s", reason, found)
+}
+
+var keywords = map[string]bool{
+	"if": true, "elif": true, "else": true, "for": true, "in": true, "while": true, "break": true,
+	"continue": true, "pass": true, "assert": true, "and": true, "or": true, "not": true, "is": true,
+	"None": true, "True": true, "False": true, "from": true, "import": true, "as": true,
+	"def": true, "return": true, "lambda": true, "class": true, "with": true, "try": true,
+	"except": true, "raise": true, "global": true, "del": true, "yield": true,
+}
+
+// Parses a line of simple statements separated by ';', or a compound statement
+func (p *pyParser) parseStatement() ([]stmt, error) {
+	line := p.peek().line
+	switch {
+	case p.acceptKeyword("if"):
+		s, err := p.parseIf(line)
+		return []stmt{s}, err
+	case p.acceptKeyword("while"):
+		cond, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		body, err := p.parseBlock()
+		return []stmt{&whileStmt{line: line, cond: cond, body: body}}, err
+	case p.acceptKeyword("for"):
+		target, err := p.parseTargetList()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expectKeyword("in")
+		if err != nil {
+			return nil, err
+		}
+		iter, err := p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+		body, err := p.parseBlock()
+		return []stmt{&forStmt{line: line, target: target, iter: iter, body: body}}, err
+	}
+
+	stmts := make([]stmt, 0, 1)
+	for {
+		s, err := p.parseSimpleStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, s)
+		if !p.acceptOp(";") || p.peek().kind == tokenNewline {
+			break
+		}
+	}
+	if p.peek().kind == tokenNewline {
+		p.next()
+	} else if p.peek().kind != tokenEOF {
+		return nil, p.unexpected("expected end of line")
+	}
+	return stmts, nil
+}
+
+// Parses the rest of an if statement, after the if / elif keyword
+func (p *pyParser) parseIf(line int) (stmt, error) {
+	cond, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.parseBlock()
+	if err != nil {
+		return nil, err
+	}
+	s := &ifStmt{line: line, cond: cond, body: body}
+	elifLine := p.peek().line
+	if p.acceptKeyword("elif") {
+		elif, err := p.parseIf(elifLine)
+		if err != nil {
+			return nil, err
+		}
+		s.orElse = []stmt{elif}
+	} else if p.acceptKeyword("else") {
+		s.orElse, err = p.parseBlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Parses ':' followed by either simple statements on the same line or an indented block
+func (p *pyParser) parseBlock() ([]stmt, error) {
+	err := p.expectOp(":")
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenNewline {
+		return p.parseStatement()
+	}
+	p.next()
+	if p.peek().kind != tokenIndent {
+		return nil, p.unexpected("expected an indented block")
+	}
+	p.next()
+	body := make([]stmt, 0)
+	for p.peek().kind != tokenDedent && p.peek().kind != tokenEOF {
+		stmts, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, stmts...)
+	}
+	p.next()
+	return body, nil
+}
+
+func (p *pyParser) parseSimpleStatement() (stmt, error) {
+	line := p.peek().line
+	switch {
+	case p.acceptKeyword("pass"):
+		return &passStmt{line: line}, nil
+	case p.acceptKeyword("break"):
+		return &breakStmt{line: line}, nil
+	case p.acceptKeyword("continue"):
+		return &continueStmt{line: line}, nil
+	case p.acceptKeyword("assert"):
+		cond, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		s := &assertStmt{line: line, cond: cond}
+		if p.acceptOp(",") {
+			s.msg, err = p.parseExpr()
+		}
+		return s, err
+	case p.acceptKeyword("from"):
+		return p.parseImport(line)
+	case p.isKeyword("import"):
+		return nil, p.unexpected("only 'from module import name' imports are supported")
+	}
+
+	value, err := p.parseExprList()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	if tok.kind == tokenOp && len(tok.text) >= 2 && tok.text[len(tok.text)-1] == '=' && tok.text != "==" && tok.text != "!=" && tok.text != "<=" && tok.text != ">=" {
+		p.next()
+		err = checkTarget(value, line, false)
+		if err != nil {
+			return nil, err
+		}
+		operand, err := p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+		return &augAssignStmt{line: line, target: value, op: tok.text[:len(tok.text)-1], value: operand}, nil
+	}
+	if !p.isOp("=") {
+		return &exprStmt{line: line, expr: value}, nil
+	}
+	targets := make([]expr, 0, 1)
+	for p.acceptOp("=") {
+		err = checkTarget(value, line, true)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, value)
+		value, err = p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &assignStmt{line: line, targets: targets, value: value}, nil
+}
+
+// Checks that an expression can be assigned to
+func checkTarget(target expr, line int, allowTuples bool) error {
+	switch target := target.(type) {
+	case *nameExpr, *attrExpr, *subscriptExpr:
+		return nil
+	case *tupleExpr:
+		if allowTuples {
+			for _, elt := range target.elts {
+				err := checkTarget(elt, line, true)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case *listExpr:
+		if allowTuples {
+			return checkTarget(&tupleExpr{elts: target.elts}, line, true)
+		}
+	}
+	return ErrSyntax(line, "cannot assign to expression")
+}
+
+func (p *pyParser) parseImport(line int) (stmt, error) {
+	module, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOp(".") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		module += "." + name
+	}
+	err = p.expectKeyword("import")
+	if err != nil {
+		return nil, err
+	}
+	parenthesized := p.acceptOp("(")
+	s := &importStmt{line: line, module: module}
+	for {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		alias := name
+		if p.acceptKeyword("as") {
+			alias, err = p.expectName()
+			if err != nil {
+				return nil, err
+			}
+		}
+		s.names = append(s.names, name)
+		s.aliases = append(s.aliases, alias)
+		if !p.acceptOp(",") || (parenthesized && p.isOp(")")) {
+			break
+		}
+	}
+	if parenthesized {
+		err = p.expectOp(")")
+	}
+	return s, err
+}
+
+// Parses the target of a for loop, which can't contain comparisons as they would swallow the 'in'
+func (p *pyParser) parseTargetList() (expr, error) {
+	elts := make([]expr, 0, 1)
+	for {
+		elt, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		err = checkTarget(elt, p.peek().line, true)
+		if err != nil {
+			return nil, err
+		}
+		elts = append(elts, elt)
+		if !p.acceptOp(",") || p.isKeyword("in") {
+			break
+		}
+	}
+	if len(elts) == 1 {
+		return elts[0], nil
+	}
+	return &tupleExpr{elts: elts}, nil
+}
+
+// Parses comma separated expressions, returning a tuple if there is more than one (or a trailing comma)
+func (p *pyParser) parseExprList() (expr, error) {
+	first, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOp(",") {
+		return first, nil
+	}
+	elts := []expr{first}
+	for p.acceptOp(",") {
+		if p.atExprListEnd() {
+			break
+		}
+		elt, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		elts = append(elts, elt)
+	}
+	return &tupleExpr{elts: elts}, nil
+}
+
+func (p *pyParser) atExprListEnd() bool {
+	tok := p.peek()
+	if tok.kind == tokenNewline || tok.kind == tokenEOF {
+		return true
+	}
+	return tok.kind == tokenOp && (tok.text == "=" || tok.text == ")" || tok.text == ";" || tok.text == "]")
+}
+
+// expr: or_test ['if' or_test 'else' expr]
+func (p *pyParser) parseExpr() (expr, error) {
+	body, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.acceptKeyword("if") {
+		return body, nil
+	}
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	err = p.expectKeyword("else")
+	if err != nil {
+		return nil, err
+	}
+	orElse, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return &ifExpr{cond: cond, body: body, orElse: orElse}, nil
+}
+
+func (p *pyParser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &boolExpr{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *pyParser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &boolExpr{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *pyParser) parseNot() (expr, error) {
+	if p.acceptKeyword("not") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "not", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *pyParser) parseComparison() (expr, error) {
+	first, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	comparison := &compareExpr{operands: []expr{first}}
+	for {
+		op := p.comparisonOp()
+		if op == "" {
+			break
+		}
+		operand, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		comparison.ops = append(comparison.ops, op)
+		comparison.operands = append(comparison.operands, operand)
+	}
+	if len(comparison.ops) == 0 {
+		return first, nil
+	}
+	return comparison, nil
+}
+
+// Consumes and returns the next comparison operator, or returns "" if there is none
+func (p *pyParser) comparisonOp() string {
+	tok := p.peek()
+	if tok.kind == tokenOp {
+		switch tok.text {
+		case "<", ">", "==", "!=", "<=", ">=":
+			p.next()
+			return tok.text
+		}
+		return ""
+	}
+	switch {
+	case p.acceptKeyword("in"):
+		return "in"
+	case p.isKeyword("not") && p.tokens[p.pos+1].kind == tokenName && p.tokens[p.pos+1].text == "in":
+		p.pos += 2
+		return "not in"
+	case p.acceptKeyword("is"):
+		if p.acceptKeyword("not") {
+			return "is not"
+		}
+		return "is"
+	}
+	return ""
+}
+
+// Binary operators by precedence level, from lowest to highest
+var binaryPrecedence = [][]string{
+	{"|"},
+	{"^"},
+	{"&"},
+	{"<<", ">>"},
+	{"+", "-"},
+	{"*", "/", "//", "%"},
+}
+
+// Parses left associative binary operators with at least the given precedence level
+func (p *pyParser) parseBinary(level int) (expr, error) {
+	if level == len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := ""
+		for _, candidate := range binaryPrecedence[level] {
+			if p.isOp(candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *pyParser) parseUnary() (expr, error) {
+	for _, op := range []string{"-", "+", "~"} {
+		if p.acceptOp(op) {
+			operand, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			return &unaryExpr{op: op, operand: operand}, nil
+		}
+	}
+	return p.parsePower()
+}
+
+// power: primary ['**' unary]
+func (p *pyParser) parsePower() (expr, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.acceptOp("**") {
+		return base, nil
+	}
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &binaryExpr{op: "**", left: base, right: exponent}, nil
+}
+
+// Parses an atom followed by any number of attribute accesses, subscripts and calls
+func (p *pyParser) parsePrimary() (expr, error) {
+	e, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.acceptOp("."):
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			e = &attrExpr{obj: e, name: name}
+		case p.acceptOp("["):
+			index, err := p.parseExprList()
+			if err != nil {
+				return nil, err
+			}
+			err = p.expectOp("]")
+			if err != nil {
+				return nil, err
+			}
+			e = &subscriptExpr{obj: e, index: index}
+		case p.acceptOp("("):
+			e, err = p.parseCallArgs(e)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return e, nil
+		}
+	}
+}
+
+func (p *pyParser) parseCallArgs(fn expr) (expr, error) {
+	call := &callExpr{fn: fn}
+	for !p.acceptOp(")") {
+		tok := p.peek()
+		if tok.kind == tokenName && p.tokens[p.pos+1].kind == tokenOp && p.tokens[p.pos+1].text == "=" {
+			p.pos += 2
+			value, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.kwargNames = append(call.kwargNames, tok.text)
+			call.kwargs = append(call.kwargs, value)
+		} else {
+			if len(call.kwargs) > 0 {
+				return nil, ErrSyntax(tok.line, "positional argument follows keyword argument")
+			}
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+		}
+		if !p.acceptOp(",") {
+			err := p.expectOp(")")
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return call, nil
+}
+
+func (p *pyParser) parseAtom() (expr, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokenInt:
+		p.next()
+		return &constExpr{value: tok.value}, nil
+	case tokenString:
+		p.next()
+		value := tok.text
+		// Adjacent string literals are concatenated
+		for p.peek().kind == tokenString {
+			value += p.next().text
+		}
+		return &constExpr{value: value}, nil
+	case tokenName:
+		switch tok.text {
+		case "True":
+			p.next()
+			return &constExpr{value: true}, nil
+		case "False":
+			p.next()
+			return &constExpr{value: false}, nil
+		case "None":
+			p.next()
+			return &constExpr{value: none}, nil
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		return &nameExpr{name: name}, nil
+	case tokenOp:
+		switch tok.text {
+		case "(":
+			p.next()
+			if p.acceptOp(")") {
+				return &tupleExpr{}, nil
+			}
+			e, err := p.parseExprList()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expectOp(")")
+		case "[":
+			p.next()
+			return p.parseList()
+		case "{":
+			p.next()
+			return p.parseDict()
+		}
+	}
+	return nil, p.unexpected("expected an expression")
+}
+
+// Parses a list literal or a list comprehension, after the opening bracket
+func (p *pyParser) parseList() (expr, error) {
+	if p.acceptOp("]") {
+		return &listExpr{}, nil
+	}
+	first, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("for") {
+		comp := &listCompExpr{elt: first}
+		comp.target, err = p.parseTargetList()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expectKeyword("in")
+		if err != nil {
+			return nil, err
+		}
+		comp.iter, err = p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		for p.acceptKeyword("if") {
+			cond, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			comp.conds = append(comp.conds, cond)
+		}
+		return comp, p.expectOp("]")
+	}
+	list := &listExpr{elts: []expr{first}}
+	for p.acceptOp(",") {
+		if p.isOp("]") {
+			break
+		}
+		elt, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list.elts = append(list.elts, elt)
+	}
+	return list, p.expectOp("]")
+}
+
+// Parses a dict literal, after the opening brace
+func (p *pyParser) parseDict() (expr, error) {
+	dict := &dictExpr{}
+	for !p.acceptOp("}") {
+		key, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expectOp(":")
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		dict.keys = append(dict.keys, key)
+		dict.values = append(dict.values, value)
+		if !p.acceptOp(",") {
+			err = p.expectOp("}")
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return dict, nil
+}
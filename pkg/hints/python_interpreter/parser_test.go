@@ -0,0 +1,57 @@
+package python_interpreter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/python_interpreter"
+)
+
+func TestParseValidCode(t *testing.T) {
+	codes := []string{
+		"memory[ap] = to_felt_or_relocatable(ids.x + 1)",
+		"a = b = 1; c, d = (2, 3)",
+		"x = [i * 2 for i in range(10) if i % 2 == 0 if i > 2]",
+		"if a:\n    pass\nelif b:\n    x = 1\nelse:\n    x = 2",
+		"for i, v in enumerate(l):\n    if v: break\n    continue",
+		"while x < 10:\n    x += 1  # increment\n\n    y <<= 2",
+		"assert a not in b and c is not None, f",
+		"from starkware.python.math_utils import div_mod, isqrt as sqrt",
+		"from starkware.python.math_utils import (\n    div_mod,\n    safe_div,\n)",
+		"x = {'a': 1, 2: [3, 4],}\ny = (1,)\nz = ()",
+		"x = -2 ** -2 if not a else ~b\ny = 1 < 2 <= 3",
+		"value = \\\n    1",
+		"print(x, end='')",
+	}
+	for _, code := range codes {
+		_, err := python_interpreter.Parse(code)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %s", code, err)
+		}
+	}
+}
+
+func TestParseInvalidCode(t *testing.T) {
+	codes := map[string]string{
+		"x = (1, 2":                  "Syntax error at line 1",
+		"if x:\nx = 1":               "Syntax error at line 2: expected an indented block",
+		"if x:\n    a = 1\n  b = 2":  "Syntax error at line 3: unindent does not match any outer indentation level",
+		"1 = x":                      "Syntax error at line 1: cannot assign to expression",
+		"import os":                  "Syntax error at line 1: only 'from module import name' imports are supported",
+		"def f():\n    pass":         "Syntax error at line 1",
+		"x = 'abc":                   "Syntax error at line 1: unterminated string literal",
+		"x = 1 $ 2":                  "Syntax error at line 1: unexpected character '$'",
+		"f(a=1, 2)":                  "Syntax error at line 1: positional argument follows keyword argument",
+		"x = 1\ny = lambda: 1":       "Syntax error at line 2",
+		"x = 0xZZ":                   "Syntax error at line 1: invalid number literal 0xZZ",
+		"x = [1, 2\n, 3]\ny = (a b)": "Syntax error at line 3",
+	}
+	for code, expectedErr := range codes {
+		_, err := python_interpreter.Parse(code)
+		if err == nil {
+			t.Errorf("Parsing %q should have failed", code)
+		} else if !strings.HasPrefix(err.Error(), expectedErr) {
+			t.Errorf("Wrong error parsing %q. Expected prefix %q, got %q", code, expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package python_interpreter
+
+import (
+	"context"
+	"os"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
+)
+
+// Hint processor that runs the hints implemented by CairoVmHintProcessor natively,
+// and interprets every other hint as python code, without needing a python runtime.
+// Only a restricted subset of python is supported, see Parse
+type PythonHintProcessor struct {
+	hints.CairoVmHintProcessor
+	// Maximum amount of loop iterations of each hint, see Context. Default: DefaultMaxLoopIterations
+	MaxLoopIterations uint
+}
+
+// Hint data of the hints that are not implemented natively
+type pythonHintData struct {
+	hints.HintData
+	// Nil if the hint couldn't be parsed
+	program  *Program
+	parseErr error
+}
+
+// The hints that aren't implemented natively are compiled to their parsed python code, which is only read when executed
+func (p *PythonHintProcessor) HintDataCacheKey() string {
+	return "python_interpreter"
+}
+
+func (p *PythonHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	return p.CompileHintWithIdentifiers(hintParams, referenceManager, nil)
+}
+
+func (p *PythonHintProcessor) CompileHintWithIdentifiers(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager, identifiers map[string]vm.Identifier) (any, error) {
+	hintData, err := p.CairoVmHintProcessor.CompileHintWithIdentifiers(hintParams, referenceManager, identifiers)
+	if err != nil {
+		return nil, err
+	}
+	data := hintData.(hints.HintData)
+	if p.IsHintSupported(data.Code) {
+		return data, nil
+	}
+	// Parse errors are reported when the hint is executed, as unknown hints are
+	program, err := Parse(data.Code)
+	return pythonHintData{HintData: data, program: program, parseErr: err}, nil
+}
+
+func (p *PythonHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	return p.ExecuteHintWithContext(context.Background(), vm, hintData, constants, execScopes)
+}
+
+// Same as ExecuteHint, but stops the loops of python hints once ctx is done
+func (p *PythonHintProcessor) ExecuteHintWithContext(ctx context.Context, vm *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	var data pythonHintData
+	switch d := (*hintData).(type) {
+	case pythonHintData:
+		data = d
+	case hints.HintData:
+		if p.IsHintSupported(d.Code) {
+			return p.CairoVmHintProcessor.ExecuteHint(vm, hintData, constants, execScopes)
+		}
+		program, err := Parse(d.Code)
+		data = pythonHintData{HintData: d, program: program, parseErr: err}
+	default:
+		return errors.New("Wrong Hint Data")
+	}
+
+	if data.parseErr != nil {
+		if p.SkipUnknownHints {
+			// Let the native processor warn about it and skip it
+			var nativeData any = data.HintData
+			return p.CairoVmHintProcessor.ExecuteHint(vm, &nativeData, constants, execScopes)
+		}
+		return errors.Wrapf(data.parseErr, "Unsupported python hint: %s", data.Code)
+	}
+	output := p.DebugOutput
+	if output == nil {
+		output = os.Stdout
+	}
+	return data.program.Execute(&Context{
+		Ids:               data.Ids,
+		Vm:                vm,
+		Constants:         constants,
+		Scopes:            execScopes,
+		Output:            output,
+		Cancellation:      ctx,
+		MaxLoopIterations: p.MaxLoopIterations,
+	})
+}
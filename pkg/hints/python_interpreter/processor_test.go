@@ -0,0 +1,318 @@
+package python_interpreter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/python_interpreter"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func runPythonHint(code string, vm *VirtualMachine, ids IdsManager, scopes *types.ExecutionScopes) (string, error) {
+	hintData := any(HintData{
+		Ids:  ids,
+		Code: code,
+	})
+	var output bytes.Buffer
+	hintProcessor := python_interpreter.PythonHintProcessor{CairoVmHintProcessor: CairoVmHintProcessor{DebugOutput: &output}}
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	return output.String(), err
+}
+
+func newTestVm() *VirtualMachine {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Ap = NewRelocatable(1, 5)
+	vm.RunContext.Fp = NewRelocatable(1, 0)
+	return vm
+}
+
+func TestPythonHintWriteMemoryAtAp(t *testing.T) {
+	vm := newTestVm()
+	ids := SetupIdsForTest(map[string][]*MaybeRelocatable{
+		"x": {NewMaybeRelocatableFelt(FeltFromUint64(41))},
+	}, vm)
+	_, err := runPythonHint("memory[ap] = to_felt_or_relocatable(ids.x + 1)", vm, ids, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	value, err := vm.Segments.Memory.GetFelt(vm.RunContext.Ap)
+	if err != nil || value != FeltFromUint64(42) {
+		t.Errorf("Wrong value at ap: %v, %v", value, err)
+	}
+}
+
+func TestPythonHintWriteIds(t *testing.T) {
+	vm := newTestVm()
+	ids := SetupIdsForTest(map[string][]*MaybeRelocatable{
+		"a":   {NewMaybeRelocatableFelt(FeltFromUint64(3))},
+		"b":   {NewMaybeRelocatableFelt(FeltFromUint64(10))},
+		"res": {nil},
+		"neg": {nil},
+	}, vm)
+	_, err := runPythonHint("ids.res = 1 if ids.a < ids.b else 0\nids.neg = -ids.a", vm, ids, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	res, err := ids.GetFelt("res", vm)
+	if err != nil || res != FeltOne() {
+		t.Errorf("Wrong value for ids.res: %v, %v", res, err)
+	}
+	neg, err := ids.GetFelt("neg", vm)
+	if err != nil || neg != FeltZero().Sub(FeltFromUint64(3)) {
+		t.Errorf("Wrong value for ids.neg: %v, %v", neg, err)
+	}
+}
+
+func TestPythonHintArithmetic(t *testing.T) {
+	code := `
+a = 7 // -2
+b = -7 % 3
+c = pow(3, -1, 7)
+d = 2 ** 10 - (1 << 3) | 1
+e = divmod(-7, 2)
+f = [x * x for x in range(5) if x % 2 == 1]
+g = sum(f) + max(1, 5, 3) + min([4, 2])
+h = as_int(PRIME - 1, PRIME)
+i = div_mod(2, 3, 5)
+`
+	scopes := types.NewExecutionScopes()
+	_, err := runPythonHint(code, newTestVm(), IdsManager{}, scopes)
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	expected := map[string]int64{"a": -4, "b": 2, "c": 5, "d": 1017, "g": 17, "h": -1, "i": 4}
+	for name, expectedValue := range expected {
+		value, err := types.FetchScopeVar[big.Int](name, scopes)
+		if err != nil {
+			t.Errorf("Failed to fetch %s: %s", name, err)
+		} else if value.Cmp(big.NewInt(expectedValue)) != 0 {
+			t.Errorf("Wrong value for %s. Expected %d, got %s", name, expectedValue, value.String())
+		}
+	}
+}
+
+func TestPythonHintLoopsAndPrint(t *testing.T) {
+	code := `
+total = 0
+for i in range(10):
+    if i == 7:
+        break
+    if i % 2 == 0:
+        continue
+    total += i
+while total > 0:
+    total -= 4
+print(total, [1, 'a', (2,)], {'k': None}, ap, sep=', ')
+`
+	output, err := runPythonHint(code, newTestVm(), IdsManager{}, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	expected := "-3, [1, 'a', (2,)], {'k': None}, 1:5\n"
+	if output != expected {
+		t.Errorf("Wrong output. Expected %q, got %q", expected, output)
+	}
+}
+
+func TestPythonHintSegmentsAndPointers(t *testing.T) {
+	vm := newTestVm()
+	code := `
+ids.ptr = segments.add()
+for i in range(3):
+    memory[ids.ptr + i] = i + 10
+ids.end = ids.ptr + 3
+ids.size = ids.end - ids.ptr
+`
+	ids := SetupIdsForTest(map[string][]*MaybeRelocatable{
+		"ptr":  {nil},
+		"end":  {nil},
+		"size": {nil},
+	}, vm)
+	_, err := runPythonHint(code, vm, ids, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	ptr, err := ids.GetRelocatable("ptr", vm)
+	if err != nil || ptr != NewRelocatable(2, 0) {
+		t.Fatalf("Wrong ids.ptr: %v, %v", ptr, err)
+	}
+	end, err := ids.GetRelocatable("end", vm)
+	if err != nil || end != NewRelocatable(2, 3) {
+		t.Errorf("Wrong ids.end: %v, %v", end, err)
+	}
+	size, err := ids.GetFelt("size", vm)
+	if err != nil || size != FeltFromUint64(3) {
+		t.Errorf("Wrong ids.size: %v, %v", size, err)
+	}
+	values, err := vm.Segments.GetFeltRange(ptr, 3)
+	if err != nil {
+		t.Fatalf("Failed to read the new segment: %s", err)
+	}
+	for i, value := range values {
+		if value != FeltFromUint64(uint64(i+10)) {
+			t.Errorf("Wrong value at index %d: %s", i, value.ToHexString())
+		}
+	}
+}
+
+func TestPythonHintScopeInterop(t *testing.T) {
+	scopes := types.NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("n", *big.NewInt(5))
+	code := `
+n_squared = n * n
+vm_enter_scope({'inner': n_squared + 1})
+`
+	_, err := runPythonHint(code, newTestVm(), IdsManager{}, scopes)
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	inner, err := types.FetchScopeVar[big.Int]("inner", scopes)
+	if err != nil || inner.Cmp(big.NewInt(26)) != 0 {
+		t.Errorf("Wrong inner scope variable: %v, %v", inner.String(), err)
+	}
+	_, err = runPythonHint("vm_exit_scope()", newTestVm(), IdsManager{}, scopes)
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	squared, err := types.FetchScopeVar[big.Int]("n_squared", scopes)
+	if err != nil || squared.Cmp(big.NewInt(25)) != 0 {
+		t.Errorf("Wrong n_squared scope variable: %v, %v", squared.String(), err)
+	}
+}
+
+func TestPythonHintAssertFails(t *testing.T) {
+	_, err := runPythonHint("x = 1\nassert x == 2, 'x should be 2'", newTestVm(), IdsManager{}, types.NewExecutionScopes())
+	expected := "Python hint error at line 2: AssertionError: x should be 2"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Wrong error. Expected %q, got %v", expected, err)
+	}
+}
+
+func TestPythonHintRuntimeErrors(t *testing.T) {
+	codes := map[string]string{
+		"x = undefined":             "Python hint error at line 1: name 'undefined' is not defined",
+		"x = 1 // 0":                "Python hint error at line 1: ZeroDivisionError: integer division or modulo by zero",
+		"x = 1 / 2":                 "Python hint error at line 1: true division is not supported, use // or div_mod",
+		"x = [1][3]":                "Python hint error at line 1: IndexError: index out of range",
+		"x = memory[ap]":            "Python hint error at line 1: ",
+		"from os import path":       "Python hint error at line 1: Unsupported import: path from os",
+		"if True:\n    x = 'a' + 1": "Python hint error at line 2: unsupported operand type(s) for +: 'str' and 'int'",
+	}
+	for code, expectedErr := range codes {
+		_, err := runPythonHint(code, newTestVm(), IdsManager{}, types.NewExecutionScopes())
+		if err == nil || !strings.HasPrefix(err.Error(), expectedErr) {
+			t.Errorf("Wrong error running %q. Expected prefix %q, got %v", code, expectedErr, err)
+		}
+	}
+}
+
+func TestPythonHintFallsBackToNativeHints(t *testing.T) {
+	vm := newTestVm()
+	_, err := runPythonHint(ADD_SEGMENT, vm, IdsManager{}, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	value, err := vm.Segments.Memory.GetRelocatable(vm.RunContext.Ap)
+	if err != nil || value != NewRelocatable(2, 0) {
+		t.Errorf("Wrong value at ap: %v, %v", value, err)
+	}
+}
+
+func TestPythonHintUnsupportedSyntax(t *testing.T) {
+	code := "def f():\n    pass"
+	_, err := runPythonHint(code, newTestVm(), IdsManager{}, types.NewExecutionScopes())
+	if err == nil || !strings.HasPrefix(err.Error(), "Unsupported python hint: "+code+": Syntax error at line 1") {
+		t.Errorf("Wrong error: %v", err)
+	}
+
+	var warnings bytes.Buffer
+	hintData := any(HintData{Code: code})
+	hintProcessor := python_interpreter.PythonHintProcessor{CairoVmHintProcessor: CairoVmHintProcessor{SkipUnknownHints: true, WarningOutput: &warnings}}
+	err = hintProcessor.ExecuteHint(newTestVm(), &hintData, nil, types.NewExecutionScopes())
+	if err != nil {
+		t.Errorf("The unsupported hint should have been skipped, got %s", err)
+	}
+	if !strings.HasPrefix(warnings.String(), "Warning: skipping unknown hint") {
+		t.Errorf("Wrong warning: %q", warnings.String())
+	}
+}
+
+func TestPythonHintStructMembers(t *testing.T) {
+	vm := newTestVm()
+	reference, _ := ParseHintReference(parser.Reference{Value: "[cast(fp, __main__.Point*)]"})
+	ids := IdsManager{
+		References: map[string]HintReference{"point": reference},
+		Identifiers: map[string]Identifier{
+			"__main__.Point": {Type: "struct", Members: map[string]any{
+				"x": map[string]any{"cairo_type": "felt", "offset": float64(0)},
+				"y": map[string]any{"cairo_type": "__main__.Pair", "offset": float64(1)},
+			}},
+			"__main__.Pair": {Type: "struct", Members: map[string]any{
+				"a": map[string]any{"cairo_type": "felt", "offset": float64(0)},
+				"b": map[string]any{"cairo_type": "felt", "offset": float64(1)},
+			}},
+		},
+	}
+	vm.Segments.Memory.Insert(NewRelocatable(1, 0), NewMaybeRelocatableFelt(FeltFromUint64(3)))
+	vm.Segments.Memory.Insert(NewRelocatable(1, 1), NewMaybeRelocatableFelt(FeltFromUint64(4)))
+
+	_, err := runPythonHint("ids.point.y.b = ids.point.x + ids.point.y.a\nmemory[ap] = ids.point.y.address_", vm, ids, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Hint failed with error %s", err)
+	}
+	b, err := vm.Segments.Memory.GetFelt(NewRelocatable(1, 2))
+	if err != nil || b != FeltFromUint64(7) {
+		t.Errorf("Wrong value of point.y.b: %v, %v", b, err)
+	}
+	addr, err := vm.Segments.Memory.GetRelocatable(vm.RunContext.Ap)
+	if err != nil || addr != NewRelocatable(1, 1) {
+		t.Errorf("Wrong address of point.y: %v, %v", addr, err)
+	}
+}
+
+func TestPythonHintLoopLimit(t *testing.T) {
+	codes := []string{
+		"while True:\n    pass",
+		"for i in range(10**30):\n    pass",
+		"x = [i for i in range(10**30)]",
+		"x = list(range(10**30))",
+	}
+	for _, code := range codes {
+		hintData := any(HintData{Code: code})
+		hintProcessor := python_interpreter.PythonHintProcessor{MaxLoopIterations: 1000}
+		err := hintProcessor.ExecuteHint(newTestVm(), &hintData, nil, types.NewExecutionScopes())
+		if !errors.Is(err, python_interpreter.ErrLoopLimitExceeded) {
+			t.Errorf("Expected ErrLoopLimitExceeded running %q, got %v", code, err)
+		}
+	}
+	_, err := runPythonHint("x = 0\nwhile x < 1000:\n    x += 1", newTestVm(), IdsManager{}, types.NewExecutionScopes())
+	if err != nil {
+		t.Errorf("Loops under the default limit should run, got %v", err)
+	}
+}
+
+func TestPythonHintCancelledLoop(t *testing.T) {
+	program, err := python_interpreter.Parse("while True:\n    pass")
+	if err != nil {
+		t.Fatalf("Parse failed with error %s", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = program.Execute(&python_interpreter.Context{Vm: newTestVm(), Scopes: types.NewExecutionScopes(), Cancellation: ctx})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the loop to be cancelled, got %v", err)
+	}
+}
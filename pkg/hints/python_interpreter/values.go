@@ -0,0 +1,375 @@
+package python_interpreter
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Values handled by the interpreter:
+//   - *big.Int for ints (never mutated once created)
+//   - bool, string, noneType
+//   - Relocatable
+//   - *listValue, *tupleValue, *dictValue, *rangeValue
+//   - *builtinFunc for builtin functions and methods
+//   - idsValue, memoryValue, segmentsValue for the hint globals
+//   - *structValue for ids references whose type is a struct
+//   - *opaqueValue for scope variables set by go hints that the interpreter can't represent
+type value any
+
+type noneType struct{}
+
+var none = noneType{}
+
+type listValue struct{ elts []value }
+
+type tupleValue struct{ elts []value }
+
+// Dict with insertion order. Keys are looked up by equality, which is fine for the small dicts used in hints
+type dictValue struct {
+	keys   []value
+	values []value
+}
+
+type rangeValue struct{ start, stop, step *big.Int }
+
+type builtinFunc struct {
+	name string
+	fn   func(args []value, kwargs map[string]value) (value, error)
+}
+
+type idsValue struct{}
+
+type memoryValue struct{}
+
+type segmentsValue struct{}
+
+// An ids reference to a cairo struct, or a struct member of one. Its members are resolved by name
+// from the struct definitions of the program
+type structValue struct {
+	// Path of the struct from ids, such as point.x
+	path    string
+	address Relocatable
+}
+
+// A scope variable the interpreter can pass around but not operate on, such as the dict manager
+type opaqueValue struct{ value any }
+
+func typeName(v value) string {
+	switch v.(type) {
+	case *big.Int:
+		return "int"
+	case bool:
+		return "bool"
+	case string:
+		return "str"
+	case noneType:
+		return "NoneType"
+	case Relocatable:
+		return "RelocatableValue"
+	case *listValue:
+		return "list"
+	case *tupleValue:
+		return "tuple"
+	case *dictValue:
+		return "dict"
+	case *rangeValue:
+		return "range"
+	case *builtinFunc:
+		return "builtin_function"
+	case *structValue:
+		return "struct"
+	}
+	return "object"
+}
+
+func (d *dictValue) index(key value) int {
+	for i, k := range d.keys {
+		if valuesEqual(k, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (d *dictValue) get(key value) (value, bool) {
+	i := d.index(key)
+	if i < 0 {
+		return nil, false
+	}
+	return d.values[i], true
+}
+
+func (d *dictValue) set(key value, v value) error {
+	if !isHashable(key) {
+		return errors.Errorf("unhashable type: '%s'", typeName(key))
+	}
+	i := d.index(key)
+	if i < 0 {
+		d.keys = append(d.keys, key)
+		d.values = append(d.values, v)
+	} else {
+		d.values[i] = v
+	}
+	return nil
+}
+
+func isHashable(v value) bool {
+	switch v := v.(type) {
+	case *listValue, *dictValue:
+		return false
+	case *tupleValue:
+		for _, elt := range v.elts {
+			if !isHashable(elt) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *rangeValue) length() *big.Int {
+	// ceil((stop - start) / step), or 0 if negative
+	diff := new(big.Int).Sub(r.stop, r.start)
+	step := r.step
+	if step.Sign() < 0 {
+		diff.Neg(diff)
+		step = new(big.Int).Neg(step)
+	}
+	if diff.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	diff.Add(diff, step).Sub(diff, big.NewInt(1))
+	return diff.Div(diff, step)
+}
+
+func isInt(v value) bool {
+	switch v.(type) {
+	case *big.Int, bool:
+		return true
+	}
+	return false
+}
+
+// Converts ints and bools to a *big.Int
+func toInt(v value) (*big.Int, bool) {
+	switch v := v.(type) {
+	case *big.Int:
+		return v, true
+	case bool:
+		if v {
+			return big.NewInt(1), true
+		}
+		return big.NewInt(0), true
+	}
+	return nil, false
+}
+
+func expectInt(v value, context string) (*big.Int, error) {
+	n, ok := toInt(v)
+	if !ok {
+		return nil, errors.Errorf("%s expected an int, got %s", context, typeName(v))
+	}
+	return n, nil
+}
+
+func truthy(v value) bool {
+	switch v := v.(type) {
+	case *big.Int:
+		return v.Sign() != 0
+	case bool:
+		return v
+	case noneType:
+		return false
+	case string:
+		return v != ""
+	case *listValue:
+		return len(v.elts) != 0
+	case *tupleValue:
+		return len(v.elts) != 0
+	case *dictValue:
+		return len(v.keys) != 0
+	case *rangeValue:
+		return v.length().Sign() != 0
+	}
+	return true
+}
+
+func valuesEqual(a value, b value) bool {
+	if aInt, ok := toInt(a); ok {
+		bInt, ok := toInt(b)
+		return ok && aInt.Cmp(bInt) == 0
+	}
+	switch a := a.(type) {
+	case string:
+		b, ok := b.(string)
+		return ok && a == b
+	case noneType:
+		_, ok := b.(noneType)
+		return ok
+	case Relocatable:
+		b, ok := b.(Relocatable)
+		return ok && a == b
+	case *listValue:
+		b, ok := b.(*listValue)
+		return ok && eltsEqual(a.elts, b.elts)
+	case *tupleValue:
+		b, ok := b.(*tupleValue)
+		return ok && eltsEqual(a.elts, b.elts)
+	case *dictValue:
+		b, ok := b.(*dictValue)
+		if !ok || len(a.keys) != len(b.keys) {
+			return false
+		}
+		for i, key := range a.keys {
+			bValue, ok := b.get(key)
+			if !ok || !valuesEqual(a.values[i], bValue) {
+				return false
+			}
+		}
+		return true
+	case *structValue:
+		b, ok := b.(*structValue)
+		return ok && a.address == b.address
+	}
+	return a == b
+}
+
+func eltsEqual(a []value, b []value) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !valuesEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Formats a value like python's str
+func str(v value) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return repr(v)
+}
+
+// Formats a value like python's repr
+func repr(v value) string {
+	switch v := v.(type) {
+	case *big.Int:
+		return v.String()
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case noneType:
+		return "None"
+	case string:
+		return "'" + strings.ReplaceAll(strings.ReplaceAll(v, "\\", "\\\\"), "'", "\\'") + "'"
+	case Relocatable:
+		return fmt.Sprintf("%d:%d", v.SegmentIndex, v.Offset)
+	case *listValue:
+		return "[" + joinRepr(v.elts) + "]"
+	case *tupleValue:
+		if len(v.elts) == 1 {
+			return "(" + repr(v.elts[0]) + ",)"
+		}
+		return "(" + joinRepr(v.elts) + ")"
+	case *dictValue:
+		entries := make([]string, 0, len(v.keys))
+		for i, key := range v.keys {
+			entries = append(entries, repr(key)+": "+repr(v.values[i]))
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	case *rangeValue:
+		if v.step.Cmp(big.NewInt(1)) == 0 {
+			return fmt.Sprintf("range(%s, %s)", v.start, v.stop)
+		}
+		return fmt.Sprintf("range(%s, %s, %s)", v.start, v.stop, v.step)
+	case *builtinFunc:
+		return "<built-in function " + v.name + ">"
+	case *structValue:
+		return fmt.Sprintf("<%s at %d:%d>", v.path, v.address.SegmentIndex, v.address.Offset)
+	}
+	return fmt.Sprintf("<%s>", typeName(v))
+}
+
+func joinRepr(elts []value) string {
+	parts := make([]string, 0, len(elts))
+	for _, elt := range elts {
+		parts = append(parts, repr(elt))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Converts a value read from memory into an interpreter value
+func fromMaybeRelocatable(v *MaybeRelocatable) value {
+	felt, ok := v.GetFelt()
+	if ok {
+		return felt.ToBigInt()
+	}
+	rel, _ := v.GetRelocatable()
+	return rel
+}
+
+// Converts an int or relocatable into a value that can be written to memory, reducing ints modulo PRIME
+func toMaybeRelocatable(v value) (*MaybeRelocatable, error) {
+	if rel, ok := v.(Relocatable); ok {
+		return NewMaybeRelocatableRelocatable(rel), nil
+	}
+	n, ok := toInt(v)
+	if !ok {
+		return nil, errors.Errorf("can't write a value of type %s to memory", typeName(v))
+	}
+	return NewMaybeRelocatableFelt(lambdaworks.FeltFromBigInt(new(big.Int).Mod(n, lambdaworks.Prime()))), nil
+}
+
+// Converts a scope variable into an interpreter value
+func fromScopeVar(v any) value {
+	switch v := v.(type) {
+	case big.Int:
+		return new(big.Int).Set(&v)
+	case *big.Int:
+		return new(big.Int).Set(v)
+	case lambdaworks.Felt:
+		return v.ToBigInt()
+	case int:
+		return big.NewInt(int64(v))
+	case int64:
+		return big.NewInt(v)
+	case uint:
+		return new(big.Int).SetUint64(uint64(v))
+	case uint64:
+		return new(big.Int).SetUint64(v)
+	case MaybeRelocatable:
+		return fromMaybeRelocatable(&v)
+	case *MaybeRelocatable:
+		return fromMaybeRelocatable(v)
+	case bool, string, noneType, Relocatable, *listValue, *tupleValue, *dictValue, *rangeValue, *structValue:
+		return v
+	case *opaqueValue:
+		return v
+	case nil:
+		return none
+	}
+	return &opaqueValue{value: v}
+}
+
+// Converts an interpreter value into a scope variable.
+// Ints are stored as big.Int, the way go hints store them, so they can be shared with them
+func toScopeVar(v value) any {
+	switch v := v.(type) {
+	case *big.Int:
+		return *new(big.Int).Set(v)
+	case *opaqueValue:
+		return v.value
+	}
+	return v
+}
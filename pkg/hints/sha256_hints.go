@@ -5,6 +5,7 @@ import (
 	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
 	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
 )
 
 func sha256Input(ids IdsManager, vm *VirtualMachine) error {
@@ -17,3 +18,95 @@ func sha256Input(ids IdsManager, vm *VirtualMachine) error {
 	}
 	return ids.Insert("full_word", NewMaybeRelocatableFelt(FeltZero()), vm)
 }
+
+// Fetches a constant used by the sha256 hints, checking that it is smaller than bound
+func getSha256Constant(name string, bound uint64, ids IdsManager, constants *map[string]Felt) (uint, error) {
+	constant, err := ids.GetConst(name, constants)
+	if err != nil {
+		return 0, err
+	}
+	if constant.Cmp(FeltFromUint64(bound)) != -1 {
+		return 0, errors.Errorf("assert 0 <= %s < %d", constant.ToSignedFeltString(), bound)
+	}
+	return constant.ToUint()
+}
+
+// Reads the message block starting at ids.sha256_start and writes its compression, starting from state, to ids.output
+func sha256Main(ids IdsManager, vm *VirtualMachine, constants *map[string]Felt, state []uint32) error {
+	chunkSize, err := getSha256Constant("SHA256_INPUT_CHUNK_SIZE_FELTS", 100, ids, constants)
+	if err != nil {
+		return err
+	}
+	sha256Start, err := ids.GetRelocatable("sha256_start", vm)
+	if err != nil {
+		return err
+	}
+	message, err := getUint32MemoryRange(sha256Start, 0, chunkSize, &vm.Segments)
+	if err != nil {
+		return err
+	}
+	if len(message) != 16 || len(state) != 8 {
+		return errors.Errorf("Invalid sha256 input: message of %d words, state of %d words", len(message), len(state))
+	}
+	newState := Sha256Compress([8]uint32(state), [16]uint32(message))
+	output, err := ids.GetRelocatable("output", vm)
+	if err != nil {
+		return err
+	}
+	data := Uint32SliceToMRSlice(newState[:])
+	_, err = vm.Segments.LoadData(output, &data)
+	return err
+}
+
+func sha256MainConstantInputLength(ids IdsManager, vm *VirtualMachine, constants *map[string]Felt) error {
+	iv := Sha256IV()
+	return sha256Main(ids, vm, constants, iv[:])
+}
+
+func sha256MainArbitraryInputLength(ids IdsManager, vm *VirtualMachine, constants *map[string]Felt) error {
+	stateSize, err := getSha256Constant("SHA256_STATE_SIZE_FELTS", 100, ids, constants)
+	if err != nil {
+		return err
+	}
+	statePtr, err := ids.GetRelocatable("state", vm)
+	if err != nil {
+		return err
+	}
+	state, err := getUint32MemoryRange(statePtr, 0, stateSize, &vm.Segments)
+	if err != nil {
+		return err
+	}
+	return sha256Main(ids, vm, constants, state)
+}
+
+func sha256Finalize(ids IdsManager, vm *VirtualMachine, constants *map[string]Felt) error {
+	blockSize, err := getSha256Constant("BLOCK_SIZE", 20, ids, constants)
+	if err != nil {
+		return err
+	}
+	chunkSize, err := getSha256Constant("SHA256_INPUT_CHUNK_SIZE_FELTS", 100, ids, constants)
+	if err != nil {
+		return err
+	}
+	if chunkSize != 16 {
+		return errors.Errorf("Invalid sha256 input: message of %d words", chunkSize)
+	}
+	sha256PtrEnd, err := ids.GetRelocatable("sha256_ptr_end", vm)
+	if err != nil {
+		return err
+	}
+	// Add dummy pairs of input and output
+	var message [16]uint32
+	iv := Sha256IV()
+	output := Sha256Compress(iv, message)
+	padding := message[:]
+	padding = append(padding, iv[:]...)
+	padding = append(padding, output[:]...)
+	fullPadding := make([]uint32, 0, len(padding)*int(blockSize))
+	for i := uint(1); i < blockSize; i++ {
+		fullPadding = append(fullPadding, padding...)
+	}
+	data := Uint32SliceToMRSlice(fullPadding)
+	_, err = vm.Segments.LoadData(sha256PtrEnd, &data)
+	return err
+}
@@ -1,6 +1,7 @@
 package hints_test
 
 import (
+	"reflect"
 	"testing"
 
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
@@ -62,3 +63,163 @@ func TestSha256InputTrue(t *testing.T) {
 		t.Error("Wrong/No value inserted into ids.full_word")
 	}
 }
+
+// Single padded message block for the input "abc"
+func sha256AbcBlock() []*MaybeRelocatable {
+	block := make([]*MaybeRelocatable, 0, 16)
+	block = append(block, NewMaybeRelocatableFelt(FeltFromUint64(0x61626380)))
+	for i := 0; i < 14; i++ {
+		block = append(block, NewMaybeRelocatableFelt(FeltZero()))
+	}
+	return append(block, NewMaybeRelocatableFelt(FeltFromUint64(0x18)))
+}
+
+// sha256("abc")
+var SHA256_ABC_DIGEST = []Felt{
+	FeltFromUint64(0xba7816bf), FeltFromUint64(0x8f01cfea), FeltFromUint64(0x414140de), FeltFromUint64(0x5dae2223),
+	FeltFromUint64(0xb00361a3), FeltFromUint64(0x96177a9c), FeltFromUint64(0xb410ff61), FeltFromUint64(0xf20015ad),
+}
+
+func loadSha256Data(vm *VirtualMachine, data []*MaybeRelocatable) Relocatable {
+	base := vm.Segments.AddSegment()
+	for i, value := range data {
+		vm.Segments.Memory.Insert(base.AddUint(uint(i)), value)
+	}
+	return base
+}
+
+func TestSha256MainConstantInputLength(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	sha256Start := loadSha256Data(vm, sha256AbcBlock())
+	output := vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"sha256_start": {NewMaybeRelocatableRelocatable(sha256Start)},
+			"output":       {NewMaybeRelocatableRelocatable(output)},
+		},
+		vm,
+	)
+	constants := SetupConstantsForTest(map[string]Felt{"SHA256_INPUT_CHUNK_SIZE_FELTS": FeltFromUint64(16)}, &idsManager)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SHA256_MAIN_CONSTANT_INPUT_LENGTH,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, &constants, nil)
+	if err != nil {
+		t.Errorf("SHA256_MAIN_CONSTANT_INPUT_LENGTH hint test failed with error %s", err)
+	}
+	state, err := vm.Segments.GetFeltRange(output, 8)
+	if err != nil || !reflect.DeepEqual(state, SHA256_ABC_DIGEST) {
+		t.Errorf("Wrong/No output state.\n Expected: %v.\n Got: %v", SHA256_ABC_DIGEST, state)
+	}
+}
+
+func TestSha256MainArbitraryInputLength(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	sha256Start := loadSha256Data(vm, sha256AbcBlock())
+	iv := make([]*MaybeRelocatable, 0, 8)
+	for _, word := range Sha256IV() {
+		iv = append(iv, NewMaybeRelocatableFelt(FeltFromUint64(uint64(word))))
+	}
+	state := loadSha256Data(vm, iv)
+	output := vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"sha256_start": {NewMaybeRelocatableRelocatable(sha256Start)},
+			"state":        {NewMaybeRelocatableRelocatable(state)},
+			"output":       {NewMaybeRelocatableRelocatable(output)},
+		},
+		vm,
+	)
+	constants := SetupConstantsForTest(map[string]Felt{
+		"SHA256_INPUT_CHUNK_SIZE_FELTS": FeltFromUint64(16),
+		"SHA256_STATE_SIZE_FELTS":       FeltFromUint64(8),
+	}, &idsManager)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SHA256_MAIN_ARBITRARY_INPUT_LENGTH,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, &constants, nil)
+	if err != nil {
+		t.Errorf("SHA256_MAIN_ARBITRARY_INPUT_LENGTH hint test failed with error %s", err)
+	}
+	newState, err := vm.Segments.GetFeltRange(output, 8)
+	if err != nil || !reflect.DeepEqual(newState, SHA256_ABC_DIGEST) {
+		t.Errorf("Wrong/No output state.\n Expected: %v.\n Got: %v", SHA256_ABC_DIGEST, newState)
+	}
+}
+
+func TestSha256MainArbitraryInputLengthInvalidStateSize(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"sha256_start": {NewMaybeRelocatableRelocatable(NewRelocatable(1, 0))},
+			"state":        {NewMaybeRelocatableRelocatable(NewRelocatable(2, 0))},
+			"output":       {NewMaybeRelocatableRelocatable(NewRelocatable(3, 0))},
+		},
+		vm,
+	)
+	constants := SetupConstantsForTest(map[string]Felt{
+		"SHA256_INPUT_CHUNK_SIZE_FELTS": FeltFromUint64(16),
+		"SHA256_STATE_SIZE_FELTS":       FeltFromUint64(100),
+	}, &idsManager)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SHA256_MAIN_ARBITRARY_INPUT_LENGTH,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, &constants, nil)
+	if err == nil {
+		t.Errorf("SHA256_MAIN_ARBITRARY_INPUT_LENGTH hint test should have failed")
+	}
+}
+
+func TestSha256Finalize(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	sha256PtrEnd := vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"sha256_ptr_end": {NewMaybeRelocatableRelocatable(sha256PtrEnd)},
+		},
+		vm,
+	)
+	constants := SetupConstantsForTest(map[string]Felt{
+		"BLOCK_SIZE":                    FeltFromUint64(7),
+		"SHA256_INPUT_CHUNK_SIZE_FELTS": FeltFromUint64(16),
+	}, &idsManager)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SHA256_FINALIZE,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, &constants, nil)
+	if err != nil {
+		t.Errorf("SHA256_FINALIZE hint test failed with error %s", err)
+	}
+	// Each of the 6 dummy instances consists of an empty message, the IV and its compression
+	instance := make([]Felt, 16, 32)
+	for _, word := range Sha256IV() {
+		instance = append(instance, FeltFromUint64(uint64(word)))
+	}
+	for _, word := range []uint64{0xda5698be, 0x17b9b469, 0x62335799, 0x779fbeca, 0x8ce5d491, 0xc0d26243, 0xbafef9ea, 0x1837a9d8} {
+		instance = append(instance, FeltFromUint64(word))
+	}
+	padding, err := vm.Segments.GetFeltRange(sha256PtrEnd, 6*32)
+	if err != nil {
+		t.Fatalf("Wrong/No padding loaded: %s", err)
+	}
+	for i := 0; i < 6; i++ {
+		if !reflect.DeepEqual(padding[32*i:32*(i+1)], instance) {
+			t.Errorf("Wrong padding instance %d.\n Expected: %v.\n Got: %v", i, instance, padding[32*i:32*(i+1)])
+		}
+	}
+	if _, ok := vm.Segments.Memory.Data[sha256PtrEnd.AddUint(6*32)]; ok {
+		t.Errorf("Only %d padding words should have been loaded", 6*32)
+	}
+}
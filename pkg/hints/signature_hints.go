@@ -8,6 +8,7 @@ import (
 	. "github.com/lambdaclass/cairo-vm.go/pkg/types"
 	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
 	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
 )
 
 func divModNPacked(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes, n *big.Int) error {
@@ -41,6 +42,12 @@ func divModNPackedDivMod(ids IdsManager, vm *VirtualMachine, scopes *ExecutionSc
 	return divModNPacked(ids, vm, scopes, n)
 }
 
+func divModNPackedDivModED25519(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	n := ED25519_N()
+	scopes.AssignOrUpdateVariable("N", n)
+	return divModNPacked(ids, vm, scopes, &n)
+}
+
 func divModNPackedDivModExternalN(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
 	n, err := FetchScopeVar[big.Int]("N", scopes)
 	if err != nil {
@@ -113,3 +120,157 @@ func getPointFromX(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes,
 	scopes.AssignOrUpdateVariable("value", *y)
 	return nil
 }
+
+/*
+Implements hint:
+%{
+    from starkware.cairo.common.cairo_secp.secp_utils import pack
+    from starkware.python.math_utils import div_mod, safe_div
+
+    N = pack(ids.n, PRIME)
+    x = pack(ids.x, PRIME) % N
+    s = pack(ids.s, PRIME) % N
+    value = res = div_mod(x, s, N)
+%}
+*/
+
+func ecRecoverDivModNPacked(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	nUnpacked, err := BigInt3FromVarName("n", ids, vm)
+	if err != nil {
+		return err
+	}
+	xUnpacked, err := BigInt3FromVarName("x", ids, vm)
+	if err != nil {
+		return err
+	}
+	sUnpacked, err := BigInt3FromVarName("s", ids, vm)
+	if err != nil {
+		return err
+	}
+	n := nUnpacked.Pack86()
+	if n.Sign() == 0 {
+		return errors.New("Attempted to divide by zero")
+	}
+	x := xUnpacked.Pack86()
+	x.Mod(&x, &n)
+	s := sUnpacked.Pack86()
+	s.Mod(&s, &n)
+
+	value, err := utils.DivMod(&x, &s, &n)
+	if err != nil {
+		return err
+	}
+	scopes.AssignOrUpdateVariable("N", n)
+	scopes.AssignOrUpdateVariable("x", x)
+	scopes.AssignOrUpdateVariable("s", s)
+	scopes.AssignOrUpdateVariable("value", *value)
+	scopes.AssignOrUpdateVariable("res", *value)
+	return nil
+}
+
+/*
+Implements hint:
+%{
+    from starkware.cairo.common.cairo_secp.secp_utils import pack
+    from starkware.python.math_utils import div_mod, safe_div
+
+    a = pack(ids.a, PRIME)
+    b = pack(ids.b, PRIME)
+
+    value = res = a - b
+%}
+*/
+
+func ecRecoverSubAB(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	aUnpacked, err := BigInt3FromVarName("a", ids, vm)
+	if err != nil {
+		return err
+	}
+	bUnpacked, err := BigInt3FromVarName("b", ids, vm)
+	if err != nil {
+		return err
+	}
+	a := aUnpacked.Pack86()
+	b := bUnpacked.Pack86()
+	value := *new(big.Int).Sub(&a, &b)
+
+	scopes.AssignOrUpdateVariable("a", a)
+	scopes.AssignOrUpdateVariable("b", b)
+	scopes.AssignOrUpdateVariable("value", value)
+	scopes.AssignOrUpdateVariable("res", value)
+	return nil
+}
+
+/*
+Implements hint:
+%{
+    from starkware.cairo.common.cairo_secp.secp_utils import pack
+    from starkware.python.math_utils import div_mod, safe_div
+
+    a = pack(ids.a, PRIME)
+    b = pack(ids.b, PRIME)
+    product = a * b
+    m = pack(ids.m, PRIME)
+
+    value = res = product % m
+%}
+*/
+
+func ecRecoverProductMod(ids IdsManager, vm *VirtualMachine, scopes *ExecutionScopes) error {
+	aUnpacked, err := BigInt3FromVarName("a", ids, vm)
+	if err != nil {
+		return err
+	}
+	bUnpacked, err := BigInt3FromVarName("b", ids, vm)
+	if err != nil {
+		return err
+	}
+	mUnpacked, err := BigInt3FromVarName("m", ids, vm)
+	if err != nil {
+		return err
+	}
+	a := aUnpacked.Pack86()
+	b := bUnpacked.Pack86()
+	m := mUnpacked.Pack86()
+	if m.Sign() == 0 {
+		return errors.New("Attempted to divide by zero")
+	}
+	product := *new(big.Int).Mul(&a, &b)
+	value := *new(big.Int).Mod(&product, &m)
+
+	scopes.AssignOrUpdateVariable("a", a)
+	scopes.AssignOrUpdateVariable("b", b)
+	scopes.AssignOrUpdateVariable("product", product)
+	scopes.AssignOrUpdateVariable("m", m)
+	scopes.AssignOrUpdateVariable("value", value)
+	scopes.AssignOrUpdateVariable("res", value)
+	return nil
+}
+
+/*
+Implements hint:
+%{ value = k = product // m %}
+*/
+
+func ecRecoverProductDivM(scopes *ExecutionScopes) error {
+	product, err := FetchScopeVar[big.Int]("product", scopes)
+	if err != nil {
+		return err
+	}
+	m, err := FetchScopeVar[big.Int]("m", scopes)
+	if err != nil {
+		return err
+	}
+	if m.Sign() == 0 {
+		return errors.New("Attempted to divide by zero")
+	}
+	// Python's floor division rounds towards negative infinity, while big.Int's euclidean division keeps the
+	// remainder positive, so they differ for negative divisors
+	value, remainder := new(big.Int).DivMod(&product, &m, new(big.Int))
+	if m.Sign() < 0 && remainder.Sign() != 0 {
+		value.Sub(value, big.NewInt(1))
+	}
+	scopes.AssignOrUpdateVariable("value", *value)
+	scopes.AssignOrUpdateVariable("k", *value)
+	return nil
+}
@@ -55,6 +55,39 @@ func TestDivModNPackedDivMod(t *testing.T) {
 	}
 }
 
+func TestDivModNPackedDivModED25519(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"a": {
+				NewMaybeRelocatableFelt(FeltFromUint64(10)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+			},
+			"b": {
+				NewMaybeRelocatableFelt(FeltFromUint64(2)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+				NewMaybeRelocatableFelt(FeltFromUint64(0)),
+			},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: DIV_MOD_N_PACKED_DIVMOD_ED25519,
+	})
+	scopes := NewExecutionScopes()
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Errorf("DIV_MOD_N_PACKED_DIVMOD_ED25519 hint test failed with error %s", err)
+	}
+	CheckScopeVar[big.Int]("res", *big.NewInt(5), scopes, t)
+	CheckScopeVar[big.Int]("value", *big.NewInt(5), scopes, t)
+	CheckScopeVar[big.Int]("N", ED25519_N(), scopes, t)
+}
+
 func TestDivModNPackedDivModExternalN(t *testing.T) {
 	vm := NewVirtualMachine()
 	vm.Segments.AddSegment()
@@ -233,3 +266,134 @@ func TestGetPointFromXNegativeY(t *testing.T) {
 		t.Errorf("Wrong/No scope var value.\n Expected %v, got: %v", expectedValue, &value)
 	}
 }
+
+func bigInt3ForTest(d0 uint64, d1 uint64, d2 uint64) []*MaybeRelocatable {
+	return []*MaybeRelocatable{
+		NewMaybeRelocatableFelt(FeltFromUint64(d0)),
+		NewMaybeRelocatableFelt(FeltFromUint64(d1)),
+		NewMaybeRelocatableFelt(FeltFromUint64(d2)),
+	}
+}
+
+func TestEcRecoverDivModNPacked(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"n": bigInt3ForTest(177, 0, 0),
+			"x": bigInt3ForTest(25, 0, 0),
+			"s": bigInt3ForTest(5, 0, 0),
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: EC_RECOVER_DIV_MOD_N_PACKED,
+	})
+	scopes := NewExecutionScopes()
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Errorf("EC_RECOVER_DIV_MOD_N_PACKED hint test failed with error %s", err)
+	}
+	CheckScopeVar[big.Int]("value", *big.NewInt(5), scopes, t)
+	CheckScopeVar[big.Int]("res", *big.NewInt(5), scopes, t)
+}
+
+func TestEcRecoverDivModNPackedZeroN(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"n": bigInt3ForTest(0, 0, 0),
+			"x": bigInt3ForTest(25, 0, 0),
+			"s": bigInt3ForTest(5, 0, 0),
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: EC_RECOVER_DIV_MOD_N_PACKED,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, NewExecutionScopes())
+	if err == nil {
+		t.Errorf("EC_RECOVER_DIV_MOD_N_PACKED hint test should have failed")
+	}
+}
+
+func TestEcRecoverSubAB(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"a": bigInt3ForTest(100, 0, 0),
+			"b": bigInt3ForTest(25, 0, 0),
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: EC_RECOVER_SUB_A_B,
+	})
+	scopes := NewExecutionScopes()
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Errorf("EC_RECOVER_SUB_A_B hint test failed with error %s", err)
+	}
+	CheckScopeVar[big.Int]("value", *big.NewInt(75), scopes, t)
+	CheckScopeVar[big.Int]("res", *big.NewInt(75), scopes, t)
+}
+
+func TestEcRecoverProductModAndDivM(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"a": bigInt3ForTest(60, 0, 0),
+			"b": bigInt3ForTest(2, 0, 0),
+			"m": bigInt3ForTest(100, 0, 0),
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: EC_RECOVER_PRODUCT_MOD,
+	})
+	scopes := NewExecutionScopes()
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Errorf("EC_RECOVER_PRODUCT_MOD hint test failed with error %s", err)
+	}
+	CheckScopeVar[big.Int]("product", *big.NewInt(120), scopes, t)
+	CheckScopeVar[big.Int]("value", *big.NewInt(20), scopes, t)
+	CheckScopeVar[big.Int]("res", *big.NewInt(20), scopes, t)
+
+	hintData = any(HintData{
+		Ids:  idsManager,
+		Code: EC_RECOVER_PRODUCT_DIV_M,
+	})
+	err = hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Errorf("EC_RECOVER_PRODUCT_DIV_M hint test failed with error %s", err)
+	}
+	CheckScopeVar[big.Int]("value", *big.NewInt(1), scopes, t)
+	CheckScopeVar[big.Int]("k", *big.NewInt(1), scopes, t)
+}
+
+func TestEcRecoverProductDivMNegativeDivisor(t *testing.T) {
+	vm := NewVirtualMachine()
+	scopes := NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("product", *big.NewInt(7))
+	scopes.AssignOrUpdateVariable("m", *big.NewInt(-2))
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{Code: EC_RECOVER_PRODUCT_DIV_M})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Errorf("EC_RECOVER_PRODUCT_DIV_M hint test failed with error %s", err)
+	}
+	// 7 // -2 == -4 in python
+	CheckScopeVar[big.Int]("k", *big.NewInt(-4), scopes, t)
+}
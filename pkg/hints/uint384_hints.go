@@ -0,0 +1,262 @@
+package hints
+
+import (
+	"math/big"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+/*
+Implements hint:
+%{
+    sum_d0 = ids.a.d0 + ids.b.d0
+    ids.carry_d0 = 1 if sum_d0 >= ids.SHIFT else 0
+    sum_d1 = ids.a.d1 + ids.b.d1 + ids.carry_d0
+    ids.carry_d1 = 1 if sum_d1 >= ids.SHIFT else 0
+    sum_d2 = ids.a.d2 + ids.b.d2 + ids.carry_d1
+    ids.carry_d2 = 1 if sum_d2 >= ids.SHIFT else 0
+%}
+*/
+
+func addNoUint384Check(ids IdsManager, vm *VirtualMachine) error {
+	shift := FeltOne().Shl(128)
+	a, err := Uint384FromVarName("a", ids, vm)
+	if err != nil {
+		return err
+	}
+	b, err := Uint384FromVarName("b", ids, vm)
+	if err != nil {
+		return err
+	}
+	carry := FeltZero()
+	for i, name := range []string{"carry_d0", "carry_d1", "carry_d2"} {
+		sum := a.Limbs[i].Add(b.Limbs[i]).Add(carry)
+		carry = FeltZero()
+		if sum.Cmp(shift) != -1 {
+			carry = FeltOne()
+		}
+		err = ids.Insert(name, NewMaybeRelocatableFelt(carry), vm)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+Implements hint:
+%{ memory[ap] = 1 if 0 <= (ids.a.d2 % PRIME) < 2 ** 127 else 0 %}
+*/
+
+func uint384SignedNN(ids IdsManager, vm *VirtualMachine) error {
+	a, err := Uint384FromVarName("a", ids, vm)
+	if err != nil {
+		return err
+	}
+	if a.Limbs[2].Cmp(FeltOne().Shl(127)) == -1 {
+		return vm.Segments.Memory.Insert(vm.RunContext.Ap, NewMaybeRelocatableFelt(FeltOne()))
+	}
+	return vm.Segments.Memory.Insert(vm.RunContext.Ap, NewMaybeRelocatableFelt(FeltZero()))
+}
+
+/*
+Implements hint:
+%{
+    ids.low = ids.a & ((1<<128) - 1)
+    ids.high = ids.a >> 128
+%}
+*/
+
+func uint384Split128(ids IdsManager, vm *VirtualMachine) error {
+	a, err := ids.GetFelt("a", vm)
+	if err != nil {
+		return err
+	}
+	mask := FeltOne().Shl(128).Sub(FeltOne())
+	err = ids.Insert("low", NewMaybeRelocatableFelt(a.And(mask)), vm)
+	if err != nil {
+		return err
+	}
+	return ids.Insert("high", NewMaybeRelocatableFelt(a.Shr(128)), vm)
+}
+
+// Stores divmod(a, div) into ids.quotient and ids.remainder, split into 128 bit limbs
+func insertUint384DivRem(a *big.Int, div *big.Int, ids IdsManager, vm *VirtualMachine) error {
+	if div.Sign() == 0 {
+		return errors.New("Attempted to divide by zero")
+	}
+	quotient, remainder := new(big.Int).DivMod(a, div, new(big.Int))
+	quotientLimbs := ToUint384(quotient)
+	err := quotientLimbs.InsertFromVarName("quotient", ids, vm)
+	if err != nil {
+		return err
+	}
+	remainderLimbs := ToUint384(remainder)
+	return remainderLimbs.InsertFromVarName("remainder", ids, vm)
+}
+
+/*
+Implements hint:
+%{
+    a = pack(ids.a, num_bits_shift = 128)
+    div = pack(ids.div, num_bits_shift = 128)
+    quotient, remainder = divmod(a, div)
+    quotient_split = split(quotient, num_bits_shift=128, length=3)
+    assert len(quotient_split) == 3
+    ...
+%}
+*/
+
+func uint384UnsignedDivRem(ids IdsManager, vm *VirtualMachine) error {
+	a, err := Uint384FromVarName("a", ids, vm)
+	if err != nil {
+		return err
+	}
+	div, err := Uint384FromVarName("div", ids, vm)
+	if err != nil {
+		return err
+	}
+	aPacked := a.Pack()
+	divPacked := div.Pack()
+	return insertUint384DivRem(&aPacked, &divPacked, ids, vm)
+}
+
+/*
+Implements hint:
+%{
+    a = pack(ids.a, num_bits_shift = 128)
+    div = pack2(ids.div, num_bits_shift = 128)
+    quotient, remainder = divmod(a, div)
+    ...
+%}
+Where pack2 packs the b01, b23 and b45 members of a Uint384_expand
+*/
+
+func uint384UnsignedDivRemExpanded(ids IdsManager, vm *VirtualMachine) error {
+	a, err := Uint384FromVarName("a", ids, vm)
+	if err != nil {
+		return err
+	}
+	div, err := Uint384ExpandFromVarName("div", ids, vm)
+	if err != nil {
+		return err
+	}
+	aPacked := a.Pack()
+	divPacked := div.Pack()
+	return insertUint384DivRem(&aPacked, &divPacked, ids, vm)
+}
+
+/*
+Implements hint:
+%{
+    from starkware.python.math_utils import isqrt
+    ...
+    a = pack(ids.a, num_bits_shift=128)
+    root = isqrt(a)
+    assert 0 <= root < 2 ** 192
+    root_split = split(root, num_bits_shift=128, length=3)
+    ...
+%}
+*/
+
+func uint384Sqrt(ids IdsManager, vm *VirtualMachine) error {
+	a, err := Uint384FromVarName("a", ids, vm)
+	if err != nil {
+		return err
+	}
+	aPacked := a.Pack()
+	root := new(big.Int).Sqrt(&aPacked)
+	if root.BitLen() > 192 {
+		return errors.Errorf("assert 0 <= %d < 2**192", root)
+	}
+	rootLimbs := ToUint384(root)
+	return rootLimbs.InsertFromVarName("root", ids, vm)
+}
+
+/*
+Implements hint:
+%{
+    from starkware.python.math_utils import div_mod
+    ...
+    a = pack(ids.a, num_bits_shift = 128)
+    b = pack(ids.b, num_bits_shift = 128)
+    p = pack(ids.p, num_bits_shift = 128)
+    b_inverse_mod_p = div_mod(1, b, p)
+    ...
+%}
+*/
+
+func uint384Div(ids IdsManager, vm *VirtualMachine) error {
+	b, err := Uint384FromVarName("b", ids, vm)
+	if err != nil {
+		return err
+	}
+	p, err := Uint384FromVarName("p", ids, vm)
+	if err != nil {
+		return err
+	}
+	bPacked := b.Pack()
+	pPacked := p.Pack()
+	if pPacked.Sign() == 0 {
+		return errors.New("Attempted to divide by zero")
+	}
+	bInverseModP, err := utils.DivMod(big.NewInt(1), &bPacked, &pPacked)
+	if err != nil {
+		return err
+	}
+	bInverseModPLimbs := ToUint384(bInverseModP)
+	return bInverseModPLimbs.InsertFromVarName("b_inverse_mod_p", ids, vm)
+}
+
+/*
+Implements hints:
+%{
+    ...
+    a = pack_extended(ids.a, num_bits_shift = 128)
+    div = pack(ids.div, num_bits_shift = 128)
+    quotient, remainder = divmod(a, div)
+    quotient_split = split(quotient, num_bits_shift=128, length=6)
+    ...
+    remainder_split = split(remainder, num_bits_shift=128, length=3)
+    ...
+%}
+Where div is a Uint384_expand if expanded is true, and a Uint384 otherwise
+*/
+
+func unsignedDivRemUint768ByUint384(ids IdsManager, vm *VirtualMachine, expanded bool) error {
+	a, err := Uint768FromVarName("a", ids, vm)
+	if err != nil {
+		return err
+	}
+	var divPacked big.Int
+	if expanded {
+		div, err := Uint384ExpandFromVarName("div", ids, vm)
+		if err != nil {
+			return err
+		}
+		divPacked = div.Pack()
+	} else {
+		div, err := Uint384FromVarName("div", ids, vm)
+		if err != nil {
+			return err
+		}
+		divPacked = div.Pack()
+	}
+	if divPacked.Sign() == 0 {
+		return errors.New("Attempted to divide by zero")
+	}
+	aPacked := a.Pack()
+	quotient, remainder := new(big.Int).DivMod(&aPacked, &divPacked, new(big.Int))
+	quotientLimbs := ToUint768(quotient)
+	err = quotientLimbs.InsertFromVarName("quotient", ids, vm)
+	if err != nil {
+		return err
+	}
+	remainderLimbs := ToUint384(remainder)
+	return remainderLimbs.InsertFromVarName("remainder", ids, vm)
+}
@@ -0,0 +1,209 @@
+package hints_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// 128 bit limb with all nibbles set to 5, (2**384 - 1) / 3 = (FIVES, FIVES, FIVES)
+var FIVES = "0x" + strings.Repeat("5", 32)
+
+func hexLimbs(values ...string) []*MaybeRelocatable {
+	limbs := make([]*MaybeRelocatable, 0, len(values))
+	for _, value := range values {
+		limbs = append(limbs, NewMaybeRelocatableFelt(FeltFromHex(value)))
+	}
+	return limbs
+}
+
+func hexFelts(values ...string) []Felt {
+	felts := make([]Felt, 0, len(values))
+	for _, value := range values {
+		felts = append(felts, FeltFromHex(value))
+	}
+	return felts
+}
+
+func runUint384Hint(code string, ids map[string][]*MaybeRelocatable) (*VirtualMachine, IdsManager, error) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	vm.RunContext.Ap = NewRelocatable(1, 0)
+	idsManager := SetupIdsForTest(ids, vm)
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: code,
+	})
+	hintProcessor := CairoVmHintProcessor{}
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	return vm, idsManager, err
+}
+
+func TestAddNoUint384Check(t *testing.T) {
+	vm, idsManager, err := runUint384Hint(ADD_NO_UINT384_CHECK, map[string][]*MaybeRelocatable{
+		"a":        hexLimbs("0xffffffffffffffffffffffffffffffff", "0x1", "0x0"),
+		"b":        hexLimbs("0x1", "0xffffffffffffffffffffffffffffffff", "0x5"),
+		"carry_d0": {nil},
+		"carry_d1": {nil},
+		"carry_d2": {nil},
+	})
+	if err != nil {
+		t.Fatalf("ADD_NO_UINT384_CHECK hint test failed with error %s", err)
+	}
+	for name, expected := range map[string]Felt{"carry_d0": FeltOne(), "carry_d1": FeltOne(), "carry_d2": FeltZero()} {
+		carry, err := idsManager.GetFelt(name, vm)
+		if err != nil || carry != expected {
+			t.Errorf("Wrong %s. Expected %s, got %s", name, expected.ToHexString(), carry.ToHexString())
+		}
+	}
+}
+
+func TestUint384SignedNN(t *testing.T) {
+	for d2, expected := range map[string]Felt{"0x1": FeltOne(), "0x80000000000000000000000000000000": FeltZero()} {
+		vm, _, err := runUint384Hint(UINT384_SIGNED_NN, map[string][]*MaybeRelocatable{
+			"a": hexLimbs("0x0", "0x0", d2),
+		})
+		if err != nil {
+			t.Fatalf("UINT384_SIGNED_NN hint test failed with error %s", err)
+		}
+		res, err := vm.Segments.Memory.GetFelt(vm.RunContext.Ap)
+		if err != nil || res != expected {
+			t.Errorf("Wrong result for d2 = %s. Expected %s, got %s", d2, expected.ToHexString(), res.ToHexString())
+		}
+	}
+}
+
+func TestUint384Split128(t *testing.T) {
+	vm, idsManager, err := runUint384Hint(UINT384_SPLIT_128, map[string][]*MaybeRelocatable{
+		"a":    hexLimbs("0x400000000000000000000000000000005"),
+		"low":  {nil},
+		"high": {nil},
+	})
+	if err != nil {
+		t.Fatalf("UINT384_SPLIT_128 hint test failed with error %s", err)
+	}
+	low, _ := idsManager.GetFelt("low", vm)
+	high, _ := idsManager.GetFelt("high", vm)
+	if low != FeltFromUint64(5) || high != FeltFromUint64(4) {
+		t.Errorf("Wrong split. Got low: %s, high: %s", low.ToHexString(), high.ToHexString())
+	}
+}
+
+func checkUint384(t *testing.T, name string, idsManager IdsManager, vm *VirtualMachine, expected []Felt) {
+	value, err := Uint384FromVarName(name, idsManager, vm)
+	if err != nil {
+		t.Fatalf("Failed to get %s: %s", name, err)
+	}
+	if !reflect.DeepEqual(value.Limbs, expected) {
+		t.Errorf("Wrong %s. Expected %v, got %v", name, expected, value.Limbs)
+	}
+}
+
+func TestUint384UnsignedDivRem(t *testing.T) {
+	vm, idsManager, err := runUint384Hint(UINT384_UNSIGNED_DIV_REM, map[string][]*MaybeRelocatable{
+		"a":         hexLimbs("0x0", "0x0", "0x1"),
+		"div":       hexLimbs("0x3", "0x0", "0x0"),
+		"quotient":  {nil, nil, nil},
+		"remainder": {nil, nil, nil},
+	})
+	if err != nil {
+		t.Fatalf("UINT384_UNSIGNED_DIV_REM hint test failed with error %s", err)
+	}
+	checkUint384(t, "quotient", idsManager, vm, hexFelts(FIVES, FIVES, "0x0"))
+	checkUint384(t, "remainder", idsManager, vm, hexFelts("0x1", "0x0", "0x0"))
+}
+
+func TestUint384UnsignedDivRemByZero(t *testing.T) {
+	_, _, err := runUint384Hint(UINT384_UNSIGNED_DIV_REM, map[string][]*MaybeRelocatable{
+		"a":         hexLimbs("0x1", "0x0", "0x0"),
+		"div":       hexLimbs("0x0", "0x0", "0x0"),
+		"quotient":  {nil, nil, nil},
+		"remainder": {nil, nil, nil},
+	})
+	if err == nil {
+		t.Errorf("UINT384_UNSIGNED_DIV_REM hint test should fail when dividing by zero")
+	}
+}
+
+func TestUint384UnsignedDivRemExpanded(t *testing.T) {
+	vm, idsManager, err := runUint384Hint(UINT384_UNSIGNED_DIV_REM_EXPANDED, map[string][]*MaybeRelocatable{
+		"a":         hexLimbs("0x0", "0x0", "0x1"),
+		"div":       hexLimbs("0x30000000000000000", "0x3", "0x0", "0x0", "0x0", "0x0", "0x0"),
+		"quotient":  {nil, nil, nil},
+		"remainder": {nil, nil, nil},
+	})
+	if err != nil {
+		t.Fatalf("UINT384_UNSIGNED_DIV_REM_EXPANDED hint test failed with error %s", err)
+	}
+	checkUint384(t, "quotient", idsManager, vm, hexFelts(FIVES, FIVES, "0x0"))
+	checkUint384(t, "remainder", idsManager, vm, hexFelts("0x1", "0x0", "0x0"))
+}
+
+func TestUint384Sqrt(t *testing.T) {
+	vm, idsManager, err := runUint384Hint(UINT384_SQRT, map[string][]*MaybeRelocatable{
+		"a":    hexLimbs("0x5", "0x0", "0x1"),
+		"root": {nil, nil, nil},
+	})
+	if err != nil {
+		t.Fatalf("UINT384_SQRT hint test failed with error %s", err)
+	}
+	checkUint384(t, "root", idsManager, vm, hexFelts("0x0", "0x1", "0x0"))
+}
+
+func TestUint384Div(t *testing.T) {
+	vm, idsManager, err := runUint384Hint(UINT384_DIV, map[string][]*MaybeRelocatable{
+		"a":               hexLimbs("0x2", "0x0", "0x0"),
+		"b":               hexLimbs("0x3", "0x0", "0x0"),
+		"p":               hexLimbs("0x7", "0x0", "0x0"),
+		"b_inverse_mod_p": {nil, nil, nil},
+	})
+	if err != nil {
+		t.Fatalf("UINT384_DIV hint test failed with error %s", err)
+	}
+	checkUint384(t, "b_inverse_mod_p", idsManager, vm, hexFelts("0x5", "0x0", "0x0"))
+}
+
+func TestUint384DivNotInvertible(t *testing.T) {
+	_, _, err := runUint384Hint(UINT384_DIV, map[string][]*MaybeRelocatable{
+		"a":               hexLimbs("0x2", "0x0", "0x0"),
+		"b":               hexLimbs("0x3", "0x0", "0x0"),
+		"p":               hexLimbs("0x9", "0x0", "0x0"),
+		"b_inverse_mod_p": {nil, nil, nil},
+	})
+	if err == nil {
+		t.Errorf("UINT384_DIV hint test should fail if b is not invertible modulo p")
+	}
+}
+
+func TestUnsignedDivRemUint768ByUint384(t *testing.T) {
+	divs := map[string][]*MaybeRelocatable{
+		UNSIGNED_DIV_REM_UINT768_BY_UINT384:          hexLimbs("0x3", "0x0", "0x0"),
+		UNSIGNED_DIV_REM_UINT768_BY_UINT384_STRIPPED: hexLimbs("0x3", "0x0", "0x0"),
+		UNSIGNED_DIV_REM_UINT768_BY_UINT384_EXPAND:   hexLimbs("0x30000000000000000", "0x3", "0x0", "0x0", "0x0", "0x0", "0x0"),
+	}
+	for code, div := range divs {
+		vm, idsManager, err := runUint384Hint(code, map[string][]*MaybeRelocatable{
+			"a":         hexLimbs("0x0", "0x0", "0x0", "0x1", "0x0", "0x0"),
+			"div":       div,
+			"quotient":  {nil, nil, nil, nil, nil, nil},
+			"remainder": {nil, nil, nil},
+		})
+		if err != nil {
+			t.Fatalf("UNSIGNED_DIV_REM_UINT768_BY_UINT384 hint test failed with error %s", err)
+		}
+		quotient, err := Uint768FromVarName("quotient", idsManager, vm)
+		expectedQuotient := hexFelts(FIVES, FIVES, FIVES, "0x0", "0x0", "0x0")
+		if err != nil || !reflect.DeepEqual(quotient.Limbs, expectedQuotient) {
+			t.Errorf("Wrong quotient. Expected %v, got %v", expectedQuotient, quotient.Limbs)
+		}
+		checkUint384(t, "remainder", idsManager, vm, hexFelts("0x1", "0x0", "0x0"))
+	}
+}
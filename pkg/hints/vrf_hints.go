@@ -0,0 +1,221 @@
+package hints
+
+import (
+	"math/big"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
+)
+
+/*
+Implements hint:
+%{
+    def split(num: int, num_bits_shift: int, length: int):
+        a = []
+        for _ in range(length):
+            a.append( num & ((1 << num_bits_shift) - 1) )
+            num = num >> num_bits_shift
+        return tuple(a)
+
+    def pack(z, num_bits_shift: int) -> int:
+        limbs = (z.low, z.high)
+        return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+    def pack_extended(z, num_bits_shift: int) -> int:
+        limbs = (z.d0, z.d1, z.d2, z.d3)
+        return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+    x = pack_extended(ids.x, num_bits_shift = 128)
+    div = pack(ids.div, num_bits_shift = 128)
+
+    quotient, remainder = divmod(x, div)
+
+    quotient_split = split(quotient, num_bits_shift=128, length=4)
+
+    ids.quotient.d0 = quotient_split[0]
+    ids.quotient.d1 = quotient_split[1]
+    ids.quotient.d2 = quotient_split[2]
+    ids.quotient.d3 = quotient_split[3]
+
+    remainder_split = split(remainder, num_bits_shift=128, length=2)
+    ids.remainder.low = remainder_split[0]
+    ids.remainder.high = remainder_split[1]
+%}
+*/
+
+func uint512UnsignedDivRem(ids IdsManager, vm *VirtualMachine) error {
+	x, err := Uint512FromVarName("x", ids, vm)
+	if err != nil {
+		return err
+	}
+	div, err := ids.GetUint256("div", vm)
+	if err != nil {
+		return err
+	}
+	xPacked := x.Pack()
+	divPacked := div.ToBigInt()
+	if divPacked.Sign() == 0 {
+		return errors.New("Attempted to divide by zero")
+	}
+	quotient, remainder := new(big.Int).DivMod(&xPacked, divPacked, new(big.Int))
+	quotientLimbs := ToUint512(quotient)
+	err = quotientLimbs.InsertFromVarName("quotient", ids, vm)
+	if err != nil {
+		return err
+	}
+	return ids.InsertUint256("remainder", ToUint256(remainder), vm)
+}
+
+/*
+Implements hint:
+%{
+    def pack_512(u, num_bits_shift: int) -> int:
+        limbs = (u.d0, u.d1, u.d2, u.d3)
+        return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+    x = pack_512(ids.x, num_bits_shift = 128)
+    p = ids.p.low + (ids.p.high << 128)
+    x_inverse_mod_p = pow(x,-1, p)
+
+    x_inverse_mod_p_split = (x_inverse_mod_p & ((1 << 128) - 1), x_inverse_mod_p >> 128)
+
+    ids.x_inverse_mod_p.low = x_inverse_mod_p_split[0]
+    ids.x_inverse_mod_p.high = x_inverse_mod_p_split[1]
+%}
+*/
+
+func invModPUint512(ids IdsManager, vm *VirtualMachine) error {
+	x, err := Uint512FromVarName("x", ids, vm)
+	if err != nil {
+		return err
+	}
+	p, err := ids.GetUint256("p", vm)
+	if err != nil {
+		return err
+	}
+	xPacked := x.Pack()
+	return insertInverseModP(&xPacked, p.ToBigInt(), "x_inverse_mod_p", ids, vm)
+}
+
+/*
+Implements hint:
+%{
+    from starkware.python.math_utils import div_mod
+
+    def split(a: int):
+        return (a & ((1 << 128) - 1), a >> 128)
+
+    def pack(z, num_bits_shift: int) -> int:
+        limbs = (z.low, z.high)
+        return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+    a = pack(ids.a, 128)
+    b = pack(ids.b, 128)
+    p = pack(ids.p, 128)
+    # For python3.8 and above the modular inverse can be computed as follows:
+    # b_inverse_mod_p = pow(b, -1, p)
+    # Instead we use the python3.7-friendly function div_mod from starkware.python.math_utils
+    b_inverse_mod_p = div_mod(1, b, p)
+
+    b_inverse_mod_p_split = split(b_inverse_mod_p)
+
+    ids.b_inverse_mod_p.low = b_inverse_mod_p_split[0]
+    ids.b_inverse_mod_p.high = b_inverse_mod_p_split[1]
+%}
+*/
+
+func invModPUint256(ids IdsManager, vm *VirtualMachine) error {
+	b, err := ids.GetUint256("b", vm)
+	if err != nil {
+		return err
+	}
+	p, err := ids.GetUint256("p", vm)
+	if err != nil {
+		return err
+	}
+	return insertInverseModP(b.ToBigInt(), p.ToBigInt(), "b_inverse_mod_p", ids, vm)
+}
+
+// Stores the inverse of x modulo p into the Uint256 identifier name
+func insertInverseModP(x *big.Int, p *big.Int, name string, ids IdsManager, vm *VirtualMachine) error {
+	if p.Sign() == 0 {
+		return errors.New("Attempted to divide by zero")
+	}
+	inverse, err := utils.DivMod(big.NewInt(1), x, p)
+	if err != nil {
+		return err
+	}
+	return ids.InsertUint256(name, ToUint256(inverse), vm)
+}
+
+/*
+Implements hints:
+%{
+    def split(num: int, num_bits_shift: int = 128, length: int = 2):
+        a = []
+        for _ in range(length):
+            a.append( num & ((1 << num_bits_shift) - 1) )
+            num = num >> num_bits_shift
+        return tuple(a)
+
+    def pack(z, num_bits_shift: int = 128) -> int:
+        limbs = (z.low, z.high)
+        return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+    a = pack(ids.a)
+    b = pack(ids.b)
+    p = pack(ids.p)
+
+    res = (a - b) % p
+
+    res_split = split(res)
+    ids.res.low = res_split[0]
+    ids.res.high = res_split[1]
+%}
+%{
+    def split(num: int, num_bits_shift: int = 128, length: int = 2):
+        a = []
+        for _ in range(length):
+            a.append( num & ((1 << num_bits_shift) - 1) )
+            num = num >> num_bits_shift
+        return tuple(a)
+
+    def pack(z, num_bits_shift: int = 128) -> int:
+        limbs = (z.low, z.high)
+        return sum(limb << (num_bits_shift * i) for i, limb in enumerate(limbs))
+
+    p = pack(ids.p)
+    a = pack(ids.a) % p
+    b = pack(ids.b) % p
+
+    res = (a - b) % p
+
+    res_split = split(res)
+    ids.res.low = res_split[0]
+    ids.res.high = res_split[1]
+%}
+Reducing a and b first doesn't change the result, so both hints share this implementation
+*/
+
+func uint256SubModP(ids IdsManager, vm *VirtualMachine) error {
+	a, err := ids.GetUint256("a", vm)
+	if err != nil {
+		return err
+	}
+	b, err := ids.GetUint256("b", vm)
+	if err != nil {
+		return err
+	}
+	p, err := ids.GetUint256("p", vm)
+	if err != nil {
+		return err
+	}
+	pPacked := p.ToBigInt()
+	if pPacked.Sign() == 0 {
+		return errors.New("Attempted to divide by zero")
+	}
+	res := new(big.Int).Mod(new(big.Int).Sub(a.ToBigInt(), b.ToBigInt()), pPacked)
+	return ids.InsertUint256("res", ToUint256(res), vm)
+}
@@ -0,0 +1,112 @@
+package hints_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// 2**255 - 19, split into its low and high 128 bit limbs
+var ED25519_P_LIMBS = []string{"0xffffffffffffffffffffffffffffffed", "0x7fffffffffffffffffffffffffffffff"}
+
+func checkUint512(t *testing.T, name string, idsManager IdsManager, vm *VirtualMachine, expected []Felt) {
+	value, err := Uint512FromVarName(name, idsManager, vm)
+	if err != nil {
+		t.Fatalf("Failed to get %s: %s", name, err)
+	}
+	if !reflect.DeepEqual(value.Limbs, expected) {
+		t.Errorf("Wrong %s. Expected %v, got %v", name, expected, value.Limbs)
+	}
+}
+
+func checkUint256(t *testing.T, name string, idsManager IdsManager, vm *VirtualMachine, expected []Felt) {
+	value, err := idsManager.GetUint256(name, vm)
+	if err != nil {
+		t.Fatalf("Failed to get %s: %s", name, err)
+	}
+	if value.Low != expected[0] || value.High != expected[1] {
+		t.Errorf("Wrong %s. Expected %v, got %s", name, expected, value.ToString())
+	}
+}
+
+func TestUint512UnsignedDivRem(t *testing.T) {
+	vm, idsManager, err := runUint384Hint(UINT512_UNSIGNED_DIV_REM, map[string][]*MaybeRelocatable{
+		"x":         hexLimbs("0x3039", "0x0", "0x0", "0x100000000000000000000000000000"),
+		"div":       hexLimbs("0x7", "0x3"),
+		"quotient":  {nil, nil, nil, nil},
+		"remainder": {nil, nil},
+	})
+	if err != nil {
+		t.Fatalf("UINT512_UNSIGNED_DIV_REM hint test failed with error %s", err)
+	}
+	checkUint512(t, "quotient", idsManager, vm, hexFelts("0x390097b425ed097b425ed097b425ed09", "0x5548e38e38e38e38e38e38e38e38e38e", "0x55555555555555555555555555555", "0x0"))
+	checkUint256(t, "remainder", idsManager, vm, hexFelts("0x70fbda12f684bda12f684bda12f6b4fa", "0x1"))
+}
+
+func TestUint512UnsignedDivRemDivisionByZero(t *testing.T) {
+	_, _, err := runUint384Hint(UINT512_UNSIGNED_DIV_REM, map[string][]*MaybeRelocatable{
+		"x":         hexLimbs("0x1", "0x0", "0x0", "0x0"),
+		"div":       hexLimbs("0x0", "0x0"),
+		"quotient":  {nil, nil, nil, nil},
+		"remainder": {nil, nil},
+	})
+	if err == nil {
+		t.Errorf("UINT512_UNSIGNED_DIV_REM hint test should have failed")
+	}
+}
+
+func TestInvModPUint512(t *testing.T) {
+	vm, idsManager, err := runUint384Hint(INV_MOD_P_UINT512, map[string][]*MaybeRelocatable{
+		"x":               hexLimbs("0x3039", "0x0", "0x0", "0x100000000000000000000000000000"),
+		"p":               hexLimbs("0x7", "0x3"),
+		"x_inverse_mod_p": {nil, nil},
+	})
+	if err != nil {
+		t.Fatalf("INV_MOD_P_UINT512 hint test failed with error %s", err)
+	}
+	checkUint256(t, "x_inverse_mod_p", idsManager, vm, hexFelts("0xe71c0bda6d1986213260910a9029a99b", "0x2"))
+}
+
+func TestInvModPUint512NotInvertible(t *testing.T) {
+	_, _, err := runUint384Hint(INV_MOD_P_UINT512, map[string][]*MaybeRelocatable{
+		"x":               hexLimbs("0x6", "0x0", "0x0", "0x0"),
+		"p":               hexLimbs("0x9", "0x0"),
+		"x_inverse_mod_p": {nil, nil},
+	})
+	if err == nil {
+		t.Errorf("INV_MOD_P_UINT512 hint test should have failed")
+	}
+}
+
+func TestInvModPUint256(t *testing.T) {
+	vm, idsManager, err := runUint384Hint(INV_MOD_P_UINT256, map[string][]*MaybeRelocatable{
+		"a":               hexLimbs("0x1", "0x0"),
+		"b":               hexLimbs("0x3", "0x0"),
+		"p":               hexLimbs(ED25519_P_LIMBS...),
+		"b_inverse_mod_p": {nil, nil},
+	})
+	if err != nil {
+		t.Fatalf("INV_MOD_P_UINT256 hint test failed with error %s", err)
+	}
+	checkUint256(t, "b_inverse_mod_p", idsManager, vm, hexFelts("0x55555555555555555555555555555549", "0x55555555555555555555555555555555"))
+}
+
+func TestUint256SubModP(t *testing.T) {
+	for _, code := range []string{UINT256_SUB_MOD_P, UINT256_SUB_REDUCED_MOD_P} {
+		vm, idsManager, err := runUint384Hint(code, map[string][]*MaybeRelocatable{
+			"a":   hexLimbs("0x5", "0x0"),
+			"b":   hexLimbs("0x7", "0x0"),
+			"p":   hexLimbs(ED25519_P_LIMBS...),
+			"res": {nil, nil},
+		})
+		if err != nil {
+			t.Fatalf("Hint %s failed with error %s", code, err)
+		}
+		checkUint256(t, "res", idsManager, vm, hexFelts("0xffffffffffffffffffffffffffffffeb", "0x7fffffffffffffffffffffffffffffff"))
+	}
+}
@@ -2,10 +2,13 @@ package parser
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
+	"io"
 	"math/big"
 	"os"
+	"strings"
 
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/pkg/errors"
 )
 
@@ -89,23 +92,90 @@ func ParserError(err error) error {
 	return errors.Wrapf(err, "Parser error\n")
 }
 
+var ErrInvalidProgram = errors.New("Invalid compiled program")
+
+func invalidProgramError(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidProgram, reason)
+}
+
+// Reads and validates the compiled program in the given file
 func Parse(jsonPath string) (CompiledJson, error) {
 	jsonFile, err := os.Open(jsonPath)
-
 	if err != nil {
-		return CompiledJson{}, nil
+		return CompiledJson{}, ParserError(err)
 	}
 	defer jsonFile.Close()
+	return ParseReader(jsonFile)
+}
 
-	var cJson CompiledJson
-
-	byteValue, _ := ioutil.ReadAll(jsonFile)
-	err = json.Unmarshal(byteValue, &cJson)
-
+// Reads and validates a compiled program, such as one received over the network
+func ParseReader(reader io.Reader) (CompiledJson, error) {
+	byteValue, err := io.ReadAll(reader)
 	if err != nil {
 		return CompiledJson{}, ParserError(err)
 	}
+	return ParseBytes(byteValue)
+}
 
+// Parses and validates a compiled program, such as one embedded in a binary
+func ParseBytes(data []byte) (CompiledJson, error) {
+	var cJson CompiledJson
+	err := json.Unmarshal(data, &cJson)
+	if err != nil {
+		return CompiledJson{}, ParserError(err)
+	}
+	err = cJson.Validate()
+	if err != nil {
+		return CompiledJson{}, ParserError(err)
+	}
 	return cJson, nil
+}
+
+// Checks that an entry of the program's data is a 0x-prefixed hex felt, as FeltFromHex can't decode other values
+func checkDataValue(index int, value string) error {
+	digits, found := strings.CutPrefix(value, "0x")
+	if !found || digits == "" || strings.Trim(digits, "0123456789abcdefABCDEF") != "" {
+		return invalidProgramError(fmt.Sprintf("invalid data[%d] %q, expected a 0x-prefixed hex value", index, value))
+	}
+	// The prime has 63 hex digits, so shorter values are below it
+	if len(digits) >= 63 {
+		felt, _ := new(big.Int).SetString(digits, 16)
+		if felt.Cmp(lambdaworks.Prime()) >= 0 {
+			return invalidProgramError(fmt.Sprintf("invalid data[%d] %s, expected a value below the prime", index, value))
+		}
+	}
+	return nil
+}
 
+// Checks that the program has the fields needed to run it: the prime of the VM's field, its data as hex felts, its
+// main scope and its main function
+func (c *CompiledJson) Validate() error {
+	return c.validate(len(c.Data))
+}
+
+// Same as Validate, with the size of the program's data given apart, as the streaming decoder doesn't keep it in Data
+func (c *CompiledJson) validate(dataSize int) error {
+	if c.Prime == "" {
+		return invalidProgramError("missing prime")
+	}
+	prime, ok := new(big.Int).SetString(strings.ToLower(c.Prime), 0)
+	if !ok || prime.Cmp(lambdaworks.Prime()) != 0 {
+		return invalidProgramError(fmt.Sprintf("unsupported prime %s, expected %s", c.Prime, lambdaworks.CAIRO_PRIME_HEX))
+	}
+	if dataSize == 0 {
+		return invalidProgramError("missing data")
+	}
+	for i, value := range c.Data {
+		err := checkDataValue(i, value)
+		if err != nil {
+			return err
+		}
+	}
+	if c.MainScope == "" {
+		return invalidProgramError("missing main_scope")
+	}
+	if _, ok := c.Identifiers["__main__.main"]; !ok {
+		return invalidProgramError("missing __main__.main identifier")
+	}
+	return nil
 }
@@ -1,7 +1,9 @@
 package parser_test
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
@@ -40,3 +42,90 @@ func TestData(t *testing.T) {
 		t.Errorf("We should have this data %s, got %s", expected, got.Data)
 	}
 }
+
+// A program whose main function returns right away
+const returnProgram = `{
+	"builtins": [],
+	"data": ["0x208b7fff7fff7ffe"],
+	"hints": {},
+	"identifiers": {"__main__.main": {"decorators": [], "pc": 0, "type": "function"}},
+	"main_scope": "__main__",
+	"prime": "0x800000000000011000000000000000000000000000000000000000000000001",
+	"reference_manager": {"references": []}
+}`
+
+func TestParseBytes(t *testing.T) {
+	program, err := parser.ParseBytes([]byte(returnProgram))
+	if err != nil {
+		t.Fatalf("ParseBytes failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(program.Data, []string{"0x208b7fff7fff7ffe"}) || program.MainScope != "__main__" {
+		t.Errorf("Wrong program: %+v", program)
+	}
+}
+
+func TestParseBytesInvalidPrograms(t *testing.T) {
+	invalidPrograms := map[string]string{
+		"missing prime":      strings.Replace(returnProgram, `"prime": "0x800000000000011000000000000000000000000000000000000000000000001"`, `"prime": ""`, 1),
+		"other prime":        strings.Replace(returnProgram, `"prime": "0x800000000000011000000000000000000000000000000000000000000000001"`, `"prime": "0x7"`, 1),
+		"missing data":       strings.Replace(returnProgram, `"data": ["0x208b7fff7fff7ffe"]`, `"data": []`, 1),
+		"missing main scope": strings.Replace(returnProgram, `"main_scope": "__main__"`, `"main_scope": ""`, 1),
+		"missing main":       strings.Replace(returnProgram, `"__main__.main"`, `"__main__.other"`, 1),
+		"decimal data":       withData("10"),
+		"unprefixed data":    withData("208b7fff7fff7ffe"),
+		"empty hex data":     withData("0x"),
+		"non hex data":       withData("0xzz"),
+		"signed data":        withData("0x-1"),
+		"data of the prime":  withData("0x800000000000011000000000000000000000000000000000000000000000001"),
+	}
+	for name, program := range invalidPrograms {
+		_, err := parser.ParseBytes([]byte(program))
+		if !errors.Is(err, parser.ErrInvalidProgram) {
+			t.Errorf("Expected ErrInvalidProgram for a program with %s, got: %v", name, err)
+		}
+	}
+	_, err := parser.ParseBytes([]byte(`{"data": `))
+	if err == nil {
+		t.Errorf("ParseBytes should fail for invalid json")
+	}
+}
+
+// Returns returnProgram with the given value added as the second entry of its data
+func withData(value string) string {
+	return strings.Replace(returnProgram, `"data": ["0x208b7fff7fff7ffe"]`, `"data": ["0x208b7fff7fff7ffe", "`+value+`"]`, 1)
+}
+
+func TestParseBytesDataValues(t *testing.T) {
+	_, err := parser.ParseBytes([]byte(withData("0x800000000000011000000000000000000000000000000000000000000000000")))
+	if err != nil {
+		t.Errorf("ParseBytes should accept the largest felt, got: %s", err)
+	}
+	_, err = parser.ParseBytes([]byte(withData("0xABCdef")))
+	if err != nil {
+		t.Errorf("ParseBytes should accept mixed case hex, got: %s", err)
+	}
+	_, err = parser.ParseBytes([]byte(withData("0xzz")))
+	if err == nil || !strings.Contains(err.Error(), "data[1]") {
+		t.Errorf("Expected an error reporting data[1], got: %v", err)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func TestParseReaderReadError(t *testing.T) {
+	_, err := parser.ParseReader(failingReader{})
+	if err == nil || !strings.Contains(err.Error(), "read failed") {
+		t.Errorf("Expected the read error, got: %v", err)
+	}
+}
+
+func TestParseMissingFile(t *testing.T) {
+	_, err := parser.Parse("missing_program.json")
+	if err == nil {
+		t.Errorf("Parse should fail for a missing file")
+	}
+}
@@ -0,0 +1,276 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/pkg/errors"
+)
+
+// How the streaming decoder handles the debug_info of a program
+type DebugInfoMode int
+
+const (
+	// Decode the whole debug info, including the contents of the source files
+	DebugInfoFull DebugInfoMode = iota
+	// Only decode the instruction locations, which the VM uses to report errors, skipping the source files
+	DebugInfoInstructionLocations
+	// Skip the debug info
+	DebugInfoSkip
+	// Skip the debug info, and remember where it is so that it can be decoded later with LoadDebugInfo.
+	// Requires the input to be a file or an io.ReaderAt
+	DebugInfoLazy
+)
+
+var ErrLazyDebugInfoUnsupported = errors.New("Lazy debug info requires an io.ReaderAt input")
+
+type StreamOptions struct {
+	DebugInfo DebugInfoMode
+	// Called after each read from the input, with the amount of bytes read so far. Nil if progress isn't reported
+	Progress func(bytesRead int64)
+}
+
+// A program decoded by the streaming decoder. Its data is decoded into felts instead of being kept in
+// CompiledJson.Data, and its DebugInfo is only set as requested by the StreamOptions
+type StreamedProgram struct {
+	CompiledJson
+	DataFelts []lambdaworks.Felt
+	// Where the debug info is, for DebugInfoLazy. Source is nil if there is no debug info to load
+	debugInfoSource io.ReaderAt
+	debugInfoPath   string
+	debugInfoStart  int64
+	debugInfoEnd    int64
+}
+
+// Decodes the compiled program in the given file without reading it whole into memory, see ParseStream
+func ParseFileStream(jsonPath string, options StreamOptions) (*StreamedProgram, error) {
+	jsonFile, err := os.Open(jsonPath)
+	if err != nil {
+		return nil, ParserError(err)
+	}
+	defer jsonFile.Close()
+	program, err := ParseStream(jsonFile, options)
+	if err != nil {
+		return nil, err
+	}
+	if program.debugInfoSource != nil {
+		// The file is reopened when the debug info is loaded
+		program.debugInfoSource = nil
+		program.debugInfoPath = jsonPath
+	}
+	return program, nil
+}
+
+// Decodes and validates a compiled program as it is read, instead of reading it whole into memory first.
+// The data is decoded directly into felts, and the debug info, which holds most of the size of big programs, can be
+// skipped or loaded later. For DebugInfoLazy, the reader must be an io.ReaderAt read from its start, and must stay
+// readable until the debug info is loaded
+func ParseStream(reader io.Reader, options StreamOptions) (*StreamedProgram, error) {
+	program := &StreamedProgram{}
+	if options.DebugInfo == DebugInfoLazy {
+		readerAt, ok := reader.(io.ReaderAt)
+		if !ok {
+			return nil, ParserError(ErrLazyDebugInfoUnsupported)
+		}
+		program.debugInfoSource = readerAt
+	}
+	if options.Progress != nil {
+		reader = &progressReader{reader: reader, progress: options.Progress}
+	}
+	decoder := json.NewDecoder(reader)
+	err := program.decode(decoder, options.DebugInfo)
+	if err != nil {
+		return nil, ParserError(err)
+	}
+	if program.debugInfoEnd == 0 {
+		program.debugInfoSource = nil
+	}
+	err = program.validate(len(program.DataFelts))
+	if err != nil {
+		return nil, ParserError(err)
+	}
+	return program, nil
+}
+
+func (p *StreamedProgram) decode(decoder *json.Decoder, debugInfoMode DebugInfoMode) error {
+	err := expectDelim(decoder, '{')
+	if err != nil {
+		return err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		switch key {
+		case "data":
+			err = p.decodeData(decoder)
+		case "debug_info":
+			err = p.decodeDebugInfo(decoder, debugInfoMode)
+		case "attributes":
+			err = decoder.Decode(&p.Attributes)
+		case "builtins":
+			err = decoder.Decode(&p.Builtins)
+		case "compiler_version":
+			err = decoder.Decode(&p.CompilerVersion)
+		case "hints":
+			err = decoder.Decode(&p.Hints)
+		case "identifiers":
+			err = decoder.Decode(&p.Identifiers)
+		case "main_scope":
+			err = decoder.Decode(&p.MainScope)
+		case "prime":
+			err = decoder.Decode(&p.Prime)
+		case "reference_manager":
+			err = decoder.Decode(&p.ReferenceManager)
+		default:
+			err = skipValue(decoder)
+		}
+		if errors.Is(err, ErrInvalidProgram) {
+			return err
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Invalid %s", key)
+		}
+	}
+	return expectDelim(decoder, '}')
+}
+
+func (p *StreamedProgram) decodeData(decoder *json.Decoder) error {
+	err := expectDelim(decoder, '[')
+	if err != nil {
+		return err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		value, ok := token.(string)
+		if !ok {
+			return errors.Errorf("Expected a hex string, got %v", token)
+		}
+		err = checkDataValue(len(p.DataFelts), value)
+		if err != nil {
+			return err
+		}
+		p.DataFelts = append(p.DataFelts, lambdaworks.FeltFromHex(value))
+	}
+	return expectDelim(decoder, ']')
+}
+
+func (p *StreamedProgram) decodeDebugInfo(decoder *json.Decoder, debugInfoMode DebugInfoMode) error {
+	switch debugInfoMode {
+	case DebugInfoFull:
+		return decoder.Decode(&p.DebugInfo)
+	case DebugInfoSkip:
+		return skipValue(decoder)
+	case DebugInfoLazy:
+		// The offset is right after the key, so the loaded section starts with the colon
+		p.debugInfoStart = decoder.InputOffset()
+		err := skipValue(decoder)
+		p.debugInfoEnd = decoder.InputOffset()
+		return err
+	}
+
+	token, err := decoder.Token()
+	if err != nil || token == nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return errors.Errorf("Expected an object, got %v", token)
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if token == "instruction_locations" {
+			err = decoder.Decode(&p.DebugInfo.InstructionLocation)
+		} else {
+			err = skipValue(decoder)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, '}')
+}
+
+// Decodes the debug info of a program parsed with DebugInfoLazy. Programs parsed with other modes return the debug
+// info they decoded
+func (p *StreamedProgram) LoadDebugInfo() (DebugInfo, error) {
+	source := p.debugInfoSource
+	if p.debugInfoPath != "" {
+		debugInfoFile, err := os.Open(p.debugInfoPath)
+		if err != nil {
+			return DebugInfo{}, ParserError(err)
+		}
+		defer debugInfoFile.Close()
+		source = debugInfoFile
+	}
+	if source == nil {
+		return p.DebugInfo, nil
+	}
+	section := make([]byte, p.debugInfoEnd-p.debugInfoStart)
+	_, err := source.ReadAt(section, p.debugInfoStart)
+	if err != nil && err != io.EOF {
+		return DebugInfo{}, ParserError(err)
+	}
+	section = bytes.TrimLeft(section, " \t\r\n:")
+	var debugInfo DebugInfo
+	err = json.Unmarshal(section, &debugInfo)
+	if err != nil {
+		return DebugInfo{}, ParserError(errors.Wrapf(err, "Invalid debug_info"))
+	}
+	return debugInfo, nil
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return errors.Errorf("Expected %s, got %v", delim, token)
+	}
+	return nil
+}
+
+// Skips the next value of the decoder token by token, without keeping it in memory
+func skipValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// Reports the amount of bytes read from the wrapped reader
+type progressReader struct {
+	reader    io.Reader
+	bytesRead int64
+	progress  func(bytesRead int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.bytesRead += int64(n)
+	r.progress(r.bytesRead)
+	return n, err
+}
@@ -0,0 +1,173 @@
+package parser_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+)
+
+// returnProgram with a debug info and a field the parser doesn't know
+var returnProgramWithDebugInfo = strings.Replace(returnProgram, `"builtins": [],`, `"builtins": [],
+	"attributes": [],
+	"compiler_version": "0.11.0",
+	"debug_info": {
+		"file_contents": {"<start>": "__start__:\ncall main\n"},
+		"instruction_locations": {
+			"0": {
+				"accessible_scopes": ["__main__", "__main__.main"],
+				"flow_tracking_data": {"ap_tracking": {"group": 0, "offset": 0}, "reference_ids": {}},
+				"hints": [],
+				"inst": {"end_col": 14, "end_line": 2, "input_file": {"filename": "return.cairo"}, "start_col": 5, "start_line": 2}
+			}
+		}
+	},
+	"unknown_field": {"nested": [1, {"a": null}]},`, 1)
+
+func TestParseStreamFullDebugInfo(t *testing.T) {
+	expected, err := parser.ParseBytes([]byte(returnProgramWithDebugInfo))
+	if err != nil {
+		t.Fatalf("ParseBytes failed with error: %s", err)
+	}
+	program, err := parser.ParseStream(strings.NewReader(returnProgramWithDebugInfo), parser.StreamOptions{})
+	if err != nil {
+		t.Fatalf("ParseStream failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(program.DataFelts, []lambdaworks.Felt{lambdaworks.FeltFromHex("0x208b7fff7fff7ffe")}) {
+		t.Errorf("Wrong data: %v", program.DataFelts)
+	}
+	program.Data = expected.Data
+	if !reflect.DeepEqual(program.CompiledJson, expected) {
+		t.Errorf("Streamed program differs.\nExpected: %+v\nGot: %+v", expected, program.CompiledJson)
+	}
+}
+
+func TestParseStreamInstructionLocations(t *testing.T) {
+	program, err := parser.ParseStream(strings.NewReader(returnProgramWithDebugInfo), parser.StreamOptions{DebugInfo: parser.DebugInfoInstructionLocations})
+	if err != nil {
+		t.Fatalf("ParseStream failed with error: %s", err)
+	}
+	if program.DebugInfo.FileContents != nil {
+		t.Errorf("The file contents should be skipped, got: %v", program.DebugInfo.FileContents)
+	}
+	if program.DebugInfo.InstructionLocation["0"].Inst.StartLine != 2 {
+		t.Errorf("Wrong instruction locations: %+v", program.DebugInfo.InstructionLocation)
+	}
+}
+
+func TestParseStreamSkipDebugInfo(t *testing.T) {
+	program, err := parser.ParseStream(strings.NewReader(returnProgramWithDebugInfo), parser.StreamOptions{DebugInfo: parser.DebugInfoSkip})
+	if err != nil {
+		t.Fatalf("ParseStream failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(program.DebugInfo, parser.DebugInfo{}) {
+		t.Errorf("The debug info should be skipped, got: %+v", program.DebugInfo)
+	}
+	debugInfo, err := program.LoadDebugInfo()
+	if err != nil || !reflect.DeepEqual(debugInfo, parser.DebugInfo{}) {
+		t.Errorf("Expected no debug info to load, got: %+v, %v", debugInfo, err)
+	}
+}
+
+func TestParseStreamLazyDebugInfo(t *testing.T) {
+	expected, err := parser.ParseBytes([]byte(returnProgramWithDebugInfo))
+	if err != nil {
+		t.Fatalf("ParseBytes failed with error: %s", err)
+	}
+	program, err := parser.ParseStream(bytes.NewReader([]byte(returnProgramWithDebugInfo)), parser.StreamOptions{DebugInfo: parser.DebugInfoLazy})
+	if err != nil {
+		t.Fatalf("ParseStream failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(program.DebugInfo, parser.DebugInfo{}) {
+		t.Errorf("The debug info should not be loaded yet, got: %+v", program.DebugInfo)
+	}
+	debugInfo, err := program.LoadDebugInfo()
+	if err != nil {
+		t.Fatalf("LoadDebugInfo failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(debugInfo, expected.DebugInfo) {
+		t.Errorf("Wrong debug info.\nExpected: %+v\nGot: %+v", expected.DebugInfo, debugInfo)
+	}
+}
+
+func TestParseFileStreamLazyDebugInfo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "return.json")
+	err := os.WriteFile(path, []byte(returnProgramWithDebugInfo), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	program, err := parser.ParseFileStream(path, parser.StreamOptions{DebugInfo: parser.DebugInfoLazy})
+	if err != nil {
+		t.Fatalf("ParseFileStream failed with error: %s", err)
+	}
+	debugInfo, err := program.LoadDebugInfo()
+	if err != nil {
+		t.Fatalf("LoadDebugInfo failed with error: %s", err)
+	}
+	if debugInfo.FileContents["<start>"] != "__start__:\ncall main\n" || len(debugInfo.InstructionLocation) != 1 {
+		t.Errorf("Wrong debug info: %+v", debugInfo)
+	}
+}
+
+func TestParseStreamLazyDebugInfoNeedsReaderAt(t *testing.T) {
+	_, err := parser.ParseStream(strings.NewReader(returnProgram), parser.StreamOptions{DebugInfo: parser.DebugInfoLazy})
+	if err != nil {
+		t.Fatalf("strings.Reader implements io.ReaderAt, got error: %s", err)
+	}
+	_, err = parser.ParseStream(failingReader{}, parser.StreamOptions{DebugInfo: parser.DebugInfoLazy})
+	if !errors.Is(err, parser.ErrLazyDebugInfoUnsupported) {
+		t.Errorf("Expected ErrLazyDebugInfoUnsupported, got: %v", err)
+	}
+}
+
+func TestParseStreamProgress(t *testing.T) {
+	var reported []int64
+	_, err := parser.ParseStream(strings.NewReader(returnProgramWithDebugInfo), parser.StreamOptions{
+		Progress: func(bytesRead int64) { reported = append(reported, bytesRead) },
+	})
+	if err != nil {
+		t.Fatalf("ParseStream failed with error: %s", err)
+	}
+	if len(reported) == 0 || reported[len(reported)-1] != int64(len(returnProgramWithDebugInfo)) {
+		t.Errorf("Expected the progress to end at %d bytes, got: %v", len(returnProgramWithDebugInfo), reported)
+	}
+}
+
+func TestParseStreamInvalidPrograms(t *testing.T) {
+	invalidPrograms := map[string]string{
+		"other prime":       strings.Replace(returnProgram, `"prime": "0x800000000000011000000000000000000000000000000000000000000000001"`, `"prime": "0x7"`, 1),
+		"missing data":      strings.Replace(returnProgram, `"data": ["0x208b7fff7fff7ffe"]`, `"data": []`, 1),
+		"missing main":      strings.Replace(returnProgram, `"__main__.main"`, `"__main__.other"`, 1),
+		"decimal data":      withData("10"),
+		"non hex data":      withData("0xzz"),
+		"data of the prime": withData("0x800000000000011000000000000000000000000000000000000000000000001"),
+	}
+	for name, program := range invalidPrograms {
+		_, err := parser.ParseStream(strings.NewReader(program), parser.StreamOptions{})
+		if !errors.Is(err, parser.ErrInvalidProgram) {
+			t.Errorf("Expected ErrInvalidProgram for a program with %s, got: %v", name, err)
+		}
+	}
+	_, err := parser.ParseStream(strings.NewReader(withData("0xzz")), parser.StreamOptions{})
+	if err == nil || !strings.Contains(err.Error(), "invalid data[1]") {
+		t.Errorf("Expected an error reporting data[1], got: %v", err)
+	}
+	malformedPrograms := []string{
+		`{"data": `,
+		`{"data": [1]}`,
+		`["data"]`,
+		strings.Replace(returnProgram, `"main_scope": "__main__"`, `"main_scope": 1`, 1),
+	}
+	for _, program := range malformedPrograms {
+		_, err := parser.ParseStream(strings.NewReader(program), parser.StreamOptions{})
+		if err == nil {
+			t.Errorf("ParseStream should fail for %s", program)
+		}
+	}
+}
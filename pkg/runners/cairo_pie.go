@@ -0,0 +1,182 @@
+package runners
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/cairo_pie"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Performs the initialization step for re-running the execution stored in a Cairo PIE: on top of the usual
+// initialization, the pie's memory and builtin additional data are loaded, so that the values written by the
+// original run's hints are available. Returns the end pointer
+func (r *CairoRunner) InitializeFromCairoPie(pie *cairo_pie.CairoPie) (memory.Relocatable, error) {
+	if r.ProofMode {
+		return memory.Relocatable{}, errors.New("Cairo PIEs can't be run in proof mode")
+	}
+	err := r.InitializeBuiltins()
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
+	r.InitializeSegments()
+	end, err := r.initializeMainEntrypoint()
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
+	if pie.Metadata.ProgramSegment.Index != r.ProgramBase.SegmentIndex || pie.Metadata.ExecutionSegment.Index != r.executionBase.SegmentIndex {
+		return memory.Relocatable{}, errors.New("The Cairo PIE's segments don't match the runner's")
+	}
+
+	for _, builtin := range r.Vm.BuiltinRunners {
+		switch builtin := builtin.(type) {
+		case *builtins.OutputBuiltinRunner:
+			data, err := pie.OutputBuiltinAdditionalData()
+			if err != nil {
+				return memory.Relocatable{}, err
+			}
+			err = builtin.ExtendAdditionalData(data)
+			if err != nil {
+				return memory.Relocatable{}, err
+			}
+		case *builtins.SignatureBuiltinRunner:
+			signatures, err := pie.SignatureBuiltinAdditionalData()
+			if err != nil {
+				return memory.Relocatable{}, err
+			}
+			for address, signature := range signatures {
+				builtin.AddSignature(address, signature)
+			}
+		}
+	}
+
+	for range pie.Metadata.ExtraSegments {
+		r.Vm.Segments.AddSegment()
+	}
+	for _, cell := range pie.Memory {
+		value := cell.Value
+		err = r.Vm.Segments.Memory.Insert(cell.Address, &value)
+		if err != nil {
+			return memory.Relocatable{}, err
+		}
+	}
+	return end, r.initializeVM()
+}
+
+// Builds a Cairo PIE from a finished run: the runner's (unrelocated) memory, its segments, execution resources
+// and builtin additional data
+func (r *CairoRunner) GetCairoPie() (*cairo_pie.CairoPie, error) {
+	if !r.RunEnded {
+		return nil, errors.New("Tried to get the Cairo PIE before run ended")
+	}
+	if r.ProofMode || r.finalPc == nil {
+		return nil, errors.New("Cairo PIEs can only be obtained from runs started at the main entrypoint")
+	}
+
+	programData := make([]string, 0, len(r.Program.Data))
+	for _, value := range r.Program.Data {
+		felt, ok := value.GetFelt()
+		if !ok {
+			return nil, errors.New("Program data contains relocatable values")
+		}
+		programData = append(programData, felt.ToHexString())
+	}
+
+	segmentSizes := r.Vm.Segments.ComputeEffectiveSizes()
+	segmentInfo := func(segmentIndex int) cairo_pie.SegmentInfo {
+		return cairo_pie.SegmentInfo{Index: segmentIndex, Size: segmentSizes[uint(segmentIndex)]}
+	}
+
+	// The return fp is the first value of the main entrypoint's stack after the builtin bases
+	retFpAddr, err := r.initialFp.SubUint(2)
+	if err != nil {
+		return nil, err
+	}
+	retFp, err := r.Vm.Segments.Memory.GetRelocatable(retFpAddr)
+	if err != nil {
+		return nil, err
+	}
+	knownSegments := map[int]bool{
+		r.ProgramBase.SegmentIndex:   true,
+		r.executionBase.SegmentIndex: true,
+		retFp.SegmentIndex:           true,
+		r.finalPc.SegmentIndex:       true,
+	}
+
+	builtinSegments := make(map[string]cairo_pie.SegmentInfo, len(r.Vm.BuiltinRunners))
+	additionalData := make(map[string]json.RawMessage)
+	for _, builtin := range r.Vm.BuiltinRunners {
+		builtinSegments[builtin.Name()] = segmentInfo(builtin.Base().SegmentIndex)
+		knownSegments[builtin.Base().SegmentIndex] = true
+
+		var data json.RawMessage
+		var err error
+		switch builtin := builtin.(type) {
+		case *builtins.OutputBuiltinRunner:
+			data, err = cairo_pie.EncodeOutputBuiltinAdditionalData(builtin.GetAdditionalData())
+		case *builtins.SignatureBuiltinRunner:
+			data, err = cairo_pie.EncodeSignatureBuiltinAdditionalData(builtin.GetSignatures())
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		additionalData[builtin.Name()+"_builtin"] = data
+	}
+
+	extraSegments := make([]cairo_pie.SegmentInfo, 0)
+	for i := 0; i < int(r.Vm.Segments.Memory.NumSegments()); i++ {
+		if !knownSegments[i] {
+			extraSegments = append(extraSegments, segmentInfo(i))
+		}
+	}
+
+	cells := make([]cairo_pie.MemoryCell, 0, len(r.Vm.Segments.Memory.Data))
+	for address, value := range r.Vm.Segments.Memory.Data {
+		cells = append(cells, cairo_pie.MemoryCell{Address: address, Value: value})
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].Address.SegmentIndex != cells[j].Address.SegmentIndex {
+			return cells[i].Address.SegmentIndex < cells[j].Address.SegmentIndex
+		}
+		return cells[i].Address.Offset < cells[j].Address.Offset
+	})
+
+	executionResources, err := r.GetExecutionResources()
+	if err != nil {
+		return nil, err
+	}
+	builtinInstanceCounter := make(map[string]uint, len(executionResources.BuiltinsInstanceCounter))
+	for name, instances := range executionResources.BuiltinsInstanceCounter {
+		builtinInstanceCounter[name+"_builtin"] = instances
+	}
+
+	return &cairo_pie.CairoPie{
+		Metadata: cairo_pie.CairoPieMetadata{
+			Program: cairo_pie.StrippedProgram{
+				Data:     programData,
+				Builtins: r.Program.Builtins,
+				Main:     r.mainOffset,
+				Prime:    lambdaworks.CAIRO_PRIME_HEX,
+			},
+			ProgramSegment:   segmentInfo(r.ProgramBase.SegmentIndex),
+			ExecutionSegment: segmentInfo(r.executionBase.SegmentIndex),
+			RetFpSegment:     segmentInfo(retFp.SegmentIndex),
+			RetPcSegment:     segmentInfo(r.finalPc.SegmentIndex),
+			BuiltinSegments:  builtinSegments,
+			ExtraSegments:    extraSegments,
+		},
+		Memory:         cells,
+		AdditionalData: additionalData,
+		ExecutionResources: cairo_pie.ExecutionResources{
+			NSteps:                 executionResources.NSteps,
+			NMemoryHoles:           executionResources.NMemoryHoles,
+			BuiltinInstanceCounter: builtinInstanceCounter,
+		},
+		Version: map[string]string{"cairo_pie": cairo_pie.CAIRO_PIE_VERSION},
+	}, nil
+}
@@ -0,0 +1,210 @@
+package runners_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/cairo_pie"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// Program equivalent to:
+//
+//	func main{output_ptr: felt*}() {
+//	    tempvar value = 10;
+//	    assert [output_ptr] = value;
+//	    let output_ptr = output_ptr + 1;
+//	    return ();
+//	}
+func outputProgram() vm.Program {
+	data := []uint64{
+		0x480680017fff8000, 10, // [ap] = 10, ap++
+		0x400280007ffd7fff,    // [ap - 1] = [[fp - 3]]
+		0x482680017ffd8000, 1, // [ap] = [fp - 3] + 1, ap++
+		0x208b7fff7fff7ffe, // ret
+	}
+	programData := make([]memory.MaybeRelocatable, 0, len(data))
+	for _, value := range data {
+		programData = append(programData, *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(value)))
+	}
+	return vm.Program{
+		Data:        programData,
+		Builtins:    []string{builtins.OUTPUT_BUILTIN_NAME},
+		Identifiers: map[string]vm.Identifier{"__main__.main": {PC: 0, Type: "function"}},
+	}
+}
+
+func runOutputProgram(t *testing.T) *runners.CairoRunner {
+	runner, err := runners.NewCairoRunner(outputProgram(), "small", false)
+	if err != nil {
+		t.Fatalf("NewCairoRunner failed with error: %s", err)
+	}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Fatalf("Initialize failed with error: %s", err)
+	}
+	hintProcessor := hints.CairoVmHintProcessor{}
+	err = runner.RunUntilPC(end, &hintProcessor)
+	if err != nil {
+		t.Fatalf("RunUntilPC failed with error: %s", err)
+	}
+	err = runner.EndRun(false, false, &hintProcessor)
+	if err != nil {
+		t.Fatalf("EndRun failed with error: %s", err)
+	}
+	err = runner.ReadReturnValues()
+	if err != nil {
+		t.Fatalf("ReadReturnValues failed with error: %s", err)
+	}
+	return runner
+}
+
+func outputProgramPie(t *testing.T) *cairo_pie.CairoPie {
+	runner := runOutputProgram(t)
+	outputBuiltin := runner.Vm.BuiltinRunners[0].(*builtins.OutputBuiltinRunner)
+	err := outputBuiltin.AddPage(1, outputBuiltin.Base(), 1)
+	if err != nil {
+		t.Fatalf("AddPage failed with error: %s", err)
+	}
+	pie, err := runner.GetCairoPie()
+	if err != nil {
+		t.Fatalf("GetCairoPie failed with error: %s", err)
+	}
+	return pie
+}
+
+func TestGetCairoPie(t *testing.T) {
+	pie := outputProgramPie(t)
+	metadata := pie.Metadata
+	if len(metadata.Program.Data) != 6 || metadata.Program.Main != 0 || metadata.Program.Prime != lambdaworks.CAIRO_PRIME_HEX {
+		t.Errorf("Wrong pie program: %+v", metadata.Program)
+	}
+	expectedSegments := []cairo_pie.SegmentInfo{{Index: 0, Size: 6}, {Index: 1, Size: 5}, {Index: 3, Size: 0}, {Index: 4, Size: 0}}
+	segments := []cairo_pie.SegmentInfo{metadata.ProgramSegment, metadata.ExecutionSegment, metadata.RetFpSegment, metadata.RetPcSegment}
+	for i := range segments {
+		if segments[i] != expectedSegments[i] {
+			t.Errorf("Wrong segment. Expected %+v, got %+v", expectedSegments[i], segments[i])
+		}
+	}
+	if metadata.BuiltinSegments["output"] != (cairo_pie.SegmentInfo{Index: 2, Size: 1}) || len(metadata.ExtraSegments) != 0 {
+		t.Errorf("Wrong builtin or extra segments: %+v, %+v", metadata.BuiltinSegments, metadata.ExtraSegments)
+	}
+	if pie.ExecutionResources.NSteps != 4 || pie.ExecutionResources.BuiltinInstanceCounter["output_builtin"] != 1 {
+		t.Errorf("Wrong execution resources: %+v", pie.ExecutionResources)
+	}
+	if len(pie.Memory) != 12 {
+		t.Errorf("Wrong pie memory size: %d", len(pie.Memory))
+	}
+	outputData, err := pie.OutputBuiltinAdditionalData()
+	if err != nil || outputData.Pages[1] != (builtins.PublicMemoryPage{Start: 0, Size: 1}) {
+		t.Errorf("Wrong output builtin additional data: %+v, error: %v", outputData, err)
+	}
+}
+
+func TestGetCairoPieBeforeRunEnded(t *testing.T) {
+	runner, err := runners.NewCairoRunner(outputProgram(), "small", false)
+	if err != nil {
+		t.Fatalf("NewCairoRunner failed with error: %s", err)
+	}
+	_, err = runner.GetCairoPie()
+	if err == nil {
+		t.Errorf("GetCairoPie should fail before the run ended")
+	}
+}
+
+func TestCairoRunPie(t *testing.T) {
+	pie := outputProgramPie(t)
+	runner, err := cairo_run.CairoRunPie(pie, cairo_run.CairoRunConfig{Layout: "small", SecureRun: true})
+	if err != nil {
+		t.Fatalf("CairoRunPie failed with error: %s", err)
+	}
+	outputBuiltin := runner.Vm.BuiltinRunners[0].(*builtins.OutputBuiltinRunner)
+	if len(outputBuiltin.GetPages()) != 1 {
+		t.Errorf("The pie's output pages should have been loaded, got %v", outputBuiltin.GetPages())
+	}
+}
+
+func TestCairoRunPieInconsistentMemory(t *testing.T) {
+	pie := outputProgramPie(t)
+	for i, cell := range pie.Memory {
+		if cell.Address == memory.NewRelocatable(2, 0) {
+			pie.Memory[i].Value = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(11))
+		}
+	}
+	_, err := cairo_run.CairoRunPie(pie, cairo_run.CairoRunConfig{Layout: "small"})
+	if err == nil {
+		t.Errorf("CairoRunPie should fail if the pie's output doesn't match the program's")
+	}
+}
+
+func TestCairoRunPieInconsistentExecutionResources(t *testing.T) {
+	pie := outputProgramPie(t)
+	pie.ExecutionResources.NSteps = 5
+	_, err := cairo_run.CairoRunPie(pie, cairo_run.CairoRunConfig{Layout: "small"})
+	if err == nil {
+		t.Errorf("CairoRunPie should fail if the pie's execution resources don't match the run")
+	}
+}
+
+func TestCairoRunPieWithoutAdditionalData(t *testing.T) {
+	pie := outputProgramPie(t)
+	delete(pie.AdditionalData, "output_builtin")
+	runner, err := cairo_run.CairoRunPie(pie, cairo_run.CairoRunConfig{Layout: "small"})
+	if err != nil {
+		t.Fatalf("CairoRunPie failed with error: %s", err)
+	}
+	outputBuiltin := runner.Vm.BuiltinRunners[0].(*builtins.OutputBuiltinRunner)
+	if len(outputBuiltin.GetPages()) != 0 {
+		t.Errorf("The output builtin shouldn't have pages, got %v", outputBuiltin.GetPages())
+	}
+}
+
+func TestCairoRunPieInconsistentProgram(t *testing.T) {
+	pie := outputProgramPie(t)
+	pie.Metadata.Program.Data[1] = "0xb"
+	_, err := cairo_run.CairoRunPie(pie, cairo_run.CairoRunConfig{Layout: "small"})
+	if err == nil {
+		t.Errorf("CairoRunPie should fail if the pie's program doesn't match its memory")
+	}
+}
+
+func TestCairoPieWriteZip(t *testing.T) {
+	pie := outputProgramPie(t)
+	var zipFile bytes.Buffer
+	err := pie.WriteZip(&zipFile)
+	if err != nil {
+		t.Fatalf("WriteZip failed with error: %s", err)
+	}
+	readPie, err := cairo_pie.CairoPieFromBytes(zipFile.Bytes())
+	if err != nil {
+		t.Fatalf("CairoPieFromBytes failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(readPie.Memory, pie.Memory) || !reflect.DeepEqual(readPie.Metadata, pie.Metadata) ||
+		!reflect.DeepEqual(readPie.ExecutionResources, pie.ExecutionResources) || !reflect.DeepEqual(readPie.Version, pie.Version) {
+		t.Errorf("The written pie differs from the read one")
+	}
+	err = readPie.CheckCompatibility(pie)
+	if err != nil {
+		t.Errorf("The written pie should be compatible with the original: %s", err)
+	}
+}
+
+func TestGetReturnValues(t *testing.T) {
+	runner := runOutputProgram(t)
+	returnValues, err := runner.GetReturnValues()
+	if err != nil {
+		t.Fatalf("GetReturnValues failed with error: %s", err)
+	}
+	outputBase := runner.Vm.BuiltinRunners[0].Base()
+	expected := []memory.MaybeRelocatable{*memory.NewMaybeRelocatableRelocatable(outputBase.AddUint(1))}
+	if !reflect.DeepEqual(returnValues, expected) {
+		t.Errorf("Wrong return values. Expected %v, got %v", expected, returnValues)
+	}
+}
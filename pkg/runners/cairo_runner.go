@@ -1,7 +1,10 @@
 package runners
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
@@ -31,6 +34,9 @@ type CairoRunner struct {
 	execScopes            types.ExecutionScopes
 	ExecutionPublicMemory *[]uint
 	SegmentsFinalized     bool
+	// Hints compiled by the last hint processor the runner ran with, reused by the following runs with it
+	hintDataMap       map[uint][]any
+	hintDataProcessor vm.HintProcessor
 }
 
 func NewCairoRunner(program vm.Program, layoutName string, proofMode bool) (*CairoRunner, error) {
@@ -58,7 +64,7 @@ func NewCairoRunner(program vm.Program, layoutName string, proofMode bool) (*Cai
 	}
 
 	runner := CairoRunner{
-		Program:    program,
+		Program:    program.Shared(),
 		Vm:         *vm.NewVirtualMachine(),
 		mainOffset: main_offset,
 		ProofMode:  proofMode,
@@ -68,6 +74,11 @@ func NewCairoRunner(program vm.Program, layoutName string, proofMode bool) (*Cai
 	return &runner, nil
 }
 
+// Makes the program's input available to its hints, as the program_input scope variable (see cairo-run's --program_input)
+func (r *CairoRunner) SetProgramInput(programInput []byte) {
+	r.execScopes.AssignOrUpdateVariable("program_input", json.RawMessage(programInput))
+}
+
 // Performs the initialization step, returns the end pointer (pc upon which execution should stop)
 func (r *CairoRunner) Initialize() (memory.Relocatable, error) {
 	err := r.InitializeBuiltins()
@@ -116,6 +127,7 @@ func (r *CairoRunner) InitializeBuiltins() error {
 func (r *CairoRunner) InitializeSegments() {
 	// Program Segment
 	r.ProgramBase = r.Vm.Segments.AddSegment()
+	r.Vm.ProgramSegmentIndex = r.ProgramBase.SegmentIndex
 	// Execution Segment
 	r.executionBase = r.Vm.Segments.AddSegment()
 	// Builtin Segments
@@ -205,33 +217,50 @@ func (r *CairoRunner) initializeVM() error {
 	return r.Vm.Segments.Memory.ValidateExistingMemory()
 }
 
+// Compiles the hints of the program with the given hint processor. The hints are compiled once per program for
+// vm.CacheableHintProcessors (see vm.Program.HintDataMap), and once per runner for other processors.
+// The returned map is shared and must not be modified
 func (r *CairoRunner) BuildHintDataMap(hintProcessor vm.HintProcessor) (map[uint][]any, error) {
-	hintDataMap := make(map[uint][]any, 0)
-	for pc, hintsParams := range r.Program.Hints {
-		hintDatas := make([]any, 0, len(hintsParams))
-		for _, hintParam := range hintsParams {
-			data, err := hintProcessor.CompileHint(&hintParam, &r.Program.ReferenceManager)
-			if err != nil {
-				return nil, err
-			}
-			hintDatas = append(hintDatas, data)
-		}
-		hintDataMap[pc] = hintDatas
+	if r.hintDataMap != nil && sameHintProcessor(r.hintDataProcessor, hintProcessor) {
+		return r.hintDataMap, nil
 	}
-
+	hintDataMap, err := r.Program.HintDataMap(hintProcessor)
+	if err != nil {
+		return nil, err
+	}
+	r.hintDataMap = hintDataMap
+	r.hintDataProcessor = hintProcessor
 	return hintDataMap, nil
 }
 
+// Hint processors that can't be compared, such as structs holding maps, are never the same
+func sameHintProcessor(a vm.HintProcessor, b vm.HintProcessor) bool {
+	return a != nil && b != nil && reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
+
 func (r *CairoRunner) RunUntilPC(end memory.Relocatable, hintProcessor vm.HintProcessor) error {
+	return r.RunUntilPCWithContext(context.Background(), end, hintProcessor)
+}
+
+// Same as RunUntilPC, but stops the run with a *RunCancelledError once ctx is cancelled or its deadline passes.
+// The context is checked between steps, and passed to the hints of processors that implement vm.ContextHintProcessor,
+// so hints of other processors run to completion before the run stops
+func (r *CairoRunner) RunUntilPCWithContext(ctx context.Context, end memory.Relocatable, hintProcessor vm.HintProcessor) error {
 	hintDataMap, err := r.BuildHintDataMap(hintProcessor)
 	if err != nil {
 		return err
 	}
-	constants := r.Program.ExtractConstants()
-	for r.Vm.RunContext.Pc != end &&
-		(r.Vm.RunResources == nil || !r.Vm.RunResources.Consumed()) {
-		err := r.Vm.Step(hintProcessor, &hintDataMap, &constants, &r.execScopes)
+	constants := r.Program.Constants()
+	for i := uint(0); r.Vm.RunContext.Pc != end &&
+		(r.Vm.RunResources == nil || !r.Vm.RunResources.Consumed()); i++ {
+		if i%contextCheckInterval == 0 && ctx.Err() != nil {
+			return r.cancelledError(ctx)
+		}
+		err := r.Vm.StepWithContext(ctx, hintProcessor, &hintDataMap, &constants, &r.execScopes)
 		if err != nil {
+			if ctx.Err() != nil {
+				return r.cancelledError(ctx)
+			}
 			return err
 		}
 		if r.Vm.RunResources != nil {
@@ -245,6 +274,11 @@ func (r *CairoRunner) RunUntilPC(end memory.Relocatable, hintProcessor vm.HintPr
 }
 
 func (runner *CairoRunner) EndRun(disableTracePadding bool, disableFinalizeAll bool, hintProcessor vm.HintProcessor) error {
+	return runner.EndRunWithContext(context.Background(), disableTracePadding, disableFinalizeAll, hintProcessor)
+}
+
+// Same as EndRun, but stops the trace padding of proof mode runs once ctx is done, see RunUntilPCWithContext
+func (runner *CairoRunner) EndRunWithContext(ctx context.Context, disableTracePadding bool, disableFinalizeAll bool, hintProcessor vm.HintProcessor) error {
 	if runner.RunEnded {
 		return ErrRunnerCalledTwice
 	}
@@ -263,7 +297,7 @@ func (runner *CairoRunner) EndRun(disableTracePadding bool, disableFinalizeAll b
 
 	runner.Vm.Segments.ComputeEffectiveSizes()
 	if runner.ProofMode && !disableTracePadding {
-		err := runner.RunUntilNextPowerOfTwo(hintProcessor)
+		err := runner.RunForStepsWithContext(ctx, utils.NextPowOf2(runner.Vm.CurrentStep)-runner.Vm.CurrentStep, hintProcessor)
 		if err != nil {
 			return err
 		}
@@ -277,12 +311,12 @@ func (runner *CairoRunner) EndRun(disableTracePadding bool, disableFinalizeAll b
 				break
 			}
 
-			err = runner.RunForSteps(1, hintProcessor)
+			err = runner.RunForStepsWithContext(ctx, 1, hintProcessor)
 			if err != nil {
 				return err
 			}
 
-			err = runner.RunUntilNextPowerOfTwo(hintProcessor)
+			err = runner.RunUntilNextPowerOfTwoWithContext(ctx, hintProcessor)
 			if err != nil {
 				return err
 			}
@@ -311,7 +345,8 @@ func (r *CairoRunner) FinalizeSegments() error {
 		publicMemory = append(publicMemory, i)
 	}
 
-	r.Vm.Segments.Finalize(size, uint(r.ProgramBase.SegmentIndex), &publicMemory)
+	programPublicMemory := memory.PublicMemoryOffsetsFromOffsets(publicMemory)
+	r.Vm.Segments.Finalize(size, uint(r.ProgramBase.SegmentIndex), &programPublicMemory)
 
 	publicMemory = make([]uint, 0)
 	execBase := r.executionBase
@@ -323,18 +358,19 @@ func (r *CairoRunner) FinalizeSegments() error {
 		publicMemory = append(publicMemory, elem+execBase.Offset)
 	}
 
-	r.Vm.Segments.Finalize(nil, uint(execBase.SegmentIndex), &publicMemory)
+	executionPublicMemory := memory.PublicMemoryOffsetsFromOffsets(publicMemory)
+	r.Vm.Segments.Finalize(nil, uint(execBase.SegmentIndex), &executionPublicMemory)
 	for _, builtin := range r.Vm.BuiltinRunners {
 		_, size, err := builtin.GetUsedCellsAndAllocatedSizes(&r.Vm.Segments, r.Vm.CurrentStep)
 		if err != nil {
 			return err
 		}
 
-		if builtin.Name() == builtins.OUTPUT_BUILTIN_NAME {
-			var publicMemory []uint
-			var i uint
-			for i = 0; i < size; i++ {
-				publicMemory = append(publicMemory, i)
+		if outputBuiltin, ok := builtin.(*builtins.OutputBuiltinRunner); ok {
+			// The output's public memory is split into the pages added by the program's hints
+			publicMemory, err := outputBuiltin.GetPublicMemory(&r.Vm.Segments)
+			if err != nil {
+				return err
 			}
 			r.Vm.Segments.Finalize(&size, uint(builtin.Base().SegmentIndex), &publicMemory)
 		} else {
@@ -386,6 +422,20 @@ func (r *CairoRunner) ReadReturnValues() error {
 
 }
 
+// Returns the values main returned, which are at the top of the stack once the run ended. As main returns the
+// pointers of the builtins it receives, these are the final pointers of the program's builtins
+func (r *CairoRunner) GetReturnValues() ([]memory.MaybeRelocatable, error) {
+	if !r.RunEnded {
+		return nil, errors.New("Tried to get return values before run ended")
+	}
+	returnValuesSize := uint(len(r.Program.Builtins))
+	start, err := r.Vm.RunContext.Ap.SubUint(returnValuesSize)
+	if err != nil {
+		return nil, err
+	}
+	return r.Vm.Segments.Memory.GetRange(start, returnValuesSize)
+}
+
 func (runner *CairoRunner) CheckUsedCells() error {
 	for _, builtin := range runner.Vm.BuiltinRunners {
 		// I guess we call this just in case it errors out, even though later on we also call it?
@@ -538,19 +588,30 @@ func (runner *CairoRunner) CheckRangeCheckUsage() error {
 }
 
 func (runner *CairoRunner) RunForSteps(steps uint, hintProcessor vm.HintProcessor) error {
+	return runner.RunForStepsWithContext(context.Background(), steps, hintProcessor)
+}
+
+// Same as RunForSteps, but stops the run once ctx is done, see RunUntilPCWithContext
+func (runner *CairoRunner) RunForStepsWithContext(ctx context.Context, steps uint, hintProcessor vm.HintProcessor) error {
 	hintDataMap, err := runner.BuildHintDataMap(hintProcessor)
 	if err != nil {
 		return err
 	}
-	constants := runner.Program.ExtractConstants()
+	constants := runner.Program.Constants()
 	var remainingSteps int
 	for remainingSteps = int(steps); remainingSteps > 0; remainingSteps-- {
 		if runner.finalPc != nil && *runner.finalPc == runner.Vm.RunContext.Pc {
 			return &vm.VirtualMachineError{Msg: fmt.Sprintf("EndOfProgram: %d", remainingSteps)}
 		}
+		if (int(steps)-remainingSteps)%contextCheckInterval == 0 && ctx.Err() != nil {
+			return runner.cancelledError(ctx)
+		}
 
-		err := runner.Vm.Step(hintProcessor, &hintDataMap, &constants, &runner.execScopes)
+		err := runner.Vm.StepWithContext(ctx, hintProcessor, &hintDataMap, &constants, &runner.execScopes)
 		if err != nil {
+			if ctx.Err() != nil {
+				return runner.cancelledError(ctx)
+			}
 			return err
 		}
 	}
@@ -559,11 +620,21 @@ func (runner *CairoRunner) RunForSteps(steps uint, hintProcessor vm.HintProcesso
 }
 
 func (runner *CairoRunner) RunUntilSteps(steps uint, hintProcessor vm.HintProcessor) error {
-	return runner.RunForSteps(steps-runner.Vm.CurrentStep, hintProcessor)
+	return runner.RunUntilStepsWithContext(context.Background(), steps, hintProcessor)
+}
+
+// Same as RunUntilSteps, but stops the run once ctx is done
+func (runner *CairoRunner) RunUntilStepsWithContext(ctx context.Context, steps uint, hintProcessor vm.HintProcessor) error {
+	return runner.RunForStepsWithContext(ctx, steps-runner.Vm.CurrentStep, hintProcessor)
 }
 
 func (runner *CairoRunner) RunUntilNextPowerOfTwo(hintProcessor vm.HintProcessor) error {
-	return runner.RunUntilSteps(utils.NextPowOf2(runner.Vm.CurrentStep), hintProcessor)
+	return runner.RunUntilNextPowerOfTwoWithContext(context.Background(), hintProcessor)
+}
+
+// Same as RunUntilNextPowerOfTwo, but stops the run once ctx is done
+func (runner *CairoRunner) RunUntilNextPowerOfTwoWithContext(ctx context.Context, hintProcessor vm.HintProcessor) error {
+	return runner.RunUntilStepsWithContext(ctx, utils.NextPowOf2(runner.Vm.CurrentStep), hintProcessor)
 }
 
 func (runner *CairoRunner) GetExecutionResources() (ExecutionResources, error) {
@@ -596,6 +667,11 @@ If `verifySecure` is set to true, [verifySecureRunner] will be called to run ext
 Each arg can be either MaybeRelocatable, []MaybeRelocatable or [][]MaybeRelocatable
 */
 func (runner *CairoRunner) RunFromEntrypoint(entrypoint uint, args []any, hintProcessor vm.HintProcessor, runResources *vm.RunResources, verifySecure bool, programSegmentSize *uint) error {
+	return runner.RunFromEntrypointWithContext(context.Background(), entrypoint, args, hintProcessor, runResources, verifySecure, programSegmentSize)
+}
+
+// Same as RunFromEntrypoint, but stops the run once ctx is done, see RunUntilPCWithContext
+func (runner *CairoRunner) RunFromEntrypointWithContext(ctx context.Context, entrypoint uint, args []any, hintProcessor vm.HintProcessor, runResources *vm.RunResources, verifySecure bool, programSegmentSize *uint) error {
 	runner.Vm.RunResources = runResources
 	stack := make([]memory.MaybeRelocatable, 0)
 	for _, arg := range args {
@@ -614,11 +690,11 @@ func (runner *CairoRunner) RunFromEntrypoint(entrypoint uint, args []any, hintPr
 	if err != nil {
 		return err
 	}
-	err = runner.RunUntilPC(end, hintProcessor)
+	err = runner.RunUntilPCWithContext(ctx, end, hintProcessor)
 	if err != nil {
 		return err
 	}
-	err = runner.EndRun(false, false, hintProcessor)
+	err = runner.EndRunWithContext(ctx, false, false, hintProcessor)
 	if err != nil {
 		return err
 	}
@@ -3,10 +3,12 @@ package runners_test
 import (
 	"bytes"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
 	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_codes"
 	"github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
@@ -783,3 +785,50 @@ func TestRunFromEntryPointFibonacciNotEnoughSteps(t *testing.T) {
 	}
 
 }
+
+func TestConcurrentRunsOfSharedProgram(t *testing.T) {
+	program := vm.Program{
+		Data: []memory.MaybeRelocatable{
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex("0x480680017fff8000")),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex("0x10780017fff7fff")),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero().Sub(lambdaworks.FeltFromUint64(2))),
+		},
+		Identifiers: map[string]vm.Identifier{
+			"__main__.N": {Type: "const", Value: lambdaworks.FeltFromUint64(3)},
+		},
+		Hints: map[uint][]parser.HintParams{0: {{Code: hint_codes.VM_ENTER_SCOPE}}},
+	}.Shared()
+
+	results := make([]*runners.CairoRunner, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			runner, err := runners.NewCairoRunner(program, "plain", false)
+			if err != nil {
+				t.Errorf("NewCairoRunner error in test: %s", err)
+				return
+			}
+			_, err = runner.Initialize()
+			if err == nil {
+				err = runner.RunForSteps(20, &hints.CairoVmHintProcessor{})
+			}
+			if err != nil {
+				t.Errorf("Run failed with error: %s", err)
+				return
+			}
+			results[i] = runner
+		}(i)
+	}
+	wg.Wait()
+	for _, runner := range results[1:] {
+		if runner == nil || results[0] == nil {
+			return
+		}
+		if !reflect.DeepEqual(runner.Vm.Segments.Memory.Data, results[0].Vm.Segments.Memory.Data) {
+			t.Errorf("The concurrent runs of the same program differ")
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package runners
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ebfe/keccak"
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+	"github.com/pkg/errors"
+)
+
+// Hash function used to combine the program hash and the program output into a fact
+type FactHashFunction int
+
+const (
+	// SHARP-style fact: keccak(program_hash, output_root), where output_root is computed from the fact topology
+	FactHashKeccak FactHashFunction = iota
+	// pedersen(program_hash, compute_hash_on_elements(output))
+	FactHashPedersen
+	// poseidon(program_hash, poseidon_hash_many(output)), using the poseidon program hash
+	FactHashPoseidon
+)
+
+func FactHashFunctionFromString(name string) (FactHashFunction, error) {
+	switch name {
+	case "keccak":
+		return FactHashKeccak, nil
+	case "pedersen":
+		return FactHashPedersen, nil
+	case "poseidon":
+		return FactHashPoseidon, nil
+	default:
+		return FactHashKeccak, errors.Errorf("Unknown fact hash function: %s", name)
+	}
+}
+
+// Describes how the program output is split into pages and how these pages are arranged in a merkle-like tree
+// (see cairo-lang's FactTopology)
+type FactTopology struct {
+	TreeStructure []uint `json:"tree_structure"`
+	PageSizes     []uint `json:"page_sizes"`
+}
+
+type FactInfo struct {
+	ProgramHash   lambdaworks.Felt
+	ProgramOutput []lambdaworks.Felt
+	FactTopology  FactTopology
+	Fact          *big.Int
+}
+
+func FactError(err error) error {
+	return errors.Wrapf(err, "Fact computation error")
+}
+
+// Returns the values written to the output builtin's segment
+func (r *CairoRunner) GetProgramOutput() ([]lambdaworks.Felt, error) {
+	outputBuiltin, err := r.Vm.GetOutputBuiltin()
+	if err != nil {
+		return nil, FactError(err)
+	}
+	var outputSize uint
+	if outputBuiltin.StopPtr != nil {
+		outputSize = *outputBuiltin.StopPtr
+	} else {
+		r.Vm.Segments.ComputeEffectiveSizes()
+		outputSize, err = r.Vm.Segments.GetSegmentUsedSize(uint(outputBuiltin.Base().SegmentIndex))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r.Vm.Segments.GetFeltRange(outputBuiltin.Base(), outputSize)
+}
+
+// Returns the fact topology of the program output, based on the pages and attributes of the output builtin
+func (r *CairoRunner) GetFactTopology(outputSize uint) (FactTopology, error) {
+	outputBuiltin, err := r.Vm.GetOutputBuiltin()
+	if err != nil {
+		return FactTopology{}, FactError(err)
+	}
+	return GetFactTopologyFromAdditionalData(outputSize, outputBuiltin.GetAdditionalData())
+}
+
+// Builds the fact topology from the output builtin's additional data (see cairo-lang's get_fact_topology_from_additional_data).
+// If the gps_fact_topology attribute is not set, the whole output is expected to be a single page
+func GetFactTopologyFromAdditionalData(outputSize uint, data builtins.OutputBuiltinAdditionalData) (FactTopology, error) {
+	treeStructure, ok := data.Attributes[builtins.GPS_FACT_TOPOLOGY]
+	if ok {
+		if len(treeStructure)%2 != 0 || len(treeStructure) == 0 || len(treeStructure) > 10 {
+			return FactTopology{}, FactError(errors.Errorf("Invalid tree structure specified in the gps_fact_topology attribute: %v", treeStructure))
+		}
+		for _, value := range treeStructure {
+			if value >= 1<<30 {
+				return FactTopology{}, FactError(errors.Errorf("Invalid tree structure specified in the gps_fact_topology attribute: %v", treeStructure))
+			}
+		}
+	} else {
+		if len(data.Pages) != 0 {
+			return FactTopology{}, FactError(errors.New("Additional pages cannot be used since the 'gps_fact_topology' attribute is not specified"))
+		}
+		treeStructure = []uint{1, 0}
+	}
+	pageSizes, err := GetPageSizesFromPages(outputSize, data.Pages)
+	if err != nil {
+		return FactTopology{}, err
+	}
+	return FactTopology{TreeStructure: treeStructure, PageSizes: pageSizes}, nil
+}
+
+// Returns the sizes of the output pages, starting with page 0, which spans from the start of the output until the first added page.
+// Pages are expected to be numbered 1, 2, ..., be adjacent to each other, and cover the rest of the output
+func GetPageSizesFromPages(outputSize uint, pages map[uint]builtins.PublicMemoryPage) ([]uint, error) {
+	pageIds := make([]uint, 0, len(pages))
+	for pageId := range pages {
+		pageIds = append(pageIds, pageId)
+	}
+	sort.Slice(pageIds, func(i, j int) bool { return pageIds[i] < pageIds[j] })
+
+	pageSizes := []uint{outputSize}
+	expectedPageStart := uint(0)
+	for i, pageId := range pageIds {
+		page := pages[pageId]
+		if pageId != uint(i+1) {
+			return nil, FactError(errors.Errorf("Expected page id %d, found %d", i+1, pageId))
+		}
+		if pageId == 1 {
+			if page.Start == 0 || page.Start > outputSize {
+				return nil, FactError(errors.Errorf("Invalid page start %d", page.Start))
+			}
+			pageSizes[0] = page.Start
+		} else if page.Start != expectedPageStart {
+			return nil, FactError(errors.Errorf("Expected page start %d, found %d", expectedPageStart, page.Start))
+		}
+		if page.Size == 0 || page.Size > outputSize {
+			return nil, FactError(errors.Errorf("Invalid page size %d", page.Size))
+		}
+		expectedPageStart = page.Start + page.Size
+		pageSizes = append(pageSizes, page.Size)
+	}
+	if len(pageIds) > 0 && expectedPageStart != outputSize {
+		return nil, FactError(errors.New("Pages must cover the entire program output"))
+	}
+	return pageSizes, nil
+}
+
+// Computes the fact of a finished run, using the given hash function.
+// The program hash is computed with the bootloader's default version (0)
+func (r *CairoRunner) GetFactInfo(hashFunction FactHashFunction) (FactInfo, error) {
+	if !r.RunEnded {
+		return FactInfo{}, FactError(errors.New("Tried to compute the fact before the run ended"))
+	}
+	output, err := r.GetProgramOutput()
+	if err != nil {
+		return FactInfo{}, err
+	}
+	programHash, err := r.Program.ComputeProgramHashChain(0, hashFunction == FactHashPoseidon)
+	if err != nil {
+		return FactInfo{}, err
+	}
+	topology, err := r.GetFactTopology(uint(len(output)))
+	if err != nil {
+		return FactInfo{}, err
+	}
+
+	var fact *big.Int
+	switch hashFunction {
+	case FactHashKeccak:
+		fact, err = GenerateProgramFact(programHash, output, topology)
+		if err != nil {
+			return FactInfo{}, err
+		}
+	case FactHashPedersen:
+		fact = starknet_crypto.PedersenHash(programHash, starknet_crypto.ComputeHashOnElements(output)).ToBigInt()
+	case FactHashPoseidon:
+		fact = starknet_crypto.PoseidonHash(programHash, starknet_crypto.PoseidonHashMany(output)).ToBigInt()
+	default:
+		return FactInfo{}, FactError(errors.Errorf("Unknown fact hash function: %d", hashFunction))
+	}
+
+	return FactInfo{
+		ProgramHash:   programHash,
+		ProgramOutput: output,
+		FactTopology:  topology,
+		Fact:          fact,
+	}, nil
+}
+
+// Computes keccak256 over the 32-byte big-endian encoding of each value (solidity's keccak256(abi.encodePacked(uint256[])))
+func KeccakInts(values []*big.Int) *big.Int {
+	hasher := keccak.New256()
+	for _, value := range values {
+		var word [32]byte
+		value.FillBytes(word[:])
+		hasher.Write(word[:])
+	}
+	return new(big.Int).SetBytes(hasher.Sum(nil))
+}
+
+// Computes the root of the output tree described by the fact topology.
+// Each page is a leaf hashed with keccak, inner nodes hash the (node_hash, end_offset) pairs of their children plus one,
+// in the same way as cairo-lang's generate_output_root
+func GenerateOutputRoot(output []lambdaworks.Felt, topology FactTopology) (*big.Int, error) {
+	if len(topology.TreeStructure)%2 != 0 {
+		return nil, FactError(errors.New("Tree structure should contain an even number of elements"))
+	}
+	type factNode struct {
+		nodeHash  *big.Int
+		endOffset uint
+	}
+	twoTo256 := new(big.Int).Lsh(big.NewInt(1), 256)
+	pageSizes := topology.PageSizes
+	nodeStack := make([]factNode, 0)
+	endOffset := uint(0)
+
+	for i := 0; i < len(topology.TreeStructure); i += 2 {
+		nPages, nNodes := topology.TreeStructure[i], topology.TreeStructure[i+1]
+		if nPages > uint(len(pageSizes)) {
+			return nil, FactError(errors.New("Invalid tree structure: n_pages is out of range"))
+		}
+		for _, pageSize := range pageSizes[:nPages] {
+			if endOffset+pageSize > uint(len(output)) {
+				return nil, FactError(errors.New("Page sizes exceed the program output size"))
+			}
+			page := make([]*big.Int, 0, pageSize)
+			for _, value := range output[endOffset : endOffset+pageSize] {
+				page = append(page, value.ToBigInt())
+			}
+			endOffset += pageSize
+			nodeStack = append(nodeStack, factNode{nodeHash: KeccakInts(page), endOffset: endOffset})
+		}
+		pageSizes = pageSizes[nPages:]
+
+		if nNodes > 0 {
+			if nNodes > uint(len(nodeStack)) {
+				return nil, FactError(errors.New("Invalid tree structure: n_nodes is out of range"))
+			}
+			children := nodeStack[uint(len(nodeStack))-nNodes:]
+			nodeData := make([]*big.Int, 0, 2*len(children))
+			for _, child := range children {
+				nodeData = append(nodeData, child.nodeHash, new(big.Int).SetUint64(uint64(child.endOffset)))
+			}
+			nodeHash := new(big.Int).Add(KeccakInts(nodeData), big.NewInt(1))
+			nodeHash.Mod(nodeHash, twoTo256)
+			parent := factNode{nodeHash: nodeHash, endOffset: children[len(children)-1].endOffset}
+			nodeStack = append(nodeStack[:uint(len(nodeStack))-nNodes], parent)
+		}
+	}
+
+	if len(nodeStack) != 1 {
+		return nil, FactError(errors.Errorf("Invalid tree structure: expected a single root, got %d nodes", len(nodeStack)))
+	}
+	if len(pageSizes) != 0 {
+		return nil, FactError(errors.New("Invalid tree structure: not all pages were used"))
+	}
+	if endOffset != uint(len(output)) {
+		return nil, FactError(errors.New("Page sizes don't match the program output size"))
+	}
+	return nodeStack[0].nodeHash, nil
+}
+
+// Computes the SHARP fact of a program: keccak(program_hash, output_root)
+func GenerateProgramFact(programHash lambdaworks.Felt, output []lambdaworks.Felt, topology FactTopology) (*big.Int, error) {
+	outputRoot, err := GenerateOutputRoot(output, topology)
+	if err != nil {
+		return nil, err
+	}
+	return KeccakInts([]*big.Int{programHash.ToBigInt(), outputRoot}), nil
+}
@@ -0,0 +1,218 @@
+package runners_test
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestKeccakIntsZero(t *testing.T) {
+	expected, _ := new(big.Int).SetString("290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563", 16)
+	result := runners.KeccakInts([]*big.Int{big.NewInt(0)})
+	if result.Cmp(expected) != 0 {
+		t.Errorf("Wrong keccak. Expected %x, got %x", expected, result)
+	}
+}
+
+func TestGenerateOutputRootSinglePage(t *testing.T) {
+	output := []lambdaworks.Felt{lambdaworks.FeltFromUint64(1), lambdaworks.FeltFromUint64(2)}
+	topology := runners.FactTopology{TreeStructure: []uint{1, 0}, PageSizes: []uint{2}}
+	expected := runners.KeccakInts([]*big.Int{big.NewInt(1), big.NewInt(2)})
+	result, err := runners.GenerateOutputRoot(output, topology)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if result.Cmp(expected) != 0 {
+		t.Errorf("Wrong output root. Expected %x, got %x", expected, result)
+	}
+}
+
+func TestGenerateOutputRootTwoPages(t *testing.T) {
+	output := []lambdaworks.Felt{lambdaworks.FeltFromUint64(1), lambdaworks.FeltFromUint64(2), lambdaworks.FeltFromUint64(3)}
+	topology := runners.FactTopology{TreeStructure: []uint{2, 2}, PageSizes: []uint{1, 2}}
+	firstPage := runners.KeccakInts([]*big.Int{big.NewInt(1)})
+	secondPage := runners.KeccakInts([]*big.Int{big.NewInt(2), big.NewInt(3)})
+	expected := runners.KeccakInts([]*big.Int{firstPage, big.NewInt(1), secondPage, big.NewInt(3)})
+	expected.Add(expected, big.NewInt(1))
+	result, err := runners.GenerateOutputRoot(output, topology)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if result.Cmp(expected) != 0 {
+		t.Errorf("Wrong output root. Expected %x, got %x", expected, result)
+	}
+}
+
+func TestGenerateOutputRootInvalidTopology(t *testing.T) {
+	output := []lambdaworks.Felt{lambdaworks.FeltFromUint64(1), lambdaworks.FeltFromUint64(2)}
+	topologies := []runners.FactTopology{
+		{TreeStructure: []uint{1}, PageSizes: []uint{2}},
+		{TreeStructure: []uint{2, 0}, PageSizes: []uint{2}},
+		{TreeStructure: []uint{1, 0}, PageSizes: []uint{1}},
+		{TreeStructure: []uint{2, 0}, PageSizes: []uint{1, 1}},
+		{TreeStructure: []uint{1, 2}, PageSizes: []uint{2}},
+	}
+	for _, topology := range topologies {
+		_, err := runners.GenerateOutputRoot(output, topology)
+		if err == nil {
+			t.Errorf("Expected topology %v to be invalid", topology)
+		}
+	}
+}
+
+func runnerWithOutput(t *testing.T, output []lambdaworks.Felt) *runners.CairoRunner {
+	program := vm.Program{
+		Data:     []memory.MaybeRelocatable{*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2345108766317314046))},
+		Builtins: []string{"output"},
+		Identifiers: map[string]vm.Identifier{
+			"__main__.main": {PC: 0, Type: "function"},
+		},
+	}
+	runner, err := runners.NewCairoRunner(program, "small", false)
+	if err != nil {
+		t.Fatalf("NewCairoRunner error in test: %s", err)
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Fatalf("Initialize error in test: %s", err)
+	}
+	outputBase := runner.Vm.BuiltinRunners[0].Base()
+	for i, value := range output {
+		runner.Vm.Segments.Memory.Insert(outputBase.AddUint(uint(i)), memory.NewMaybeRelocatableFelt(value))
+	}
+	runner.RunEnded = true
+	return runner
+}
+
+func TestGetFactInfoKeccak(t *testing.T) {
+	output := []lambdaworks.Felt{lambdaworks.FeltFromUint64(7), lambdaworks.FeltFromUint64(8)}
+	runner := runnerWithOutput(t, output)
+	factInfo, err := runner.GetFactInfo(runners.FactHashKeccak)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	programHash, _ := runner.Program.ComputeProgramHashChain(0, false)
+	if factInfo.ProgramHash != programHash {
+		t.Errorf("Wrong program hash. Expected %s, got %s", programHash.ToHexString(), factInfo.ProgramHash.ToHexString())
+	}
+	outputHash := runners.KeccakInts([]*big.Int{big.NewInt(7), big.NewInt(8)})
+	expected := runners.KeccakInts([]*big.Int{programHash.ToBigInt(), outputHash})
+	if factInfo.Fact.Cmp(expected) != 0 {
+		t.Errorf("Wrong fact. Expected %x, got %x", expected, factInfo.Fact)
+	}
+	if len(factInfo.FactTopology.PageSizes) != 1 || factInfo.FactTopology.PageSizes[0] != 2 {
+		t.Errorf("Wrong fact topology: %v", factInfo.FactTopology)
+	}
+}
+
+func TestGetFactInfoPedersen(t *testing.T) {
+	output := []lambdaworks.Felt{lambdaworks.FeltFromUint64(7)}
+	runner := runnerWithOutput(t, output)
+	factInfo, err := runner.GetFactInfo(runners.FactHashPedersen)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	programHash, _ := runner.Program.ComputeProgramHashChain(0, false)
+	expected := starknet_crypto.PedersenHash(programHash, starknet_crypto.ComputeHashOnElements(output)).ToBigInt()
+	if factInfo.Fact.Cmp(expected) != 0 {
+		t.Errorf("Wrong fact. Expected %x, got %x", expected, factInfo.Fact)
+	}
+}
+
+func TestGetFactInfoPoseidon(t *testing.T) {
+	output := []lambdaworks.Felt{lambdaworks.FeltFromUint64(7)}
+	runner := runnerWithOutput(t, output)
+	factInfo, err := runner.GetFactInfo(runners.FactHashPoseidon)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	programHash, _ := runner.Program.ComputeProgramHashChain(0, true)
+	expected := starknet_crypto.PoseidonHash(programHash, starknet_crypto.PoseidonHashMany(output)).ToBigInt()
+	if factInfo.Fact.Cmp(expected) != 0 {
+		t.Errorf("Wrong fact. Expected %x, got %x", expected, factInfo.Fact)
+	}
+}
+
+func TestGetFactInfoRunNotEnded(t *testing.T) {
+	runner := runnerWithOutput(t, []lambdaworks.Felt{})
+	runner.RunEnded = false
+	_, err := runner.GetFactInfo(runners.FactHashKeccak)
+	if err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestFactHashFunctionFromString(t *testing.T) {
+	hashFunction, err := runners.FactHashFunctionFromString("poseidon")
+	if err != nil || hashFunction != runners.FactHashPoseidon {
+		t.Errorf("Wrong hash function: %d, %v", hashFunction, err)
+	}
+	_, err = runners.FactHashFunctionFromString("sha256")
+	if err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestGetFactTopologyFromAdditionalDataNoAttribute(t *testing.T) {
+	data := builtins.OutputBuiltinAdditionalData{Pages: map[uint]builtins.PublicMemoryPage{}, Attributes: map[string][]uint{}}
+	topology, err := runners.GetFactTopologyFromAdditionalData(5, data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := runners.FactTopology{TreeStructure: []uint{1, 0}, PageSizes: []uint{5}}
+	if !reflect.DeepEqual(topology, expected) {
+		t.Errorf("Wrong fact topology. Expected %v, got %v", expected, topology)
+	}
+}
+
+func TestGetFactTopologyFromAdditionalDataWithPages(t *testing.T) {
+	data := builtins.OutputBuiltinAdditionalData{
+		Pages:      map[uint]builtins.PublicMemoryPage{1: {Start: 2, Size: 2}, 2: {Start: 4, Size: 1}},
+		Attributes: map[string][]uint{builtins.GPS_FACT_TOPOLOGY: {3, 2, 0, 2}},
+	}
+	topology, err := runners.GetFactTopologyFromAdditionalData(5, data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := runners.FactTopology{TreeStructure: []uint{3, 2, 0, 2}, PageSizes: []uint{2, 2, 1}}
+	if !reflect.DeepEqual(topology, expected) {
+		t.Errorf("Wrong fact topology. Expected %v, got %v", expected, topology)
+	}
+}
+
+func TestGetFactTopologyFromAdditionalDataPagesWithoutAttribute(t *testing.T) {
+	data := builtins.OutputBuiltinAdditionalData{
+		Pages:      map[uint]builtins.PublicMemoryPage{1: {Start: 2, Size: 3}},
+		Attributes: map[string][]uint{},
+	}
+	_, err := runners.GetFactTopologyFromAdditionalData(5, data)
+	if err == nil {
+		t.Errorf("Expected pages without the gps_fact_topology attribute to fail")
+	}
+}
+
+func TestGetPageSizesFromPagesInvalid(t *testing.T) {
+	invalidPages := []map[uint]builtins.PublicMemoryPage{
+		// Missing page 1
+		{2: {Start: 2, Size: 3}},
+		// Page 2 doesn't start where page 1 ends
+		{1: {Start: 1, Size: 2}, 2: {Start: 4, Size: 1}},
+		// Pages don't cover the whole output
+		{1: {Start: 1, Size: 2}},
+		// Page 1 starts at the beginning of the output
+		{1: {Start: 0, Size: 5}},
+	}
+	for _, pages := range invalidPages {
+		_, err := runners.GetPageSizesFromPages(5, pages)
+		if err == nil {
+			t.Errorf("Expected pages %v to be invalid", pages)
+		}
+	}
+}
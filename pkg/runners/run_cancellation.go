@@ -0,0 +1,29 @@
+package runners
+
+import (
+	"context"
+	"fmt"
+)
+
+// Amount of steps between two checks of the run's context
+const contextCheckInterval = 256
+
+// Returned by the context-aware run methods when the run is stopped by its context
+type RunCancelledError struct {
+	// Steps executed before the run was stopped
+	Step uint
+	// context.Canceled or context.DeadlineExceeded
+	Err error
+}
+
+func (e *RunCancelledError) Error() string {
+	return fmt.Sprintf("Run stopped after %d steps: %s", e.Step, e.Err)
+}
+
+func (e *RunCancelledError) Unwrap() error {
+	return e.Err
+}
+
+func (r *CairoRunner) cancelledError(ctx context.Context) error {
+	return &RunCancelledError{Step: r.Vm.CurrentStep, Err: ctx.Err()}
+}
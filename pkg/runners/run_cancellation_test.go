@@ -0,0 +1,148 @@
+package runners_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/python_interpreter"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// Returns a runner for a program that loops forever (jmp rel 0)
+func infiniteLoopRunner(t *testing.T, hints map[uint][]parser.HintParams) (*runners.CairoRunner, memory.Relocatable) {
+	program := vm.Program{
+		Data: []memory.MaybeRelocatable{
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex("0x10780017fff7fff")),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero()),
+		},
+		Identifiers: make(map[string]vm.Identifier),
+		Hints:       hints,
+	}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Fatalf("NewCairoRunner error in test: %s", err)
+	}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Fatalf("Initialize error in test: %s", err)
+	}
+	return runner, end
+}
+
+func TestRunUntilPCWithContextDeadline(t *testing.T) {
+	runner, end := infiniteLoopRunner(t, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := runner.RunUntilPCWithContext(ctx, end, &hints.CairoVmHintProcessor{})
+	var cancelled *runners.RunCancelledError
+	if !errors.As(err, &cancelled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline error, got %v", err)
+	}
+	if cancelled.Step == 0 || cancelled.Step != runner.Vm.CurrentStep {
+		t.Errorf("Wrong step count: %d, the vm ran %d steps", cancelled.Step, runner.Vm.CurrentStep)
+	}
+}
+
+func TestRunUntilPCWithContextCancelled(t *testing.T) {
+	runner, end := infiniteLoopRunner(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := runner.RunUntilPCWithContext(ctx, end, &hints.CairoVmHintProcessor{})
+	if !errors.Is(err, context.Canceled) || err.Error() != "Run stopped after 0 steps: context canceled" {
+		t.Errorf("Expected a cancellation error, got %v", err)
+	}
+}
+
+// Hint processor whose hints block until release is closed, or until the context of the run is done
+type blockingHintProcessor struct {
+	release chan struct{}
+	// Set once a hint returned
+	returned bool
+}
+
+func (p *blockingHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	return nil, nil
+}
+
+func (p *blockingHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	return p.ExecuteHintWithContext(context.Background(), vm, hintData, constants, execScopes)
+}
+
+func (p *blockingHintProcessor) ExecuteHintWithContext(ctx context.Context, vm *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	defer func() { p.returned = true }()
+	select {
+	case <-p.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRunUntilPCWithContextBlockingHint(t *testing.T) {
+	runner, end := infiniteLoopRunner(t, map[uint][]parser.HintParams{0: {{Code: "block()"}}})
+	hintProcessor := &blockingHintProcessor{release: make(chan struct{})}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := runner.RunUntilPCWithContext(ctx, end, hintProcessor)
+	var cancelled *runners.RunCancelledError
+	if !errors.As(err, &cancelled) || !errors.Is(err, context.DeadlineExceeded) || cancelled.Step != 0 {
+		t.Errorf("Expected a deadline error at step 0, got %v", err)
+	}
+	if !hintProcessor.returned {
+		t.Errorf("The run returned while its hint was still running")
+	}
+}
+
+func TestRunUntilPCWithContextLoopingPythonHint(t *testing.T) {
+	runner, end := infiniteLoopRunner(t, map[uint][]parser.HintParams{0: {{Code: "while True:\n    pass"}}})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	hintProcessor := &python_interpreter.PythonHintProcessor{MaxLoopIterations: math.MaxUint}
+	err := runner.RunUntilPCWithContext(ctx, end, hintProcessor)
+	var cancelled *runners.RunCancelledError
+	if !errors.As(err, &cancelled) || !errors.Is(err, context.DeadlineExceeded) || cancelled.Step != 0 {
+		t.Errorf("Expected a deadline error at step 0, got %v", err)
+	}
+}
+
+func TestRunUntilNextPowerOfTwoWithContextCancelled(t *testing.T) {
+	runner, _ := infiniteLoopRunner(t, nil)
+	err := runner.RunForSteps(3, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Fatalf("RunForSteps error in test: %s", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = runner.RunUntilNextPowerOfTwoWithContext(ctx, &hints.CairoVmHintProcessor{})
+	if !errors.Is(err, context.Canceled) || runner.Vm.CurrentStep != 3 {
+		t.Errorf("Expected a cancellation error at step 3, got %v at step %d", err, runner.Vm.CurrentStep)
+	}
+	err = runner.RunUntilNextPowerOfTwoWithContext(context.Background(), &hints.CairoVmHintProcessor{})
+	if err != nil || runner.Vm.CurrentStep != 4 {
+		t.Errorf("Expected the run to stop at step 4, got %v at step %d", err, runner.Vm.CurrentStep)
+	}
+}
+
+func TestEndRunWithContextCancelledWhilePadding(t *testing.T) {
+	runner, _ := infiniteLoopRunner(t, nil)
+	runner.ProofMode = true
+	err := runner.RunForSteps(3, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Fatalf("RunForSteps error in test: %s", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = runner.EndRunWithContext(ctx, false, false, &hints.CairoVmHintProcessor{})
+	if !errors.Is(err, context.Canceled) || runner.RunEnded {
+		t.Errorf("Expected the padding to be cancelled, got %v", err)
+	}
+}
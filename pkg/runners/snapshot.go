@@ -0,0 +1,351 @@
+package runners
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Version of the snapshot format, increased on incompatible changes
+const SNAPSHOT_VERSION = 1
+
+var ErrSnapshotUnsupported = errors.New("The runner's state can't be snapshotted")
+
+func SnapshotError(err error) error {
+	return errors.Wrapf(err, "Snapshot error")
+}
+
+// State of a paused run, as written by CairoRunner.WriteSnapshot
+type runnerSnapshot struct {
+	Version               uint                                 `json:"version"`
+	Layout                string                               `json:"layout"`
+	ProofMode             bool                                 `json:"proof_mode"`
+	ProgramBase           memory.Relocatable                   `json:"program_base"`
+	ExecutionBase         memory.Relocatable                   `json:"execution_base"`
+	InitialPc             memory.Relocatable                   `json:"initial_pc"`
+	InitialAp             memory.Relocatable                   `json:"initial_ap"`
+	InitialFp             memory.Relocatable                   `json:"initial_fp"`
+	FinalPc               *memory.Relocatable                  `json:"final_pc"`
+	ExecutionPublicMemory *[]uint                              `json:"execution_public_memory"`
+	RunContext            vm.RunContext                        `json:"run_context"`
+	CurrentStep           uint                                 `json:"current_step"`
+	HintExecutions        uint                                 `json:"hint_executions"`
+	RemainingSteps        *uint                                `json:"remaining_steps"`
+	RcLimitsMin           *int                                 `json:"rc_limits_min"`
+	RcLimitsMax           *int                                 `json:"rc_limits_max"`
+	Trace                 []vm.TraceEntry                      `json:"trace"`
+	NumSegments           uint                                 `json:"num_segments"`
+	Memory                []snapshotCell                       `json:"memory"`
+	AccessedAddresses     []memory.Relocatable                 `json:"accessed_addresses"`
+	SegmentUsedSizes      map[uint]uint                        `json:"segment_used_sizes"`
+	SegmentSizes          map[uint]uint                        `json:"segment_sizes"`
+	PublicMemoryOffsets   map[uint][]memory.PublicMemoryOffset `json:"public_memory_offsets"`
+	Builtins              []snapshotBuiltin                    `json:"builtins"`
+	DictManagers          []snapshotDictManager                `json:"dict_managers"`
+	// Execution scopes, from the main scope to the current one
+	Scopes [][]snapshotScopeVar `json:"scopes"`
+}
+
+// A felt, or a relocatable if Relocatable is set
+type snapshotValue struct {
+	Felt        string              `json:"felt,omitempty"`
+	Relocatable *memory.Relocatable `json:"relocatable,omitempty"`
+}
+
+type snapshotCell struct {
+	Address memory.Relocatable `json:"address"`
+	Value   snapshotValue      `json:"value"`
+}
+
+type snapshotSignature struct {
+	Address memory.Relocatable `json:"address"`
+	R       string             `json:"r"`
+	S       string             `json:"s"`
+}
+
+type snapshotBuiltin struct {
+	Name string             `json:"name"`
+	Base memory.Relocatable `json:"base"`
+	// Pages and attributes of the output builtin
+	Output *builtins.OutputBuiltinState `json:"output,omitempty"`
+	// Signatures of the signature builtin
+	Signatures []snapshotSignature `json:"signatures,omitempty"`
+}
+
+func encodeFelt(felt lambdaworks.Felt) string {
+	return felt.ToBigInt().String()
+}
+
+func decodeFelt(encoded string) (lambdaworks.Felt, error) {
+	value, ok := new(big.Int).SetString(encoded, 10)
+	if !ok || value.Sign() < 0 || value.Cmp(lambdaworks.Prime()) >= 0 {
+		return lambdaworks.Felt{}, errors.Errorf("Invalid felt %q", encoded)
+	}
+	return lambdaworks.FeltFromBigInt(value), nil
+}
+
+func encodeValue(value memory.MaybeRelocatable) snapshotValue {
+	if relocatable, ok := value.GetRelocatable(); ok {
+		return snapshotValue{Relocatable: &relocatable}
+	}
+	felt, _ := value.GetFelt()
+	return snapshotValue{Felt: encodeFelt(felt)}
+}
+
+func (v snapshotValue) decode() (memory.MaybeRelocatable, error) {
+	if v.Relocatable != nil {
+		return *memory.NewMaybeRelocatableRelocatable(*v.Relocatable), nil
+	}
+	felt, err := decodeFelt(v.Felt)
+	if err != nil {
+		return memory.MaybeRelocatable{}, err
+	}
+	return *memory.NewMaybeRelocatableFelt(felt), nil
+}
+
+func lessAddress(a memory.Relocatable, b memory.Relocatable) bool {
+	if a.SegmentIndex != b.SegmentIndex {
+		return a.SegmentIndex < b.SegmentIndex
+	}
+	return a.Offset < b.Offset
+}
+
+// Returns the pc at which the run ends, the one returned by the runner's initialization
+func (r *CairoRunner) endPc() memory.Relocatable {
+	if r.finalPc != nil {
+		return *r.finalPc
+	}
+	return memory.NewRelocatable(r.ProgramBase.SegmentIndex, r.ProgramBase.Offset+r.Program.End)
+}
+
+// Writes the state of a paused run (such as after RunForSteps, or after RunUntilPC failed because its steps ran out)
+// so that it can be resumed by another runner with RestoreSnapshot: the memory and its segments, the registers,
+// the trace, the builtins' state and the execution scopes.
+// The run's limits and hooks are not part of its state, and must be set again after restoring it
+func (r *CairoRunner) WriteSnapshot(writer io.Writer) error {
+	if r.Vm.Segments.Memory.NumSegments() == 0 {
+		return SnapshotError(errors.Wrap(ErrSnapshotUnsupported, "The runner was not initialized"))
+	}
+	if r.RunEnded {
+		return SnapshotError(errors.Wrap(ErrSnapshotUnsupported, "The run already ended"))
+	}
+	if len(r.Vm.LoadedPrograms) != 0 {
+		return SnapshotError(errors.Wrap(ErrSnapshotUnsupported, "The run loaded programs into memory, whose compiled hints can't be stored"))
+	}
+	snapshot := runnerSnapshot{
+		Version:               SNAPSHOT_VERSION,
+		Layout:                r.Layout.Name,
+		ProofMode:             r.ProofMode,
+		ProgramBase:           r.ProgramBase,
+		ExecutionBase:         r.executionBase,
+		InitialPc:             r.initialPc,
+		InitialAp:             r.initialAp,
+		InitialFp:             r.initialFp,
+		FinalPc:               r.finalPc,
+		ExecutionPublicMemory: r.ExecutionPublicMemory,
+		RunContext:            r.Vm.RunContext,
+		CurrentStep:           r.Vm.CurrentStep,
+		HintExecutions:        r.Vm.HintExecutions,
+		RcLimitsMin:           r.Vm.RcLimitsMin,
+		RcLimitsMax:           r.Vm.RcLimitsMax,
+		Trace:                 r.Vm.Trace,
+		NumSegments:           r.Vm.Segments.Memory.NumSegments(),
+		SegmentUsedSizes:      r.Vm.Segments.SegmentUsedSizes,
+		SegmentSizes:          r.Vm.Segments.SegmentSizes,
+		PublicMemoryOffsets:   r.Vm.Segments.PublicMemoryOffsets,
+	}
+	if r.Vm.RunResources != nil {
+		snapshot.RemainingSteps = r.Vm.RunResources.GetNSteps()
+	}
+
+	snapshot.Memory = make([]snapshotCell, 0, len(r.Vm.Segments.Memory.Data))
+	for address, value := range r.Vm.Segments.Memory.Data {
+		snapshot.Memory = append(snapshot.Memory, snapshotCell{Address: address, Value: encodeValue(value)})
+	}
+	sort.Slice(snapshot.Memory, func(i, j int) bool {
+		return lessAddress(snapshot.Memory[i].Address, snapshot.Memory[j].Address)
+	})
+	snapshot.AccessedAddresses = make([]memory.Relocatable, 0, len(r.Vm.Segments.Memory.AccessedAddresses))
+	for address, accessed := range r.Vm.Segments.Memory.AccessedAddresses {
+		if accessed {
+			snapshot.AccessedAddresses = append(snapshot.AccessedAddresses, address)
+		}
+	}
+	sort.Slice(snapshot.AccessedAddresses, func(i, j int) bool {
+		return lessAddress(snapshot.AccessedAddresses[i], snapshot.AccessedAddresses[j])
+	})
+
+	for _, builtin := range r.Vm.BuiltinRunners {
+		builtinSnapshot := snapshotBuiltin{Name: builtin.Name(), Base: builtin.Base()}
+		switch builtin := builtin.(type) {
+		case *builtins.OutputBuiltinRunner:
+			state := builtin.GetState()
+			builtinSnapshot.Output = &state
+		case *builtins.SignatureBuiltinRunner:
+			for address, signature := range builtin.GetSignatures() {
+				builtinSnapshot.Signatures = append(builtinSnapshot.Signatures, snapshotSignature{Address: address, R: encodeFelt(signature.R), S: encodeFelt(signature.S)})
+			}
+			sort.Slice(builtinSnapshot.Signatures, func(i, j int) bool {
+				return lessAddress(builtinSnapshot.Signatures[i].Address, builtinSnapshot.Signatures[j].Address)
+			})
+		}
+		snapshot.Builtins = append(snapshot.Builtins, builtinSnapshot)
+	}
+
+	encoder := newScopeEncoder()
+	for _, scope := range r.execScopes.Scopes() {
+		scopeVars, err := encoder.encodeScope(scope)
+		if err != nil {
+			return SnapshotError(err)
+		}
+		snapshot.Scopes = append(snapshot.Scopes, scopeVars)
+	}
+	snapshot.DictManagers = encoder.dictManagers
+
+	err := json.NewEncoder(writer).Encode(snapshot)
+	if err != nil {
+		return SnapshotError(err)
+	}
+	return nil
+}
+
+// Restores the state written by WriteSnapshot into a new runner, created with NewCairoRunner from the same program,
+// layout and proof mode as the snapshotted one. Replaces the runner's initialization: the run can be resumed by
+// calling RunUntilPC with the returned end pointer
+func (r *CairoRunner) RestoreSnapshot(reader io.Reader) (memory.Relocatable, error) {
+	var snapshot runnerSnapshot
+	err := json.NewDecoder(reader).Decode(&snapshot)
+	if err != nil {
+		return memory.Relocatable{}, SnapshotError(err)
+	}
+	err = r.restoreSnapshot(&snapshot)
+	if err != nil {
+		return memory.Relocatable{}, SnapshotError(err)
+	}
+	return r.endPc(), nil
+}
+
+func (r *CairoRunner) restoreSnapshot(snapshot *runnerSnapshot) error {
+	if snapshot.Version != SNAPSHOT_VERSION {
+		return errors.Errorf("Unsupported snapshot version %d, expected %d", snapshot.Version, SNAPSHOT_VERSION)
+	}
+	if r.Vm.Segments.Memory.NumSegments() != 0 {
+		return errors.New("Snapshots can only be restored into new runners")
+	}
+	if snapshot.Layout != r.Layout.Name || snapshot.ProofMode != r.ProofMode {
+		return errors.Errorf("The snapshot was taken with layout %s and proof mode %t, but the runner uses layout %s and proof mode %t",
+			snapshot.Layout, snapshot.ProofMode, r.Layout.Name, r.ProofMode)
+	}
+
+	// The runner's segments are created in the same order as the snapshotted runner's
+	err := r.InitializeBuiltins()
+	if err != nil {
+		return err
+	}
+	r.InitializeSegments()
+	if r.ProgramBase != snapshot.ProgramBase || r.executionBase != snapshot.ExecutionBase {
+		return errors.New("The snapshot's segments don't match the runner's")
+	}
+	if len(snapshot.Builtins) != len(r.Vm.BuiltinRunners) {
+		return errors.Errorf("The snapshot has %d builtins, but the runner has %d", len(snapshot.Builtins), len(r.Vm.BuiltinRunners))
+	}
+	for i, builtin := range r.Vm.BuiltinRunners {
+		builtinSnapshot := snapshot.Builtins[i]
+		if builtinSnapshot.Name != builtin.Name() || builtinSnapshot.Base != builtin.Base() {
+			return errors.Errorf("The snapshot's builtin %s at %s doesn't match the runner's builtin %s", builtinSnapshot.Name, builtinSnapshot.Base.ToString(), builtin.Name())
+		}
+		switch builtin := builtin.(type) {
+		case *builtins.OutputBuiltinRunner:
+			if builtinSnapshot.Output != nil {
+				builtin.SetState(*builtinSnapshot.Output)
+			}
+		case *builtins.SignatureBuiltinRunner:
+			for _, signature := range builtinSnapshot.Signatures {
+				sigR, errR := decodeFelt(signature.R)
+				sigS, errS := decodeFelt(signature.S)
+				if errR != nil || errS != nil {
+					return errors.Errorf("Invalid signature at %s", signature.Address.ToString())
+				}
+				builtin.AddSignature(signature.Address, builtins.Signature{R: sigR, S: sigS})
+			}
+		}
+	}
+	for r.Vm.Segments.Memory.NumSegments() < snapshot.NumSegments {
+		r.Vm.Segments.AddSegment()
+	}
+
+	for _, cell := range snapshot.Memory {
+		value, err := cell.Value.decode()
+		if err != nil {
+			return err
+		}
+		err = r.Vm.Segments.Memory.Insert(cell.Address, &value)
+		if err != nil {
+			return err
+		}
+	}
+	for i, value := range r.Program.Data {
+		snapshotValue, err := r.Vm.Segments.Memory.Get(r.ProgramBase.AddUint(uint(i)))
+		if err != nil || *snapshotValue != value {
+			return errors.New("The snapshot was taken from a different program")
+		}
+	}
+	for _, address := range snapshot.AccessedAddresses {
+		r.Vm.Segments.Memory.MarkAsAccessed(address)
+	}
+	if snapshot.SegmentUsedSizes != nil {
+		r.Vm.Segments.SegmentUsedSizes = snapshot.SegmentUsedSizes
+	}
+	if snapshot.SegmentSizes != nil {
+		r.Vm.Segments.SegmentSizes = snapshot.SegmentSizes
+	}
+	if snapshot.PublicMemoryOffsets != nil {
+		r.Vm.Segments.PublicMemoryOffsets = snapshot.PublicMemoryOffsets
+	}
+
+	r.initialPc = snapshot.InitialPc
+	r.initialAp = snapshot.InitialAp
+	r.initialFp = snapshot.InitialFp
+	r.finalPc = snapshot.FinalPc
+	r.ExecutionPublicMemory = snapshot.ExecutionPublicMemory
+	r.Vm.RunContext = snapshot.RunContext
+	r.Vm.CurrentStep = snapshot.CurrentStep
+	r.Vm.HintExecutions = snapshot.HintExecutions
+	r.Vm.RcLimitsMin = snapshot.RcLimitsMin
+	r.Vm.RcLimitsMax = snapshot.RcLimitsMax
+	if snapshot.Trace != nil {
+		r.Vm.Trace = snapshot.Trace
+	}
+	if snapshot.RemainingSteps != nil {
+		runResources := vm.NewRunResources(*snapshot.RemainingSteps)
+		r.Vm.RunResources = &runResources
+	}
+
+	decoder := newScopeDecoder(snapshot.DictManagers)
+	scopes := make([]map[string]interface{}, 0, len(snapshot.Scopes))
+	for _, scopeVars := range snapshot.Scopes {
+		scope, err := decoder.decodeScope(scopeVars)
+		if err != nil {
+			return err
+		}
+		scopes = append(scopes, scope)
+	}
+	execScopes, err := types.NewExecutionScopesFromScopes(scopes)
+	if err != nil {
+		return err
+	}
+	r.execScopes = *execScopes
+
+	for _, builtin := range r.Vm.BuiltinRunners {
+		builtin.AddValidationRule(&r.Vm.Segments.Memory)
+	}
+	return r.Vm.Segments.Memory.ValidateExistingMemory()
+}
@@ -0,0 +1,446 @@
+package runners
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/dict_manager"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// A scope variable of a snapshot. Its value is stored along with its go type, which hints check when fetching it
+type snapshotScopeVar struct {
+	Name string `json:"name"`
+	snapshotScopeValue
+}
+
+type snapshotScopeValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+type snapshotDictEntry struct {
+	Key   snapshotValue `json:"key"`
+	Value snapshotValue `json:"value"`
+}
+
+type snapshotDict struct {
+	Segment      int                 `json:"segment"`
+	Entries      []snapshotDictEntry `json:"entries"`
+	DefaultValue *snapshotValue      `json:"default_value"`
+	CurrentPtr   memory.Relocatable  `json:"current_ptr"`
+}
+
+// Dict managers are stored apart from the scopes, as the same manager can be shared by several scopes
+type snapshotDictManager struct {
+	Dicts []snapshotDict `json:"dicts"`
+}
+
+type snapshotIntsEntry struct {
+	Key    snapshotValue `json:"key"`
+	Values []int         `json:"values"`
+}
+
+type snapshotUint64sEntry struct {
+	Key    string   `json:"key"`
+	Values []uint64 `json:"values"`
+}
+
+type scopeEncoder struct {
+	dictManagers   []snapshotDictManager
+	dictManagerIds map[*dict_manager.DictManager]int
+}
+
+func newScopeEncoder() *scopeEncoder {
+	return &scopeEncoder{dictManagerIds: make(map[*dict_manager.DictManager]int)}
+}
+
+func (e *scopeEncoder) encodeScope(scope map[string]interface{}) ([]snapshotScopeVar, error) {
+	scopeVars := make([]snapshotScopeVar, 0, len(scope))
+	for name, value := range scope {
+		encoded, err := e.encodeValue(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Scope variable %s", name)
+		}
+		scopeVars = append(scopeVars, snapshotScopeVar{Name: name, snapshotScopeValue: encoded})
+	}
+	sort.Slice(scopeVars, func(i, j int) bool { return scopeVars[i].Name < scopeVars[j].Name })
+	return scopeVars, nil
+}
+
+func lessValue(a memory.MaybeRelocatable, b memory.MaybeRelocatable) bool {
+	aRelocatable, aIsRelocatable := a.GetRelocatable()
+	bRelocatable, bIsRelocatable := b.GetRelocatable()
+	if aIsRelocatable != bIsRelocatable {
+		return bIsRelocatable
+	}
+	if aIsRelocatable {
+		return lessAddress(aRelocatable, bRelocatable)
+	}
+	aFelt, _ := a.GetFelt()
+	bFelt, _ := b.GetFelt()
+	return aFelt.Cmp(bFelt) < 0
+}
+
+func encodeDict(dict map[memory.MaybeRelocatable]memory.MaybeRelocatable) []snapshotDictEntry {
+	entries := make([]snapshotDictEntry, 0, len(dict))
+	keys := make([]memory.MaybeRelocatable, 0, len(dict))
+	for key := range dict {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessValue(keys[i], keys[j]) })
+	for _, key := range keys {
+		entries = append(entries, snapshotDictEntry{Key: encodeValue(key), Value: encodeValue(dict[key])})
+	}
+	return entries
+}
+
+func (e *scopeEncoder) encodeDictManager(dictManager *dict_manager.DictManager) int {
+	id, ok := e.dictManagerIds[dictManager]
+	if ok {
+		return id
+	}
+	var encoded snapshotDictManager
+	for segment, tracker := range dictManager.GetState() {
+		dict := snapshotDict{Segment: segment, Entries: encodeDict(tracker.Data), CurrentPtr: tracker.CurrentPtr}
+		if tracker.DefaultValue != nil {
+			defaultValue := encodeValue(*tracker.DefaultValue)
+			dict.DefaultValue = &defaultValue
+		}
+		encoded.Dicts = append(encoded.Dicts, dict)
+	}
+	sort.Slice(encoded.Dicts, func(i, j int) bool { return encoded.Dicts[i].Segment < encoded.Dicts[j].Segment })
+	id = len(e.dictManagers)
+	e.dictManagers = append(e.dictManagers, encoded)
+	e.dictManagerIds[dictManager] = id
+	return id
+}
+
+// Encodes the types of scope variables stored by the VM's hints
+func (e *scopeEncoder) encodeValue(value any) (snapshotScopeValue, error) {
+	var typeName string
+	var encoded any
+	switch value := value.(type) {
+	case nil:
+		typeName = "none"
+	case bool:
+		typeName, encoded = "bool", value
+	case string:
+		typeName, encoded = "string", value
+	case int:
+		typeName, encoded = "int", value
+	case uint:
+		typeName, encoded = "uint", value
+	case uint64:
+		typeName, encoded = "uint64", value
+	case big.Int:
+		typeName, encoded = "big_int", value.String()
+	case *big.Int:
+		typeName, encoded = "big_int_ptr", value.String()
+	case lambdaworks.Felt:
+		typeName, encoded = "felt", encodeFelt(value)
+	case memory.Relocatable:
+		typeName, encoded = "relocatable", value
+	case memory.MaybeRelocatable:
+		typeName, encoded = "maybe_relocatable", encodeValue(value)
+	case []int:
+		typeName, encoded = "int_slice", value
+	case []uint64:
+		typeName, encoded = "uint64_slice", value
+	case []lambdaworks.Felt:
+		felts := make([]string, 0, len(value))
+		for _, felt := range value {
+			felts = append(felts, encodeFelt(felt))
+		}
+		typeName, encoded = "felt_slice", felts
+	case []memory.MaybeRelocatable:
+		values := make([]snapshotValue, 0, len(value))
+		for _, elem := range value {
+			values = append(values, encodeValue(elem))
+		}
+		typeName, encoded = "maybe_relocatable_slice", values
+	case map[memory.MaybeRelocatable]memory.MaybeRelocatable:
+		typeName, encoded = "dict", encodeDict(value)
+	case map[memory.MaybeRelocatable][]int:
+		keys := make([]memory.MaybeRelocatable, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return lessValue(keys[i], keys[j]) })
+		entries := make([]snapshotIntsEntry, 0, len(value))
+		for _, key := range keys {
+			entries = append(entries, snapshotIntsEntry{Key: encodeValue(key), Values: value[key]})
+		}
+		typeName, encoded = "maybe_relocatable_to_ints", entries
+	case map[lambdaworks.Felt][]uint64:
+		keys := make([]lambdaworks.Felt, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Cmp(keys[j]) < 0 })
+		entries := make([]snapshotUint64sEntry, 0, len(value))
+		for _, key := range keys {
+			entries = append(entries, snapshotUint64sEntry{Key: encodeFelt(key), Values: value[key]})
+		}
+		typeName, encoded = "felt_to_uint64s", entries
+	case json.RawMessage:
+		typeName, encoded = "json", value
+	case *dict_manager.DictManager:
+		typeName, encoded = "dict_manager", e.encodeDictManager(value)
+	case []any:
+		elems := make([]snapshotScopeValue, 0, len(value))
+		for _, elem := range value {
+			encodedElem, err := e.encodeValue(elem)
+			if err != nil {
+				return snapshotScopeValue{}, err
+			}
+			elems = append(elems, encodedElem)
+		}
+		typeName, encoded = "list", elems
+	default:
+		return snapshotScopeValue{}, errors.Wrapf(ErrSnapshotUnsupported, "Unsupported scope variable type %T", value)
+	}
+	raw, err := json.Marshal(encoded)
+	if err != nil {
+		return snapshotScopeValue{}, err
+	}
+	return snapshotScopeValue{Type: typeName, Value: raw}, nil
+}
+
+type scopeDecoder struct {
+	dictManagers        []snapshotDictManager
+	decodedDictManagers map[int]*dict_manager.DictManager
+}
+
+func newScopeDecoder(dictManagers []snapshotDictManager) *scopeDecoder {
+	return &scopeDecoder{dictManagers: dictManagers, decodedDictManagers: make(map[int]*dict_manager.DictManager)}
+}
+
+func (d *scopeDecoder) decodeScope(scopeVars []snapshotScopeVar) (map[string]interface{}, error) {
+	scope := make(map[string]interface{}, len(scopeVars))
+	for _, scopeVar := range scopeVars {
+		value, err := d.decodeValue(scopeVar.snapshotScopeValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Scope variable %s", scopeVar.Name)
+		}
+		scope[scopeVar.Name] = value
+	}
+	return scope, nil
+}
+
+func decodeBigInt(encoded string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(encoded, 10)
+	if !ok {
+		return nil, errors.Errorf("Invalid integer %q", encoded)
+	}
+	return value, nil
+}
+
+func decodeDict(entries []snapshotDictEntry) (map[memory.MaybeRelocatable]memory.MaybeRelocatable, error) {
+	dict := make(map[memory.MaybeRelocatable]memory.MaybeRelocatable, len(entries))
+	for _, entry := range entries {
+		key, err := entry.Key.decode()
+		if err != nil {
+			return nil, err
+		}
+		value, err := entry.Value.decode()
+		if err != nil {
+			return nil, err
+		}
+		dict[key] = value
+	}
+	return dict, nil
+}
+
+func (d *scopeDecoder) decodeDictManager(id int) (*dict_manager.DictManager, error) {
+	if dictManager, ok := d.decodedDictManagers[id]; ok {
+		return dictManager, nil
+	}
+	if id < 0 || id >= len(d.dictManagers) {
+		return nil, errors.Errorf("Unknown dict manager %d", id)
+	}
+	state := make(map[int]dict_manager.DictTrackerState)
+	for _, dict := range d.dictManagers[id].Dicts {
+		data, err := decodeDict(dict.Entries)
+		if err != nil {
+			return nil, err
+		}
+		trackerState := dict_manager.DictTrackerState{Data: data, CurrentPtr: dict.CurrentPtr}
+		if dict.DefaultValue != nil {
+			defaultValue, err := dict.DefaultValue.decode()
+			if err != nil {
+				return nil, err
+			}
+			trackerState.DefaultValue = &defaultValue
+		}
+		state[dict.Segment] = trackerState
+	}
+	dictManager := dict_manager.DictManagerFromState(state)
+	d.decodedDictManagers[id] = dictManager
+	return dictManager, nil
+}
+
+func (d *scopeDecoder) decodeValue(encoded snapshotScopeValue) (any, error) {
+	var err error
+	unmarshal := func(value any) {
+		err = json.Unmarshal(encoded.Value, value)
+	}
+	switch encoded.Type {
+	case "none":
+		return nil, nil
+	case "bool":
+		var value bool
+		unmarshal(&value)
+		return value, err
+	case "string":
+		var value string
+		unmarshal(&value)
+		return value, err
+	case "int":
+		var value int
+		unmarshal(&value)
+		return value, err
+	case "uint":
+		var value uint
+		unmarshal(&value)
+		return value, err
+	case "uint64":
+		var value uint64
+		unmarshal(&value)
+		return value, err
+	case "big_int", "big_int_ptr":
+		var value string
+		unmarshal(&value)
+		if err != nil {
+			return nil, err
+		}
+		n, err := decodeBigInt(value)
+		if err != nil || encoded.Type == "big_int_ptr" {
+			return n, err
+		}
+		return *n, nil
+	case "felt":
+		var value string
+		unmarshal(&value)
+		if err != nil {
+			return nil, err
+		}
+		return decodeFelt(value)
+	case "relocatable":
+		var value memory.Relocatable
+		unmarshal(&value)
+		return value, err
+	case "maybe_relocatable":
+		var value snapshotValue
+		unmarshal(&value)
+		if err != nil {
+			return nil, err
+		}
+		return value.decode()
+	case "int_slice":
+		var value []int
+		unmarshal(&value)
+		return value, err
+	case "uint64_slice":
+		var value []uint64
+		unmarshal(&value)
+		return value, err
+	case "felt_slice":
+		var value []string
+		unmarshal(&value)
+		if err != nil {
+			return nil, err
+		}
+		felts := make([]lambdaworks.Felt, 0, len(value))
+		for _, elem := range value {
+			felt, err := decodeFelt(elem)
+			if err != nil {
+				return nil, err
+			}
+			felts = append(felts, felt)
+		}
+		return felts, nil
+	case "maybe_relocatable_slice":
+		var value []snapshotValue
+		unmarshal(&value)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]memory.MaybeRelocatable, 0, len(value))
+		for _, elem := range value {
+			decoded, err := elem.decode()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, decoded)
+		}
+		return values, nil
+	case "dict":
+		var value []snapshotDictEntry
+		unmarshal(&value)
+		if err != nil {
+			return nil, err
+		}
+		return decodeDict(value)
+	case "maybe_relocatable_to_ints":
+		var value []snapshotIntsEntry
+		unmarshal(&value)
+		if err != nil {
+			return nil, err
+		}
+		decoded := make(map[memory.MaybeRelocatable][]int, len(value))
+		for _, entry := range value {
+			key, err := entry.Key.decode()
+			if err != nil {
+				return nil, err
+			}
+			decoded[key] = entry.Values
+		}
+		return decoded, nil
+	case "felt_to_uint64s":
+		var value []snapshotUint64sEntry
+		unmarshal(&value)
+		if err != nil {
+			return nil, err
+		}
+		decoded := make(map[lambdaworks.Felt][]uint64, len(value))
+		for _, entry := range value {
+			key, err := decodeFelt(entry.Key)
+			if err != nil {
+				return nil, err
+			}
+			decoded[key] = entry.Values
+		}
+		return decoded, nil
+	case "json":
+		var value json.RawMessage
+		unmarshal(&value)
+		return value, err
+	case "dict_manager":
+		var id int
+		unmarshal(&id)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeDictManager(id)
+	case "list":
+		var value []snapshotScopeValue
+		unmarshal(&value)
+		if err != nil {
+			return nil, err
+		}
+		elems := make([]any, 0, len(value))
+		for _, elem := range value {
+			decoded, err := d.decodeValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, decoded)
+		}
+		return elems, nil
+	}
+	return nil, errors.Errorf("Unknown scope variable type %q", encoded.Type)
+}
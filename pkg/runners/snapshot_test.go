@@ -0,0 +1,182 @@
+package runners_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/dict_manager"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// Hint processor whose only hint keeps a counter, a dict and a results segment in the execution scopes,
+// and writes the counter and the dict's size to the results segment
+type countingHintProcessor struct{}
+
+func (p *countingHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	return hintParams.Code, nil
+}
+
+func (p *countingHintProcessor) ExecuteHint(v *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	if _, err := execScopes.Get("counter"); err != nil {
+		dictManager := dict_manager.NewDictManager()
+		execScopes.EnterScope(map[string]interface{}{
+			"counter":        *big.NewInt(0),
+			"results":        v.Segments.AddSegment(),
+			"__dict_manager": &dictManager,
+			"dict":           dictManager.NewDefaultDictionary(memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero()), v),
+		})
+	}
+	counterVar, _ := execScopes.Get("counter")
+	counter := counterVar.(big.Int)
+	counter.Add(&counter, big.NewInt(1))
+	execScopes.AssignOrUpdateVariable("counter", counter)
+
+	dictManagerVar, _ := execScopes.Get("__dict_manager")
+	dictManager := dictManagerVar.(*dict_manager.DictManager)
+	dictVar, _ := execScopes.Get("dict")
+	tracker, err := dictManager.GetTracker(dictVar.(memory.Relocatable))
+	if err != nil {
+		return err
+	}
+	key := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(counter.Uint64() % 3))
+	tracker.InsertValue(key, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromBigInt(&counter)))
+
+	resultsVar, _ := execScopes.Get("results")
+	results := resultsVar.(memory.Relocatable)
+	address := results.AddUint(2 * uint(counter.Uint64()))
+	err = v.Segments.Memory.Insert(address, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromBigInt(&counter)))
+	if err != nil {
+		return err
+	}
+	return v.Segments.Memory.Insert(address.AddUint(1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(dictManager.DictEntries()))))
+}
+
+// Returns a runner for a program that loops forever writing 5 at ap: [ap] = 5, ap++; jmp rel -2,
+// with a counting hint at the start of the loop
+func countingLoopRunner(t *testing.T) *runners.CairoRunner {
+	program := vm.Program{
+		Data: []memory.MaybeRelocatable{
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex("0x480680017fff8000")),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex("0x10780017fff7fff")),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero().Sub(lambdaworks.FeltFromUint64(2))),
+		},
+		Identifiers: make(map[string]vm.Identifier),
+		Hints:       map[uint][]parser.HintParams{0: {{Code: "count"}}},
+	}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Fatalf("NewCairoRunner error in test: %s", err)
+	}
+	return runner
+}
+
+func TestSnapshotRestoreResumesRun(t *testing.T) {
+	hintProcessor := &countingHintProcessor{}
+	expected := countingLoopRunner(t)
+	_, err := expected.Initialize()
+	if err != nil {
+		t.Fatalf("Initialize error in test: %s", err)
+	}
+	err = expected.RunForSteps(20, hintProcessor)
+	if err != nil {
+		t.Fatalf("RunForSteps error in test: %s", err)
+	}
+
+	paused := countingLoopRunner(t)
+	expectedEnd, err := paused.Initialize()
+	if err != nil {
+		t.Fatalf("Initialize error in test: %s", err)
+	}
+	err = paused.RunForSteps(7, hintProcessor)
+	if err != nil {
+		t.Fatalf("RunForSteps error in test: %s", err)
+	}
+	var snapshot bytes.Buffer
+	err = paused.WriteSnapshot(&snapshot)
+	if err != nil {
+		t.Fatalf("WriteSnapshot failed: %s", err)
+	}
+
+	resumed := countingLoopRunner(t)
+	end, err := resumed.RestoreSnapshot(&snapshot)
+	if err != nil {
+		t.Fatalf("RestoreSnapshot failed: %s", err)
+	}
+	if end != expectedEnd {
+		t.Errorf("Wrong end pc. Expected %s, got %s", expectedEnd.ToString(), end.ToString())
+	}
+	err = resumed.RunForSteps(13, hintProcessor)
+	if err != nil {
+		t.Fatalf("RunForSteps after restoring failed: %s", err)
+	}
+
+	if resumed.Vm.CurrentStep != expected.Vm.CurrentStep || resumed.Vm.RunContext != expected.Vm.RunContext {
+		t.Errorf("Wrong state after resuming: step %d at %+v, expected step %d at %+v",
+			resumed.Vm.CurrentStep, resumed.Vm.RunContext, expected.Vm.CurrentStep, expected.Vm.RunContext)
+	}
+	if !reflect.DeepEqual(resumed.Vm.Trace, expected.Vm.Trace) {
+		t.Errorf("The resumed run's trace differs from the uninterrupted run's")
+	}
+	if !reflect.DeepEqual(resumed.Vm.Segments.Memory.Data, expected.Vm.Segments.Memory.Data) {
+		t.Errorf("The resumed run's memory differs from the uninterrupted run's")
+	}
+	if resumed.Vm.Segments.Memory.NumSegments() != expected.Vm.Segments.Memory.NumSegments() {
+		t.Errorf("The resumed run has %d segments, expected %d", resumed.Vm.Segments.Memory.NumSegments(), expected.Vm.Segments.Memory.NumSegments())
+	}
+}
+
+func TestSnapshotRestoreDifferentProgram(t *testing.T) {
+	paused, _ := infiniteLoopRunner(t, nil)
+	var snapshot bytes.Buffer
+	err := paused.WriteSnapshot(&snapshot)
+	if err != nil {
+		t.Fatalf("WriteSnapshot failed: %s", err)
+	}
+	_, err = countingLoopRunner(t).RestoreSnapshot(&snapshot)
+	if err == nil {
+		t.Errorf("Restoring a snapshot of another program should fail")
+	}
+}
+
+func TestSnapshotUnsupportedScopeVariable(t *testing.T) {
+	runner, _ := infiniteLoopRunner(t, nil)
+	runner.SetProgramInput([]byte(`{"tasks": []}`))
+	var snapshot bytes.Buffer
+	err := runner.WriteSnapshot(&snapshot)
+	if err != nil {
+		t.Fatalf("The program input should be supported, got: %s", err)
+	}
+
+	unsupported, _ := infiniteLoopRunner(t, map[uint][]parser.HintParams{0: {{Code: "store_value"}}})
+	err = unsupported.RunForSteps(1, &scopeHintProcessor{value: struct{}{}})
+	if err != nil {
+		t.Fatalf("RunForSteps error in test: %s", err)
+	}
+	err = unsupported.WriteSnapshot(&snapshot)
+	if !errors.Is(err, runners.ErrSnapshotUnsupported) {
+		t.Errorf("Expected ErrSnapshotUnsupported, got %v", err)
+	}
+}
+
+// Hint processor that stores a value in the execution scopes
+type scopeHintProcessor struct {
+	value any
+}
+
+func (p *scopeHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	return nil, nil
+}
+
+func (p *scopeHintProcessor) ExecuteHint(v *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	execScopes.AssignOrUpdateVariable("value", p.value)
+	return nil
+}
@@ -0,0 +1,54 @@
+package starknet_crypto
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+// Computes the pedersen hash chain of the given elements, as starkware's compute_hash_chain does:
+// h(data[0], h(data[1], h(..., h(data[n-2], data[n-1]))))
+// Returns zero if no elements are given
+func PedersenHashChain(data []lambdaworks.Felt) lambdaworks.Felt {
+	if len(data) == 0 {
+		return lambdaworks.FeltZero()
+	}
+	acc := data[len(data)-1]
+	for i := len(data) - 2; i >= 0; i-- {
+		acc = PedersenHash(data[i], acc)
+	}
+	return acc
+}
+
+// Computes the pedersen hash of the given elements, as starkware's compute_hash_on_elements does:
+// h(h(h(h(0, data[0]), data[1]), ...), len(data))
+func ComputeHashOnElements(data []lambdaworks.Felt) lambdaworks.Felt {
+	acc := lambdaworks.FeltZero()
+	for _, elem := range data {
+		acc = PedersenHash(acc, elem)
+	}
+	return PedersenHash(acc, lambdaworks.FeltFromUint64(uint64(len(data))))
+}
+
+// Computes the poseidon hash of an arbitrary amount of elements, as starkware's poseidon_hash_many does.
+// The input is padded with a one followed by zeros so that its length is even, and absorbed two elements at a time
+func PoseidonHashMany(data []lambdaworks.Felt) lambdaworks.Felt {
+	padded := make([]lambdaworks.Felt, 0, len(data)+2)
+	padded = append(padded, data...)
+	padded = append(padded, lambdaworks.FeltOne())
+	if len(padded)%2 != 0 {
+		padded = append(padded, lambdaworks.FeltZero())
+	}
+	state := [3]lambdaworks.Felt{lambdaworks.FeltZero(), lambdaworks.FeltZero(), lambdaworks.FeltZero()}
+	for i := 0; i < len(padded); i += 2 {
+		state[0] = state[0].Add(padded[i])
+		state[1] = state[1].Add(padded[i+1])
+		PoseidonPermuteComp(&state)
+	}
+	return state[0]
+}
+
+// Computes the poseidon hash of two elements, as starkware's poseidon_hash does
+func PoseidonHash(x lambdaworks.Felt, y lambdaworks.Felt) lambdaworks.Felt {
+	state := [3]lambdaworks.Felt{x, y, lambdaworks.FeltFromUint64(2)}
+	PoseidonPermuteComp(&state)
+	return state[0]
+}
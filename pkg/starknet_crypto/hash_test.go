@@ -0,0 +1,63 @@
+package starknet_crypto_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	starknet_crypto "github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+)
+
+func TestPedersenHashChain(t *testing.T) {
+	a := lambdaworks.FeltFromUint64(1)
+	b := lambdaworks.FeltFromUint64(2)
+	c := lambdaworks.FeltFromUint64(3)
+	expected := starknet_crypto.PedersenHash(a, starknet_crypto.PedersenHash(b, c))
+	result := starknet_crypto.PedersenHashChain([]lambdaworks.Felt{a, b, c})
+	if result != expected {
+		t.Errorf("Wrong hash chain. Expected %s, got %s", expected.ToHexString(), result.ToHexString())
+	}
+}
+
+func TestPedersenHashChainSingleElement(t *testing.T) {
+	a := lambdaworks.FeltFromUint64(17)
+	result := starknet_crypto.PedersenHashChain([]lambdaworks.Felt{a})
+	if result != a {
+		t.Errorf("Wrong hash chain. Expected %s, got %s", a.ToHexString(), result.ToHexString())
+	}
+}
+
+func TestComputeHashOnElements(t *testing.T) {
+	a := lambdaworks.FeltFromUint64(1)
+	b := lambdaworks.FeltFromUint64(2)
+	expected := starknet_crypto.PedersenHash(
+		starknet_crypto.PedersenHash(starknet_crypto.PedersenHash(lambdaworks.FeltZero(), a), b),
+		lambdaworks.FeltFromUint64(2),
+	)
+	result := starknet_crypto.ComputeHashOnElements([]lambdaworks.Felt{a, b})
+	if result != expected {
+		t.Errorf("Wrong hash. Expected %s, got %s", expected.ToHexString(), result.ToHexString())
+	}
+}
+
+func TestPoseidonHashManyOddLength(t *testing.T) {
+	a := lambdaworks.FeltFromUint64(1)
+	state := [3]lambdaworks.Felt{a, lambdaworks.FeltOne(), lambdaworks.FeltZero()}
+	starknet_crypto.PoseidonPermuteComp(&state)
+	result := starknet_crypto.PoseidonHashMany([]lambdaworks.Felt{a})
+	if result != state[0] {
+		t.Errorf("Wrong hash. Expected %s, got %s", state[0].ToHexString(), result.ToHexString())
+	}
+}
+
+func TestPoseidonHashManyEvenLength(t *testing.T) {
+	a := lambdaworks.FeltFromUint64(1)
+	b := lambdaworks.FeltFromUint64(2)
+	state := [3]lambdaworks.Felt{a, b, lambdaworks.FeltZero()}
+	starknet_crypto.PoseidonPermuteComp(&state)
+	state[0] = state[0].Add(lambdaworks.FeltOne())
+	starknet_crypto.PoseidonPermuteComp(&state)
+	result := starknet_crypto.PoseidonHashMany([]lambdaworks.Felt{a, b})
+	if result != state[0] {
+		t.Errorf("Wrong hash. Expected %s, got %s", state[0].ToHexString(), result.ToHexString())
+	}
+}
@@ -276,3 +276,25 @@ func TestFetchScopeVar(t *testing.T) {
 		t.Errorf("TestGetLocalVariables failed, expected: %s, got: %s", expected.ToSignedFeltString(), result.ToSignedFeltString())
 	}
 }
+
+func TestNewExecutionScopesFromScopes(t *testing.T) {
+	scopes := types.NewExecutionScopes()
+	scopes.EnterScope(map[string]interface{}{"k": lambdaworks.FeltOne()})
+
+	restored, err := types.NewExecutionScopesFromScopes(scopes.Scopes())
+	if err != nil {
+		t.Fatalf("NewExecutionScopesFromScopes failed with error: %s", err)
+	}
+	result, err := restored.Get("k")
+	if err != nil || result != lambdaworks.FeltOne() {
+		t.Errorf("Wrong value of k: %v, error: %v", result, err)
+	}
+	if restored.ExitScope() != nil || restored.ExitScope() == nil {
+		t.Errorf("The restored scopes should hold the main scope and the entered one")
+	}
+
+	_, err = types.NewExecutionScopesFromScopes(nil)
+	if err == nil {
+		t.Errorf("Execution scopes without the main scope should fail")
+	}
+}
@@ -28,6 +28,19 @@ func NewExecutionScopes() *ExecutionScopes {
 	return &ExecutionScopes{data}
 }
 
+// Creates execution scopes holding the given scopes, from the main scope to the current one
+func NewExecutionScopesFromScopes(scopes []map[string]interface{}) (*ExecutionScopes, error) {
+	if len(scopes) == 0 {
+		return nil, ExecutionScopesError(errors.Errorf("There must be at least the main scope"))
+	}
+	return &ExecutionScopes{scopes}, nil
+}
+
+// Returns the scopes, from the main scope to the current one
+func (es *ExecutionScopes) Scopes() []map[string]interface{} {
+	return es.data
+}
+
 func (es *ExecutionScopes) EnterScope(newScopeLocals map[string]interface{}) {
 	es.data = append(es.data, newScopeLocals)
 
@@ -1,12 +1,17 @@
 package cairo_run
 
 import (
+	"context"
 	"encoding/binary"
 	"fmt"
 	"io"
 	"sort"
+	"time"
 
+	"github.com/lambdaclass/cairo-vm.go/pkg/cairo_pie"
 	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/external_hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/python_interpreter"
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
 	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
@@ -25,6 +30,44 @@ type CairoRunConfig struct {
 	ProofMode           bool
 	Layout              string
 	SecureRun           bool
+	// Json input of the program, available to its hints as program_input
+	ProgramInput []byte
+	// Skip unknown hints with a warning instead of failing the run
+	SkipUnknownHints bool
+	// Interpret the hints the VM doesn't implement as python code, see python_interpreter.PythonHintProcessor
+	InterpretPythonHints bool
+	// Command and arguments of a worker process that executes the hints the VM doesn't implement,
+	// see external_hints.ExternalHintProcessor
+	HintWorker []string
+	// Maximum time the hint worker can take to execute a hint, after which the worker is stopped and the run fails.
+	// 0 for no timeout
+	HintWorkerTimeout time.Duration
+	// Fail the bootloader inputs that make hints read or write files, such as CairoPiePath tasks, for runs of
+	// untrusted inputs. See bootloader.SimpleBootloaderInput.CheckNoFilePaths
+	DisableFilePathInputs bool
+	// Limits on the memory, segments, builtin instances, hints and dict entries used by the run, see vm.RunLimits
+	RunLimits *vm.RunLimits
+}
+
+// Returns the hint processor selected by the config. Processors that implement io.Closer must be closed after the run
+func newHintProcessor(cairoRunConfig CairoRunConfig) (vm.HintProcessor, error) {
+	hintProcessor := hints.CairoVmHintProcessor{SkipUnknownHints: cairoRunConfig.SkipUnknownHints, DisableFilePathInputs: cairoRunConfig.DisableFilePathInputs}
+	if cairoRunConfig.InterpretPythonHints && len(cairoRunConfig.HintWorker) != 0 {
+		return nil, errors.New("Python hints can't be interpreted when using a hint worker")
+	}
+	if len(cairoRunConfig.HintWorker) != 0 {
+		externalProcessor, err := external_hints.NewExternalHintProcessor(cairoRunConfig.HintWorker[0], cairoRunConfig.HintWorker[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		externalProcessor.CairoVmHintProcessor = hintProcessor
+		externalProcessor.HintTimeout = cairoRunConfig.HintWorkerTimeout
+		return externalProcessor, nil
+	}
+	if cairoRunConfig.InterpretPythonHints {
+		return &python_interpreter.PythonHintProcessor{CairoVmHintProcessor: hintProcessor}, nil
+	}
+	return &hintProcessor, nil
 }
 
 func CairoRunError(err error) error {
@@ -32,29 +75,55 @@ func CairoRunError(err error) error {
 }
 
 func CairoRun(programPath string, cairoRunConfig CairoRunConfig) (*runners.CairoRunner, error) {
-	compiledProgram, err := parser.Parse(programPath)
+	return CairoRunWithContext(context.Background(), programPath, cairoRunConfig)
+}
+
+// Same as CairoRun, but stops the run with a *runners.RunCancelledError once ctx is cancelled or its deadline passes.
+// Python hints and hint workers are stopped when ctx is done, see runners.CairoRunner.RunUntilPCWithContext
+func CairoRunWithContext(ctx context.Context, programPath string, cairoRunConfig CairoRunConfig) (*runners.CairoRunner, error) {
+	// The VM only uses the instruction locations of the debug info, so the source files it holds aren't loaded
+	streamedProgram, err := parser.ParseFileStream(programPath, parser.StreamOptions{DebugInfo: parser.DebugInfoInstructionLocations})
 	if err != nil {
 		return nil, CairoRunError(err)
 	}
-	programJson := vm.DeserializeProgramJson(compiledProgram)
+	return CairoRunProgramWithContext(ctx, vm.DeserializeStreamedProgram(streamedProgram), cairoRunConfig)
+}
+
+// Runs an already loaded program, such as one embedded in a binary (see parser.ParseBytes) or received over the
+// network. The program can be shared by concurrent runs
+func CairoRunProgram(program vm.Program, cairoRunConfig CairoRunConfig) (*runners.CairoRunner, error) {
+	return CairoRunProgramWithContext(context.Background(), program, cairoRunConfig)
+}
 
+// Same as CairoRunProgram, but stops the run once ctx is done, see CairoRunWithContext
+func CairoRunProgramWithContext(ctx context.Context, program vm.Program, cairoRunConfig CairoRunConfig) (*runners.CairoRunner, error) {
 	layout := cairoRunConfig.Layout
 	proofMode := cairoRunConfig.ProofMode
 
-	cairoRunner, err := runners.NewCairoRunner(programJson, layout, proofMode)
+	cairoRunner, err := runners.NewCairoRunner(program, layout, proofMode)
 	if err != nil {
 		return nil, err
 	}
+	if cairoRunConfig.ProgramInput != nil {
+		cairoRunner.SetProgramInput(cairoRunConfig.ProgramInput)
+	}
 	end, err := cairoRunner.Initialize()
 	if err != nil {
 		return nil, err
 	}
-	hintProcessor := hints.CairoVmHintProcessor{}
-	err = cairoRunner.RunUntilPC(end, &hintProcessor)
+	hintProcessor, err := newHintProcessor(cairoRunConfig)
+	if err != nil {
+		return nil, CairoRunError(err)
+	}
+	if closer, ok := hintProcessor.(io.Closer); ok {
+		defer closer.Close()
+	}
+	cairoRunner.Vm.RunLimits = cairoRunConfig.RunLimits
+	err = cairoRunner.RunUntilPCWithContext(ctx, end, hintProcessor)
 	if err != nil {
 		return nil, err
 	}
-	err = cairoRunner.EndRun(cairoRunConfig.DisableTracePadding, false, &hintProcessor)
+	err = cairoRunner.EndRunWithContext(ctx, cairoRunConfig.DisableTracePadding, false, hintProcessor)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +148,66 @@ func CairoRun(programPath string, cairoRunConfig CairoRunConfig) (*runners.Cairo
 	return cairoRunner, err
 }
 
+// Re-runs the execution stored in a Cairo PIE and checks that the pie produced by the run matches the given one.
+// This validates pies received from untrusted sources before they are proven
+func CairoRunPie(pie *cairo_pie.CairoPie, cairoRunConfig CairoRunConfig) (*runners.CairoRunner, error) {
+	return CairoRunPieWithContext(context.Background(), pie, cairoRunConfig)
+}
+
+// Same as CairoRunPie, but stops the run once ctx is done, see CairoRunWithContext
+func CairoRunPieWithContext(ctx context.Context, pie *cairo_pie.CairoPie, cairoRunConfig CairoRunConfig) (*runners.CairoRunner, error) {
+	if cairoRunConfig.ProofMode {
+		return nil, CairoRunError(errors.New("Cairo PIEs can't be run in proof mode"))
+	}
+	cairoRunner, err := runners.NewCairoRunner(pie.Program(), cairoRunConfig.Layout, false)
+	if err != nil {
+		return nil, err
+	}
+	end, err := cairoRunner.InitializeFromCairoPie(pie)
+	if err != nil {
+		return nil, CairoRunError(err)
+	}
+	hintProcessor, err := newHintProcessor(cairoRunConfig)
+	if err != nil {
+		return nil, CairoRunError(err)
+	}
+	if closer, ok := hintProcessor.(io.Closer); ok {
+		defer closer.Close()
+	}
+	cairoRunner.Vm.RunLimits = cairoRunConfig.RunLimits
+	err = cairoRunner.RunUntilPCWithContext(ctx, end, hintProcessor)
+	if err != nil {
+		return nil, err
+	}
+	err = cairoRunner.EndRunWithContext(ctx, cairoRunConfig.DisableTracePadding, false, hintProcessor)
+	if err != nil {
+		return nil, err
+	}
+	err = cairoRunner.ReadReturnValues()
+	if err != nil {
+		return nil, err
+	}
+
+	if cairoRunConfig.SecureRun {
+		err = runners.VerifySecureRunner(cairoRunner, true, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	runPie, err := cairoRunner.GetCairoPie()
+	if err != nil {
+		return nil, CairoRunError(err)
+	}
+	err = runPie.CheckCompatibility(pie)
+	if err != nil {
+		return nil, CairoRunError(err)
+	}
+
+	err = cairoRunner.Vm.Relocate()
+	return cairoRunner, err
+}
+
 // Writes the trace binary representation.
 //
 // Bincode encodes to little endian by default and each trace entry is composed of
@@ -2,8 +2,17 @@ package cairo_run_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
+	"github.com/lambdaclass/cairo-vm.go/pkg/bootloader"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
 )
 
@@ -384,6 +393,14 @@ func TestExampleBlake2s(t *testing.T) {
 	testProgram("example_blake2s", t)
 }
 
+func TestPackedSha256(t *testing.T) {
+	testProgram("packed_sha256_test", t)
+}
+
+func TestSha256(t *testing.T) {
+	testProgram("sha256_test", t)
+}
+
 func TestUint256Integration(t *testing.T) {
 	testProgram("uint256_integration_tests", t)
 }
@@ -395,3 +412,184 @@ func TestUint256(t *testing.T) {
 func TestUint256Root(t *testing.T) {
 	testProgram("uint256_root", t)
 }
+
+func TestEcRecover(t *testing.T) {
+	testProgram("ec_recover", t)
+}
+
+func TestEcMulInner(t *testing.T) {
+	testProgram("ec_mul_inner", t)
+}
+
+func TestSecp256k1Recover(t *testing.T) {
+	testProgram("secp256k1_recover", t)
+}
+
+func TestRecoverY(t *testing.T) {
+	testProgram("recover_y", t)
+}
+
+func TestUint384(t *testing.T) {
+	testProgram("uint384_test", t)
+}
+
+func TestUint384Extension(t *testing.T) {
+	testProgram("uint384_extension_test", t)
+}
+
+func TestOutputPages(t *testing.T) {
+	testProgram("output_pages", t)
+}
+
+func TestUint512(t *testing.T) {
+	testProgram("uint512_test", t)
+}
+
+func TestFq(t *testing.T) {
+	testProgram("fq_test", t)
+}
+
+func TestEd25519Field(t *testing.T) {
+	testProgram("ed25519_field_test", t)
+}
+
+func TestPrintHints(t *testing.T) {
+	testProgram("print_hints", t)
+}
+
+func TestCairoRunProgram(t *testing.T) {
+	compiledProgram, err := parser.ParseBytes([]byte(`{
+		"builtins": [],
+		"data": ["0x208b7fff7fff7ffe"],
+		"hints": {},
+		"identifiers": {"__main__.main": {"decorators": [], "pc": 0, "type": "function"}},
+		"main_scope": "__main__",
+		"prime": "0x800000000000011000000000000000000000000000000000000000000000001",
+		"reference_manager": {"references": []}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseBytes failed with error: %s", err)
+	}
+	cairoRunConfig := cairo_run.CairoRunConfig{Layout: "plain", SecureRun: true}
+	cairoRunner, err := cairo_run.CairoRunProgram(vm.DeserializeProgramJson(compiledProgram), cairoRunConfig)
+	if err != nil {
+		t.Fatalf("CairoRunProgram failed with error: %s", err)
+	}
+	if cairoRunner.Vm.CurrentStep != 1 {
+		t.Errorf("Expected the program to run for 1 step, ran for %d", cairoRunner.Vm.CurrentStep)
+	}
+}
+
+func TestCairoRunMissingProgram(t *testing.T) {
+	_, err := cairo_run.CairoRun("missing_program.json", cairo_run.CairoRunConfig{Layout: "plain"})
+	if err == nil {
+		t.Errorf("CairoRun should fail for a missing program file")
+	}
+}
+
+func TestCairoRunKeepsInstructionLocations(t *testing.T) {
+	programPath := filepath.Join(t.TempDir(), "return.json")
+	err := os.WriteFile(programPath, []byte(`{
+		"builtins": [],
+		"data": ["0x208b7fff7fff7ffe"],
+		"debug_info": {
+			"file_contents": {"return.cairo": "func main() {\n    return ();\n}\n"},
+			"instruction_locations": {"0": {"accessible_scopes": ["__main__.main"], "inst": {"start_line": 2}}}
+		},
+		"hints": {},
+		"identifiers": {"__main__.main": {"decorators": [], "pc": 0, "type": "function"}},
+		"main_scope": "__main__",
+		"prime": "0x800000000000011000000000000000000000000000000000000000000000001",
+		"reference_manager": {"references": []}
+	}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cairoRunner, err := cairo_run.CairoRun(programPath, cairo_run.CairoRunConfig{Layout: "plain", SecureRun: true})
+	if err != nil {
+		t.Fatalf("CairoRun failed with error: %s", err)
+	}
+	if cairoRunner.Program.InstructionLocations[0].Inst.StartLine != 2 {
+		t.Errorf("Wrong instruction locations: %+v", cairoRunner.Program.InstructionLocations)
+	}
+}
+
+// Runs the simple bootloader with a RunProgramTask and a CairoPiePath task of bitwise_output, whose output is [0]
+func TestSimpleBootloader(t *testing.T) {
+	taskPath := "../../../cairo_programs/bitwise_output.json"
+	taskJson, err := os.ReadFile(taskPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskRunner, err := cairo_run.CairoRun(taskPath, cairo_run.CairoRunConfig{Layout: "all_cairo"})
+	if err != nil {
+		t.Fatalf("Task execution failed with error: %s", err)
+	}
+	pie, err := taskRunner.GetCairoPie()
+	if err != nil {
+		t.Fatalf("GetCairoPie failed with error: %s", err)
+	}
+	pieFile, err := os.Create(filepath.Join(t.TempDir(), "pie.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pieFile.Close()
+	err = pie.WriteZip(pieFile)
+	if err != nil {
+		t.Fatalf("WriteZip failed with error: %s", err)
+	}
+
+	factTopologiesPath := filepath.Join(t.TempDir(), "fact_topologies.json")
+	programInput, err := json.Marshal(bootloader.SimpleBootloaderInput{
+		Tasks: []bootloader.TaskSpec{
+			{Type: bootloader.RUN_PROGRAM_TASK, Program: taskJson, ProgramInput: json.RawMessage("{}")},
+			{Type: bootloader.CAIRO_PIE_PATH, Path: pieFile.Name()},
+		},
+		FactTopologiesPath: &factTopologiesPath,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cairoRunner, err := cairo_run.CairoRun("../../../cairo_programs/bootloader/simple_bootloader.json", cairo_run.CairoRunConfig{Layout: "all_cairo", ProgramInput: programInput})
+	if err != nil {
+		t.Fatalf("Bootloader execution failed with error: %s", err)
+	}
+
+	programHash, err := taskRunner.Program.ComputeProgramHashChain(0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pieProgram := pie.Program()
+	pieProgramHash, err := pieProgram.ComputeProgramHashChain(0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The number of tasks, then the size, program hash and output of each task
+	expectedOutput := []lambdaworks.Felt{
+		lambdaworks.FeltFromUint64(2),
+		lambdaworks.FeltFromUint64(3), programHash, lambdaworks.FeltZero(),
+		lambdaworks.FeltFromUint64(3), pieProgramHash, lambdaworks.FeltZero(),
+	}
+	output, err := cairoRunner.GetProgramOutput()
+	if err != nil {
+		t.Fatalf("GetProgramOutput failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(output, expectedOutput) {
+		t.Errorf("Wrong output. Expected %v, got %v", expectedOutput, output)
+	}
+
+	factTopologiesJson, err := os.ReadFile(factTopologiesPath)
+	if err != nil {
+		t.Fatalf("The fact topologies were not written: %s", err)
+	}
+	var factTopologies map[string][]runners.FactTopology
+	err = json.Unmarshal(factTopologiesJson, &factTopologies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskTopology := runners.FactTopology{TreeStructure: []uint{1, 0}, PageSizes: []uint{1}}
+	expectedTopologies := map[string][]runners.FactTopology{"fact_topologies": {taskTopology, taskTopology}}
+	if !reflect.DeepEqual(factTopologies, expectedTopologies) {
+		t.Errorf("Wrong fact topologies. Expected %v, got %v", expectedTopologies, factTopologies)
+	}
+}
@@ -1,6 +1,8 @@
 package vm
 
 import (
+	"context"
+
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
 	"github.com/lambdaclass/cairo-vm.go/pkg/types"
@@ -12,3 +14,27 @@ type HintProcessor interface {
 	// Executes the hint which's data is provided by a dynamic structure previously created by CompileHint
 	ExecuteHint(vm *VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error
 }
+
+// Optional interface of the hint processors whose hints use the identifiers of their program,
+// such as the struct definitions needed to access the members of ids by name
+type IdentifiersHintProcessor interface {
+	HintProcessor
+	// Same as CompileHint, also receiving the identifiers of the program the hint belongs to
+	CompileHintWithIdentifiers(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager, identifiers map[string]Identifier) (any, error)
+}
+
+// Optional interface of the hint processors whose hints can block or loop, such as interpreters and external workers.
+// Runs stopped by a context pass it to the processor, which must stop the hint once the context is done
+type ContextHintProcessor interface {
+	HintProcessor
+	// Same as ExecuteHint, returning an error as soon as possible once ctx is done
+	ExecuteHintWithContext(ctx context.Context, vm *VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error
+}
+
+// Compiles a hint of the given program, passing it the program's identifiers if the hint processor uses them
+func CompileProgramHint(hintProcessor HintProcessor, hintParams *parser.HintParams, program *Program) (any, error) {
+	if processor, ok := hintProcessor.(IdentifiersHintProcessor); ok {
+		return processor.CompileHintWithIdentifiers(hintParams, &program.ReferenceManager, program.Identifiers)
+	}
+	return hintProcessor.CompileHint(hintParams, &program.ReferenceManager)
+}
@@ -0,0 +1,140 @@
+package vm
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// Observes the execution of the VM, so that profilers, debuggers or tracers can be built outside of it.
+// Returning an error from any of the methods stops the run with that error
+type Hooks interface {
+	// Called at the start of each step, before its hints are executed
+	PreStep(vm *VirtualMachine) error
+	// Called after the instruction of each step is executed
+	PostStep(vm *VirtualMachine) error
+	// Called before each hint is executed, with the data the hint processor compiled for it
+	OnHint(vm *VirtualMachine, hintData any) error
+	// Called after each memory write, by the instructions, the hints or the builtins
+	OnMemoryWrite(vm *VirtualMachine, addr memory.Relocatable, val memory.MaybeRelocatable) error
+	// Called after a segment is added. As adding a segment can't fail, an error stops the run at the end of
+	// the current hint or instruction
+	OnSegmentAdd(vm *VirtualMachine, base memory.Relocatable) error
+}
+
+// Implements every Hooks method as a no-op, so that hooks only need to implement the methods they use
+type NoopHooks struct{}
+
+func (NoopHooks) PreStep(vm *VirtualMachine) error {
+	return nil
+}
+
+func (NoopHooks) PostStep(vm *VirtualMachine) error {
+	return nil
+}
+
+func (NoopHooks) OnHint(vm *VirtualMachine, hintData any) error {
+	return nil
+}
+
+func (NoopHooks) OnMemoryWrite(vm *VirtualMachine, addr memory.Relocatable, val memory.MaybeRelocatable) error {
+	return nil
+}
+
+func (NoopHooks) OnSegmentAdd(vm *VirtualMachine, base memory.Relocatable) error {
+	return nil
+}
+
+// Registers hooks to be called during the run, after the ones already registered.
+// A memory observer set before the first hooks keeps being notified, before the hooks.
+// Runs without hooks don't pay for them
+func (v *VirtualMachine) AddHooks(hooks Hooks) {
+	v.hooks = append(v.hooks, hooks)
+	if observer, ok := v.Segments.Memory.Observer.(*hooksMemoryObserver); ok && observer.vm == v {
+		return
+	}
+	v.Segments.Memory.Observer = &hooksMemoryObserver{vm: v, next: v.Segments.Memory.Observer}
+}
+
+// Forwards the memory events to the VM's hooks
+type hooksMemoryObserver struct {
+	vm *VirtualMachine
+	// The observer set before the hooks were added, nil if there was none
+	next memory.MemoryObserver
+}
+
+func (o *hooksMemoryObserver) OnMemoryWrite(addr memory.Relocatable, val memory.MaybeRelocatable) error {
+	if o.next != nil {
+		err := o.next.OnMemoryWrite(addr, val)
+		if err != nil {
+			return err
+		}
+	}
+	for _, hooks := range o.vm.hooks {
+		err := hooks.OnMemoryWrite(o.vm, addr, val)
+		if err != nil {
+			// Kept in case the caller ignores the error of the write
+			o.vm.recordHooksError(err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *hooksMemoryObserver) OnSegmentAdd(base memory.Relocatable) {
+	if o.next != nil {
+		o.next.OnSegmentAdd(base)
+	}
+	for _, hooks := range o.vm.hooks {
+		err := hooks.OnSegmentAdd(o.vm, base)
+		if err != nil {
+			o.vm.recordHooksError(err)
+		}
+	}
+}
+
+func (v *VirtualMachine) recordHooksError(err error) {
+	if v.hooksErr == nil {
+		v.hooksErr = err
+	}
+}
+
+func (v *VirtualMachine) preStepHooks() error {
+	// Segments can also be added outside of the hints, such as by the runner
+	err := v.hooksError()
+	if err != nil {
+		return err
+	}
+	for _, hooks := range v.hooks {
+		err := hooks.PreStep(v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *VirtualMachine) postStepHooks() error {
+	for _, hooks := range v.hooks {
+		err := hooks.PostStep(v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *VirtualMachine) hintHooks(hintData any) error {
+	for _, hooks := range v.hooks {
+		err := hooks.OnHint(v, hintData)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the first error of the hooks of the segments added and the memory writes since the last call
+func (v *VirtualMachine) hooksError() error {
+	err := v.hooksErr
+	v.hooksErr = nil
+	return err
+}
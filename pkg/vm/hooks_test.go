@@ -0,0 +1,138 @@
+package vm_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// Records the events of the run
+type recordingHooks struct {
+	vm.NoopHooks
+	events []string
+	// Event at which the hooks stop the run
+	abortAt string
+}
+
+var errAborted = errors.New("aborted by hooks")
+
+func (h *recordingHooks) record(event string) error {
+	h.events = append(h.events, event)
+	if event == h.abortAt {
+		return errAborted
+	}
+	return nil
+}
+
+func (h *recordingHooks) PreStep(v *vm.VirtualMachine) error {
+	return h.record("pre_step")
+}
+
+func (h *recordingHooks) PostStep(v *vm.VirtualMachine) error {
+	return h.record("post_step")
+}
+
+func (h *recordingHooks) OnHint(v *vm.VirtualMachine, hintData any) error {
+	return h.record("hint")
+}
+
+func (h *recordingHooks) OnMemoryWrite(v *vm.VirtualMachine, addr memory.Relocatable, val memory.MaybeRelocatable) error {
+	return h.record("write " + addr.ToString())
+}
+
+func (h *recordingHooks) OnSegmentAdd(v *vm.VirtualMachine, base memory.Relocatable) error {
+	return h.record("segment " + base.ToString())
+}
+
+func addSegmentHint(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+	v.Segments.AddSegment()
+	return nil
+}
+
+func stepWithHooks(hooks vm.Hooks, hints ...hintFunc) (*vm.VirtualMachine, error) {
+	virtualMachine, hintDataMap := newStepVm(hints...)
+	virtualMachine.AddHooks(hooks)
+	err := virtualMachine.Step(&funcHintProcessor{}, &hintDataMap, nil, types.NewExecutionScopes())
+	return virtualMachine, err
+}
+
+func TestHooksEvents(t *testing.T) {
+	hooks := &recordingHooks{}
+	_, err := stepWithHooks(hooks, addSegmentHint)
+	if err != nil {
+		t.Fatalf("Step failed with error: %s", err)
+	}
+	expected := []string{"pre_step", "hint", "segment {2:0}", "write {1:1}", "post_step"}
+	if !reflect.DeepEqual(hooks.events, expected) {
+		t.Errorf("Wrong events. Expected %v, got %v", expected, hooks.events)
+	}
+}
+
+func TestHooksAbort(t *testing.T) {
+	for _, abortAt := range []string{"pre_step", "hint", "segment {2:0}", "write {1:1}", "post_step"} {
+		hooks := &recordingHooks{abortAt: abortAt}
+		virtualMachine, err := stepWithHooks(hooks, addSegmentHint)
+		if !errors.Is(err, errAborted) {
+			t.Errorf("Expected the run to be aborted at %s, got: %v", abortAt, err)
+		}
+		if hooks.events[len(hooks.events)-1] != abortAt {
+			t.Errorf("Events after aborting at %s: %v", abortAt, hooks.events)
+		}
+		if abortAt != "post_step" && virtualMachine.CurrentStep != 0 {
+			t.Errorf("The step shouldn't have finished after aborting at %s", abortAt)
+		}
+	}
+}
+
+func TestHooksMultiple(t *testing.T) {
+	first := &recordingHooks{}
+	second := &recordingHooks{}
+	virtualMachine, hintDataMap := newStepVm()
+	virtualMachine.AddHooks(first)
+	virtualMachine.AddHooks(second)
+	err := virtualMachine.Step(&funcHintProcessor{}, &hintDataMap, nil, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Step failed with error: %s", err)
+	}
+	if len(first.events) != 3 || !reflect.DeepEqual(first.events, second.events) {
+		t.Errorf("Both hooks should have been called. Got %v and %v", first.events, second.events)
+	}
+}
+
+// Records the memory events it observes
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) OnMemoryWrite(addr memory.Relocatable, val memory.MaybeRelocatable) error {
+	o.events = append(o.events, "write "+addr.ToString())
+	return nil
+}
+
+func (o *recordingObserver) OnSegmentAdd(base memory.Relocatable) {
+	o.events = append(o.events, "segment "+base.ToString())
+}
+
+func TestHooksKeepExistingObserver(t *testing.T) {
+	observer := &recordingObserver{}
+	hooks := &recordingHooks{}
+	virtualMachine, hintDataMap := newStepVm(addSegmentHint)
+	virtualMachine.Segments.Memory.Observer = observer
+	virtualMachine.AddHooks(hooks)
+	virtualMachine.AddHooks(&recordingHooks{})
+	err := virtualMachine.Step(&funcHintProcessor{}, &hintDataMap, nil, types.NewExecutionScopes())
+	if err != nil {
+		t.Fatalf("Step failed with error: %s", err)
+	}
+	expected := []string{"segment {2:0}", "write {1:1}"}
+	if !reflect.DeepEqual(observer.events, expected) {
+		t.Errorf("Wrong observed events. Expected %v, got %v", expected, observer.events)
+	}
+	if len(hooks.events) != 5 {
+		t.Errorf("The hooks should have been called once per event, got %v", hooks.events)
+	}
+}
@@ -0,0 +1,87 @@
+package memory
+
+// Kind of change recorded in the memory's journal
+type journalEntryKind uint8
+
+const (
+	journalWrite journalEntryKind = iota
+	journalAccess
+	journalSegment
+)
+
+// A change made to the memory, with what is needed to undo it
+type journalEntry struct {
+	kind journalEntryKind
+	// Written address for writes, accessed address for accesses, and base of the segment for segments
+	address Relocatable
+	// Written size of the address's segment before a write
+	prevWrittenSize uint
+}
+
+// Starts recording the writes of new cells, the accessed addresses and the added segments, so that they can be
+// undone with UndoJournal. Only the changes made after this call are recorded
+func (m *Memory) EnableJournal() {
+	m.journaling = true
+}
+
+// Returns the number of changes recorded in the journal, which identifies the current point of the journal.
+// The changes discarded by TruncateJournal are counted as well, so that the points of the journal stay the same
+func (m *Memory) JournalLen() int {
+	return m.journalStart + len(m.journal)
+}
+
+// Discards the changes recorded before the journal had the given length, which can't be undone anymore. Used to bound
+// the memory taken by the journal
+func (m *Memory) TruncateJournal(journalLen int) {
+	discarded := journalLen - m.journalStart
+	if discarded <= 0 {
+		return
+	}
+	if discarded > len(m.journal) {
+		discarded = len(m.journal)
+	}
+	m.journal = m.journal[discarded:]
+	m.journalStart += discarded
+}
+
+// Undoes the changes recorded after the journal had the given length, in reverse order, and discards them.
+// Changes discarded by TruncateJournal are not undone.
+// Undone cells are removed from the memory and have to be validated again if they are written again.
+// The observer is not notified of the undone changes
+func (m *Memory) UndoJournal(journalLen int) {
+	journalLen -= m.journalStart
+	if journalLen < 0 {
+		journalLen = 0
+	}
+	for i := len(m.journal) - 1; i >= journalLen; i-- {
+		entry := m.journal[i]
+		switch entry.kind {
+		case journalWrite:
+			delete(m.Data, entry.address)
+			delete(m.validatedAdresses, entry.address)
+			m.writtenSizes[entry.address.SegmentIndex] = entry.prevWrittenSize
+		case journalAccess:
+			delete(m.AccessedAddresses, entry.address)
+		case journalSegment:
+			m.numSegments = uint(entry.address.SegmentIndex)
+			if len(m.writtenSizes) > entry.address.SegmentIndex {
+				m.writtenSizes = m.writtenSizes[:entry.address.SegmentIndex]
+			}
+		}
+	}
+	if journalLen < len(m.journal) {
+		m.journal = m.journal[:journalLen]
+	}
+}
+
+func (m *Memory) recordWrite(addr Relocatable) {
+	m.journal = append(m.journal, journalEntry{kind: journalWrite, address: addr, prevWrittenSize: m.SegmentWrittenSize(uint(addr.SegmentIndex))})
+}
+
+func (m *Memory) recordAccess(addr Relocatable) {
+	m.journal = append(m.journal, journalEntry{kind: journalAccess, address: addr})
+}
+
+func (m *Memory) recordSegment(base Relocatable) {
+	m.journal = append(m.journal, journalEntry{kind: journalSegment, address: base})
+}
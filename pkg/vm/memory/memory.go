@@ -37,6 +37,15 @@ func (set AddressSet) Contains(element Relocatable) bool {
 // A function that validates a memory address and returns a list of validated addresses
 type ValidationRule func(*Memory, Relocatable) ([]Relocatable, error)
 
+// Observes the writes to the memory and the segments added to it, such as the VM's hooks
+type MemoryObserver interface {
+	// Called after each successful write, once the value is stored. Returning an error doesn't undo the write, but
+	// Insert returns the error so that the run stops
+	OnMemoryWrite(addr Relocatable, val MaybeRelocatable) error
+	// Called after a segment is added, with its first address
+	OnSegmentAdd(base Relocatable)
+}
+
 // Memory represents the Cairo VM's memory.
 type Memory struct {
 	Data              map[Relocatable]MaybeRelocatable
@@ -47,6 +56,40 @@ type Memory struct {
 	// The map is of the form `segmentIndex` -> `offset`. This is to
 	// make the counting of memory holes easier
 	AccessedAddresses map[Relocatable]bool
+	// Size of each segment given by its highest written offset, kept up to date during the run
+	writtenSizes []uint
+	// Notified of the memory writes and the added segments, nil if there is no observer
+	Observer MemoryObserver
+	// Whether the changes to the memory are recorded in the journal (see EnableJournal)
+	journaling bool
+	journal    []journalEntry
+	// Number of changes discarded from the start of the journal by TruncateJournal
+	journalStart int
+	// Enforced as the memory is written and its segments are added
+	Limits MemoryLimits
+	// Set once a write or a segment is refused because of the limits
+	limitErr error
+}
+
+// Limits on the memory, enforced as it is written and its segments are added. 0 means no limit. See vm.RunLimits
+type MemoryLimits struct {
+	MaxCells    uint
+	MaxSegments uint
+}
+
+var ErrMaxCellsExceeded = errors.New("Memory cells limit exceeded")
+var ErrMaxSegmentsExceeded = errors.New("Segments limit exceeded")
+
+// Returns the error of the first write or segment refused because of the memory's limits, nil if none was
+func (m *Memory) LimitError() error {
+	return m.limitErr
+}
+
+func (m *Memory) exceedLimit(err error, used uint, limit uint) error {
+	if m.limitErr == nil {
+		m.limitErr = fmt.Errorf("%w, used: %d, limit: %d", err, used, limit)
+	}
+	return m.limitErr
 }
 
 var ErrMissingSegmentUsize = errors.New("Segment effective sizes haven't been calculated")
@@ -88,6 +131,10 @@ func (m *Memory) Insert(addr Relocatable, val *MaybeRelocatable) error {
 
 	// Check that insertions are preformed within the memory bounds
 	if addr.SegmentIndex >= int(m.numSegments) {
+		if m.limitErr != nil {
+			// Most likely a segment refused by the limits
+			return m.limitErr
+		}
 		return errors.Errorf("Error: Inserting into a non allocated segment %s", addr.ToString())
 	}
 
@@ -96,8 +143,37 @@ func (m *Memory) Insert(addr Relocatable, val *MaybeRelocatable) error {
 	if ok && prev_elem != *val {
 		return ErrMemoryWriteOnce(addr, prev_elem, *val)
 	}
+	if !ok && m.Limits.MaxCells != 0 && uint(len(m.Data)) >= m.Limits.MaxCells {
+		return m.exceedLimit(ErrMaxCellsExceeded, uint(len(m.Data))+1, m.Limits.MaxCells)
+	}
+	if m.journaling && !ok {
+		m.recordWrite(addr)
+	}
 	m.Data[addr] = *val
-	return m.validateAddress(addr)
+	m.updateWrittenSize(addr)
+	err := m.validateAddress(addr)
+	if err != nil || m.Observer == nil {
+		return err
+	}
+	return m.Observer.OnMemoryWrite(addr, *val)
+}
+
+func (m *Memory) updateWrittenSize(addr Relocatable) {
+	for len(m.writtenSizes) <= addr.SegmentIndex {
+		m.writtenSizes = append(m.writtenSizes, 0)
+	}
+	if addr.Offset >= m.writtenSizes[addr.SegmentIndex] {
+		m.writtenSizes[addr.SegmentIndex] = addr.Offset + 1
+	}
+}
+
+// Returns the size of the segment given by its highest written offset so far.
+// Unlike the segment's used size, it is available during the run
+func (m *Memory) SegmentWrittenSize(segmentIndex uint) uint {
+	if segmentIndex >= uint(len(m.writtenSizes)) {
+		return 0
+	}
+	return m.writtenSizes[segmentIndex]
 }
 
 // Gets some value stored in the memory address `addr`.
@@ -189,6 +265,9 @@ func (m *Memory) validateAddress(addr Relocatable) error {
 }
 
 func (m *Memory) MarkAsAccessed(address Relocatable) {
+	if m.journaling && !m.AccessedAddresses[address] {
+		m.recordAccess(address)
+	}
 	m.AccessedAddresses[address] = true
 }
 
@@ -457,3 +457,73 @@ func TestValidateMemoryForValidSignature(t *testing.T) {
 		t.Errorf("ValidateExistingMemory error in test: %s", err)
 	}
 }
+
+func TestMemorySegmentWrittenSize(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	val := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5))
+
+	for _, key := range []memory.Relocatable{memory.NewRelocatable(1, 4), memory.NewRelocatable(1, 2), memory.NewRelocatable(0, 0)} {
+		err := mem.Insert(key, val)
+		if err != nil {
+			t.Errorf("Insert error in test: %s", err)
+		}
+	}
+	if mem.SegmentWrittenSize(0) != 1 || mem.SegmentWrittenSize(1) != 5 || mem.SegmentWrittenSize(2) != 0 {
+		t.Errorf("Wrong written sizes: %d, %d, %d", mem.SegmentWrittenSize(0), mem.SegmentWrittenSize(1), mem.SegmentWrittenSize(2))
+	}
+}
+
+func TestMemoryUndoJournal(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	err := segments.Memory.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	if err != nil {
+		t.Fatalf("Insert error in test: %s", err)
+	}
+	segments.Memory.EnableJournal()
+	start := segments.Memory.JournalLen()
+
+	// Rewriting the same value is not a change
+	segments.Memory.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	segments.Memory.Insert(memory.NewRelocatable(0, 3), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)))
+	segments.Memory.MarkAsAccessed(memory.NewRelocatable(0, 3))
+	base := segments.AddSegment()
+	segments.Memory.Insert(base, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3)))
+	if segments.Memory.JournalLen() != start+4 {
+		t.Errorf("Expected 4 changes in the journal, got %d", segments.Memory.JournalLen()-start)
+	}
+
+	segments.Memory.UndoJournal(start)
+	if len(segments.Memory.Data) != 1 || segments.Memory.NumSegments() != 1 || len(segments.Memory.AccessedAddresses) != 0 {
+		t.Errorf("The changes weren't undone: %d cells, %d segments, %d accessed addresses",
+			len(segments.Memory.Data), segments.Memory.NumSegments(), len(segments.Memory.AccessedAddresses))
+	}
+	if segments.Memory.SegmentWrittenSize(0) != 1 {
+		t.Errorf("Expected the written size to be restored to 1, got %d", segments.Memory.SegmentWrittenSize(0))
+	}
+}
+
+func TestMemoryTruncateJournal(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.Memory.EnableJournal()
+	segments.Memory.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	truncated := segments.Memory.JournalLen()
+	segments.Memory.Insert(memory.NewRelocatable(0, 1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)))
+
+	segments.Memory.TruncateJournal(truncated)
+	if segments.Memory.JournalLen() != truncated+1 {
+		t.Errorf("Truncating the journal shouldn't change its length, expected %d, got %d", truncated+1, segments.Memory.JournalLen())
+	}
+	// Only the change recorded after the truncated point can be undone
+	segments.Memory.UndoJournal(0)
+	if len(segments.Memory.Data) != 1 || segments.Memory.SegmentWrittenSize(0) != 1 {
+		t.Errorf("Expected only the second write to be undone, got %d cells", len(segments.Memory.Data))
+	}
+	if segments.Memory.JournalLen() != truncated {
+		t.Errorf("Expected the journal to be back at %d, got %d", truncated, segments.Memory.JournalLen())
+	}
+}
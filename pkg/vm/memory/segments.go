@@ -13,21 +13,38 @@ type MemorySegmentManager struct {
 	SegmentUsedSizes map[uint]uint
 	SegmentSizes     map[uint]uint
 	Memory           Memory
-	// In the original vm implementation, public memory is a list of tuples (uint, uint).
-	// The thing is, that second uint is ALWAYS zero. Every single single time someone instantiates
-	// some public memory, that second value is zero. I just removed it.
-	PublicMemoryOffsets map[uint][]uint
+	// Public memory of each finalized segment, as a list of (offset, page) pairs.
+	// Page 0 is the default page, other pages are only used by the output builtin.
+	PublicMemoryOffsets map[uint][]PublicMemoryOffset
+}
+
+// An offset inside a segment which belongs to the public memory, along with the id of the page it belongs to
+type PublicMemoryOffset struct {
+	Offset uint
+	Page   uint
 }
 
 func NewMemorySegmentManager() MemorySegmentManager {
 	memory := NewMemory()
-	return MemorySegmentManager{make(map[uint]uint), make(map[uint]uint), *memory, make(map[uint][]uint)}
+	return MemorySegmentManager{make(map[uint]uint), make(map[uint]uint), *memory, make(map[uint][]PublicMemoryOffset)}
 }
 
-// Adds a memory segment and returns the first address of the new segment
+// Adds a memory segment and returns the first address of the new segment.
+// Segments over the memory's MaxSegments limit aren't added: the returned address can't be written to, and the
+// limit's error is kept in Memory.LimitError
 func (m *MemorySegmentManager) AddSegment() Relocatable {
 	ptr := Relocatable{int(m.Memory.numSegments), 0}
+	if m.Memory.Limits.MaxSegments != 0 && m.Memory.numSegments >= m.Memory.Limits.MaxSegments {
+		m.Memory.exceedLimit(ErrMaxSegmentsExceeded, m.Memory.numSegments+1, m.Memory.Limits.MaxSegments)
+		return ptr
+	}
 	m.Memory.numSegments += 1
+	if m.Memory.journaling {
+		m.Memory.recordSegment(ptr)
+	}
+	if m.Memory.Observer != nil {
+		m.Memory.Observer.OnSegmentAdd(ptr)
+	}
 	return ptr
 }
 
@@ -162,7 +179,7 @@ func (m *MemorySegmentManager) GetMemoryHoles(builtinCount uint) (uint, error) {
 	return memoryHoles, nil
 }
 
-func (m *MemorySegmentManager) Finalize(size *uint, segmentIndex uint, publicMemory *[]uint) {
+func (m *MemorySegmentManager) Finalize(size *uint, segmentIndex uint, publicMemory *[]PublicMemoryOffset) {
 	if size != nil {
 		m.SegmentSizes[segmentIndex] = *size
 	}
@@ -170,11 +187,20 @@ func (m *MemorySegmentManager) Finalize(size *uint, segmentIndex uint, publicMem
 	if publicMemory != nil {
 		m.PublicMemoryOffsets[segmentIndex] = *publicMemory
 	} else {
-		emptyList := make([]uint, 0)
+		emptyList := make([]PublicMemoryOffset, 0)
 		m.PublicMemoryOffsets[segmentIndex] = emptyList
 	}
 }
 
+// Builds a public memory list from a list of offsets, assigning all of them to the default page (page 0)
+func PublicMemoryOffsetsFromOffsets(offsets []uint) []PublicMemoryOffset {
+	publicMemory := make([]PublicMemoryOffset, 0, len(offsets))
+	for _, offset := range offsets {
+		publicMemory = append(publicMemory, PublicMemoryOffset{Offset: offset, Page: 0})
+	}
+	return publicMemory
+}
+
 // Gets a range of Felt memory values from addr to addr + size
 // Fails if any of the values inside the range is missing (memory gap), or is not a Felt
 func (m *MemorySegmentManager) GetFeltRange(start Relocatable, size uint) ([]lambdaworks.Felt, error) {
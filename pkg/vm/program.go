@@ -1,6 +1,9 @@
 package vm
 
 import (
+	"strconv"
+	"sync"
+
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
@@ -18,6 +21,9 @@ type Identifier struct {
 	Destination string
 }
 
+// A compiled program. Programs are read-only once created: runs never modify them, so a program can be shared by
+// CairoRunners running in parallel goroutines. Programs returned by DeserializeProgramJson or Shared also cache their
+// constants and compiled hints, so that they are computed once for all the runs of the program
 type Program struct {
 	Data             []memory.MaybeRelocatable
 	Builtins         []string
@@ -26,16 +32,59 @@ type Program struct {
 	ReferenceManager parser.ReferenceManager
 	Start            uint
 	End              uint
+	// Source locations of the instructions, indexed by pc. Only present if the program was compiled with debug info
+	InstructionLocations map[uint]parser.InstructionLocation
+	// Values derived from the program, shared by its copies. Nil if the program doesn't cache them
+	cache *programCache
 }
 
-func DeserializeProgramJson(compiledProgram parser.CompiledJson) Program {
-	var program Program
+// Values derived from a program, computed on first use
+type programCache struct {
+	constantsOnce sync.Once
+	constants     map[string]lambdaworks.Felt
+	// Compiled hints, indexed by the hint processors' HintDataCacheKey
+	hintDataMutex sync.Mutex
+	hintData      map[string]*cachedHintData
+}
 
-	hexData := compiledProgram.Data
-	for _, hexVal := range hexData {
+type cachedHintData struct {
+	once        sync.Once
+	hintDataMap map[uint][]any
+	err         error
+}
+
+// Optional interface of the hint processors whose compiled hint data only depends on the hint and its program,
+// and not on the state of the processor, so that it can be compiled once per program and shared by all the runs
+type CacheableHintProcessor interface {
+	HintProcessor
+	// Identifies the compiled hint data: processors with the same key compile hints to the same data,
+	// and their ExecuteHint doesn't modify it
+	HintDataCacheKey() string
+}
+
+func DeserializeProgramJson(compiledProgram parser.CompiledJson) Program {
+	var data []memory.MaybeRelocatable
+	for _, hexVal := range compiledProgram.Data {
 		felt := lambdaworks.FeltFromHex(hexVal)
-		program.Data = append(program.Data, *memory.NewMaybeRelocatableFelt(felt))
+		data = append(data, *memory.NewMaybeRelocatableFelt(felt))
 	}
+	return deserializeProgram(compiledProgram, data)
+}
+
+// Same as DeserializeProgramJson, for a program decoded by the streaming parser. The program's instruction locations
+// are only set if its debug info was decoded
+func DeserializeStreamedProgram(streamedProgram *parser.StreamedProgram) Program {
+	data := make([]memory.MaybeRelocatable, 0, len(streamedProgram.DataFelts))
+	for _, felt := range streamedProgram.DataFelts {
+		data = append(data, *memory.NewMaybeRelocatableFelt(felt))
+	}
+	return deserializeProgram(streamedProgram.CompiledJson, data)
+}
+
+func deserializeProgram(compiledProgram parser.CompiledJson, data []memory.MaybeRelocatable) Program {
+	var program Program
+
+	program.Data = data
 	program.Builtins = compiledProgram.Builtins
 	program.Identifiers = make(map[string]Identifier)
 
@@ -60,7 +109,75 @@ func DeserializeProgramJson(compiledProgram parser.CompiledJson) Program {
 	program.Hints = compiledProgram.Hints
 	program.ReferenceManager = compiledProgram.ReferenceManager
 
-	return program
+	program.InstructionLocations = make(map[uint]parser.InstructionLocation)
+	for pc, location := range compiledProgram.DebugInfo.InstructionLocation {
+		pcOffset, err := strconv.ParseUint(pc, 10, 64)
+		if err != nil {
+			continue
+		}
+		program.InstructionLocations[uint(pcOffset)] = location
+	}
+
+	return program.Shared()
+}
+
+// Returns a copy of the program that caches its constants and compiled hints, which are shared by the copies of the
+// returned program. The program must not be modified afterwards
+func (p Program) Shared() Program {
+	if p.cache == nil {
+		p.cache = &programCache{hintData: make(map[string]*cachedHintData)}
+	}
+	return p
+}
+
+// Returns the constants of the program, computed once if the program caches them (see Shared).
+// The returned map is shared and must not be modified
+func (p *Program) Constants() map[string]lambdaworks.Felt {
+	if p.cache == nil {
+		return p.ExtractConstants()
+	}
+	p.cache.constantsOnce.Do(func() {
+		p.cache.constants = p.ExtractConstants()
+	})
+	return p.cache.constants
+}
+
+// Compiles the hints of the program with the given hint processor, indexed by pc. If the program caches them
+// (see Shared) and the processor is a CacheableHintProcessor, the hints are compiled once per cache key.
+// The returned map is shared and must not be modified
+func (p *Program) HintDataMap(hintProcessor HintProcessor) (map[uint][]any, error) {
+	processor, ok := hintProcessor.(CacheableHintProcessor)
+	if !ok || p.cache == nil {
+		return p.compileHints(hintProcessor)
+	}
+	key := processor.HintDataCacheKey()
+	p.cache.hintDataMutex.Lock()
+	cached, ok := p.cache.hintData[key]
+	if !ok {
+		cached = &cachedHintData{}
+		p.cache.hintData[key] = cached
+	}
+	p.cache.hintDataMutex.Unlock()
+	cached.once.Do(func() {
+		cached.hintDataMap, cached.err = p.compileHints(hintProcessor)
+	})
+	return cached.hintDataMap, cached.err
+}
+
+func (p *Program) compileHints(hintProcessor HintProcessor) (map[uint][]any, error) {
+	hintDataMap := make(map[uint][]any, len(p.Hints))
+	for pc, hintsParams := range p.Hints {
+		hintDatas := make([]any, 0, len(hintsParams))
+		for _, hintParam := range hintsParams {
+			data, err := CompileProgramHint(hintProcessor, &hintParam, p)
+			if err != nil {
+				return nil, err
+			}
+			hintDatas = append(hintDatas, data)
+		}
+		hintDataMap[pc] = hintDatas
+	}
+	return hintDataMap, nil
 }
 
 func (p *Program) ExtractConstants() map[string]lambdaworks.Felt {
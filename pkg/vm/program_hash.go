@@ -0,0 +1,59 @@
+package vm
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+	"github.com/pkg/errors"
+)
+
+func ProgramHashError(err error) error {
+	return errors.Wrapf(err, "Program hash error")
+}
+
+// Converts a short ascii string (such as a builtin name) into a felt, interpreting its bytes as a big-endian integer
+func FeltFromShortString(value string) (lambdaworks.Felt, error) {
+	if len(value) > 31 {
+		return lambdaworks.FeltZero(), errors.Errorf("String %s is too long to fit in a felt", value)
+	}
+	var bytes [32]byte
+	copy(bytes[32-len(value):], value)
+	return lambdaworks.FeltFromBeBytes(&bytes), nil
+}
+
+// Computes the hash of a program, in the same way the bootloader does (see cairo-lang's compute_program_hash_chain).
+// The hashed data consists of the program header ([bootloader_version, main, n_builtins, builtins...]) followed by
+// the program's data, hashed with poseidon_hash_many if usePoseidon is true, or with a pedersen hash chain prefixed by its length otherwise.
+func (p *Program) ComputeProgramHashChain(bootloaderVersion uint64, usePoseidon bool) (lambdaworks.Felt, error) {
+	mainIdentifier, ok := p.Identifiers["__main__.main"]
+	if !ok {
+		return lambdaworks.FeltZero(), ProgramHashError(errors.New("Program has no __main__.main identifier"))
+	}
+
+	dataChain := make([]lambdaworks.Felt, 0, 3+len(p.Builtins)+len(p.Data))
+	dataChain = append(dataChain,
+		lambdaworks.FeltFromUint64(bootloaderVersion),
+		lambdaworks.FeltFromUint64(uint64(mainIdentifier.PC)),
+		lambdaworks.FeltFromUint64(uint64(len(p.Builtins))),
+	)
+	for _, builtin := range p.Builtins {
+		builtinFelt, err := FeltFromShortString(builtin)
+		if err != nil {
+			return lambdaworks.FeltZero(), ProgramHashError(err)
+		}
+		dataChain = append(dataChain, builtinFelt)
+	}
+	for i, value := range p.Data {
+		felt, ok := value.GetFelt()
+		if !ok {
+			return lambdaworks.FeltZero(), ProgramHashError(errors.Errorf("Expected program data at index %d to be a felt", i))
+		}
+		dataChain = append(dataChain, felt)
+	}
+
+	if usePoseidon {
+		return starknet_crypto.PoseidonHashMany(dataChain), nil
+	}
+	// The program header is missing the data length, so it is prepended to the hashed chain
+	lengthPrefixed := append([]lambdaworks.Felt{lambdaworks.FeltFromUint64(uint64(len(dataChain)))}, dataChain...)
+	return starknet_crypto.PedersenHashChain(lengthPrefixed), nil
+}
@@ -0,0 +1,106 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func programForHash() vm.Program {
+	return vm.Program{
+		Data: []memory.MaybeRelocatable{
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5189976364521848832)),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1000)),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2345108766317314046)),
+		},
+		Builtins: []string{"output", "pedersen"},
+		Identifiers: map[string]vm.Identifier{
+			"__main__.main": {PC: 0, Type: "function"},
+		},
+	}
+}
+
+func TestFeltFromShortString(t *testing.T) {
+	result, err := vm.FeltFromShortString("output")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// "output" as a big-endian integer
+	expected := lambdaworks.FeltFromHex("0x6f7574707574")
+	if result != expected {
+		t.Errorf("Wrong felt. Expected %s, got %s", expected.ToHexString(), result.ToHexString())
+	}
+}
+
+func TestFeltFromShortStringTooLong(t *testing.T) {
+	_, err := vm.FeltFromShortString("a string which is way too long to fit in a felt")
+	if err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+// The expected hashes are the ones of cairo-lang's compute_program_hash_chain(program, use_poseidon, bootloader_version)
+var programHashVectors = []struct {
+	bootloaderVersion uint64
+	usePoseidon       bool
+	expected          string
+}{
+	{0, false, "0x78ea1379a0418605bfb06e54b364914f40d8910c2f31b2e240e09912217388c"},
+	{0, true, "0x6923195bfd6dfea6c8433cabb6a2c86f4c7b5d4a5424a6cde7c3a31e118a395"},
+	{1, false, "0x513a95041d266d26b37ed0dc3976602b5ba03e40f091c1b1a1c8b1a307658bf"},
+}
+
+func TestComputeProgramHashChain(t *testing.T) {
+	program := programForHash()
+	for _, vector := range programHashVectors {
+		result, err := program.ComputeProgramHashChain(vector.bootloaderVersion, vector.usePoseidon)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := lambdaworks.FeltFromHex(vector.expected)
+		if result != expected {
+			t.Errorf("Wrong program hash for bootloader version %d (poseidon: %t). Expected %s, got %s", vector.bootloaderVersion, vector.usePoseidon, expected.ToHexString(), result.ToHexString())
+		}
+	}
+}
+
+func TestComputeProgramHashChainNoBuiltins(t *testing.T) {
+	// A program whose main only returns
+	program := vm.Program{
+		Data:        []memory.MaybeRelocatable{*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex("0x208b7fff7fff7ffe"))},
+		Identifiers: map[string]vm.Identifier{"__main__.main": {PC: 0, Type: "function"}},
+	}
+	expected := map[bool]lambdaworks.Felt{
+		false: lambdaworks.FeltFromHex("0x46192fc5e7708648336a5e96378d61b176c448c366cba30f9d21b7a35493f60"),
+		true:  lambdaworks.FeltFromHex("0x2ae6f35c18c46914af883e0ba2bd69c97b39b692353dc95c5684c0d66e1a39"),
+	}
+	for usePoseidon, expectedHash := range expected {
+		result, err := program.ComputeProgramHashChain(0, usePoseidon)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if result != expectedHash {
+			t.Errorf("Wrong program hash (poseidon: %t). Expected %s, got %s", usePoseidon, expectedHash.ToHexString(), result.ToHexString())
+		}
+	}
+}
+
+func TestComputeProgramHashChainNoMain(t *testing.T) {
+	program := programForHash()
+	program.Identifiers = map[string]vm.Identifier{}
+	_, err := program.ComputeProgramHashChain(0, false)
+	if err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestComputeProgramHashChainRelocatableData(t *testing.T) {
+	program := programForHash()
+	program.Data = append(program.Data, *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 0)))
+	_, err := program.ComputeProgramHashChain(0, false)
+	if err == nil {
+		t.Errorf("Expected an error")
+	}
+}
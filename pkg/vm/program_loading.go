@@ -0,0 +1,45 @@
+package vm
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// A program whose code was loaded into memory while running another program (such as a task run by the bootloader).
+// Its hints are compiled when the program is loaded, and executed with the program's own constants
+type LoadedProgram struct {
+	Base        memory.Relocatable
+	HintDataMap map[uint][]any
+	Constants   map[string]lambdaworks.Felt
+}
+
+// Returns the hints of the loaded program at the given pc
+func (p *LoadedProgram) HintsAt(pc memory.Relocatable) ([]any, bool) {
+	if pc.SegmentIndex != p.Base.SegmentIndex || pc.Offset < p.Base.Offset {
+		return nil, false
+	}
+	hintDatas, ok := p.HintDataMap[pc.Offset-p.Base.Offset]
+	return hintDatas, ok
+}
+
+// Registers the hints of a program whose code was loaded at the given address, so that they are executed when
+// the pc reaches them (see cairo-lang's vm_load_program). The program's code is expected to already be in memory
+func (v *VirtualMachine) LoadProgram(program *Program, base memory.Relocatable, hintProcessor HintProcessor) error {
+	if _, ok := v.LoadedPrograms[base.SegmentIndex]; ok {
+		return errors.Errorf("A program was already loaded into segment %d", base.SegmentIndex)
+	}
+	hintDataMap, err := program.HintDataMap(hintProcessor)
+	if err != nil {
+		return err
+	}
+	if v.LoadedPrograms == nil {
+		v.LoadedPrograms = make(map[int]*LoadedProgram)
+	}
+	v.LoadedPrograms[base.SegmentIndex] = &LoadedProgram{
+		Base:        base,
+		HintDataMap: hintDataMap,
+		Constants:   program.Constants(),
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Hint processor whose hints fail with their own code, so that tests can tell which hint was executed
+type failingHintProcessor struct{}
+
+func (p *failingHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	return hintParams.Code, nil
+}
+
+func (p *failingHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	return errors.Errorf("%s %s", (*hintData).(string), (*constants)["__main__.VALUE"].ToHexString())
+}
+
+func vmWithLoadedProgram(t *testing.T) *vm.VirtualMachine {
+	virtualMachine := vm.NewVirtualMachine()
+	virtualMachine.Segments.AddSegment()
+	virtualMachine.Segments.AddSegment()
+	program := vm.Program{
+		Hints: map[uint][]parser.HintParams{0: {{Code: "task"}}},
+		Identifiers: map[string]vm.Identifier{
+			"__main__.VALUE": {Type: "const", Value: lambdaworks.FeltFromUint64(2)},
+		},
+	}
+	err := virtualMachine.LoadProgram(&program, memory.NewRelocatable(1, 2), &failingHintProcessor{})
+	if err != nil {
+		t.Fatalf("LoadProgram failed with error: %s", err)
+	}
+	return virtualMachine
+}
+
+func TestStepRunsLoadedProgramHints(t *testing.T) {
+	virtualMachine := vmWithLoadedProgram(t)
+	hintDataMap := map[uint][]any{0: {"main"}}
+	constants := map[string]lambdaworks.Felt{"__main__.VALUE": lambdaworks.FeltFromUint64(1)}
+
+	virtualMachine.RunContext.Pc = memory.NewRelocatable(1, 2)
+	err := virtualMachine.Step(&failingHintProcessor{}, &hintDataMap, &constants, types.NewExecutionScopes())
+	if err == nil || err.Error() != "task 0x2" {
+		t.Errorf("Expected the loaded program's hint to run with its own constants, got: %v", err)
+	}
+
+	virtualMachine.RunContext.Pc = memory.NewRelocatable(0, 0)
+	err = virtualMachine.Step(&failingHintProcessor{}, &hintDataMap, &constants, types.NewExecutionScopes())
+	if err == nil || err.Error() != "main 0x1" {
+		t.Errorf("Expected the main program's hint to run, got: %v", err)
+	}
+}
+
+func TestStepDoesntRunMainHintsInLoadedProgramSegment(t *testing.T) {
+	virtualMachine := vmWithLoadedProgram(t)
+	hintDataMap := map[uint][]any{0: {"main"}}
+	constants := map[string]lambdaworks.Felt{}
+
+	// The offset matches a hint of the main program, but the pc is in the loaded program's segment
+	virtualMachine.RunContext.Pc = memory.NewRelocatable(1, 0)
+	err := virtualMachine.Step(&failingHintProcessor{}, &hintDataMap, &constants, types.NewExecutionScopes())
+	if err == nil || err.Error() == "main 0x0" {
+		t.Errorf("Expected no hint to run, got: %v", err)
+	}
+}
+
+func TestLoadProgramTwiceIntoSameSegment(t *testing.T) {
+	virtualMachine := vmWithLoadedProgram(t)
+	program := vm.Program{}
+	err := virtualMachine.LoadProgram(&program, memory.NewRelocatable(1, 10), &failingHintProcessor{})
+	if err == nil {
+		t.Errorf("LoadProgram should fail if a program was already loaded into the segment")
+	}
+}
+
+func TestStepRunsMainHintsOnlyInProgramSegment(t *testing.T) {
+	virtualMachine := vmWithLoadedProgram(t)
+	virtualMachine.Segments.AddSegment()
+	hintDataMap := map[uint][]any{0: {"main"}}
+	constants := map[string]lambdaworks.Felt{"__main__.VALUE": lambdaworks.FeltFromUint64(1)}
+
+	// The offset matches a hint of the main program, but the pc is in another segment, like the one of a cairo pie task
+	virtualMachine.RunContext.Pc = memory.NewRelocatable(2, 0)
+	err := virtualMachine.Step(&failingHintProcessor{}, &hintDataMap, &constants, types.NewExecutionScopes())
+	if err == nil || err.Error() == "main 0x1" {
+		t.Errorf("Expected no hint to run, got: %v", err)
+	}
+
+	virtualMachine.ProgramSegmentIndex = 2
+	err = virtualMachine.Step(&failingHintProcessor{}, &hintDataMap, &constants, types.NewExecutionScopes())
+	if err == nil || err.Error() != "main 0x1" {
+		t.Errorf("Expected the main program's hint to run in its segment, got: %v", err)
+	}
+}
@@ -2,9 +2,13 @@ package vm_test
 
 import (
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
 )
 
@@ -80,3 +84,105 @@ func TestExtractConstantsWithAliasedConstants(t *testing.T) {
 		t.Errorf("Wrong Constants, expected %v, got %v", expectedConstants, program.ExtractConstants())
 	}
 }
+
+// Cacheable hint processor that counts the hints it compiles
+type compileCountingHintProcessor struct {
+	mutex    sync.Mutex
+	compiled int
+}
+
+func (p *compileCountingHintProcessor) HintDataCacheKey() string {
+	return "compile_counting"
+}
+
+func (p *compileCountingHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.compiled++
+	return hintParams.Code, nil
+}
+
+func (p *compileCountingHintProcessor) ExecuteHint(v *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	return nil
+}
+
+func TestProgramSharedCachesHintData(t *testing.T) {
+	program := vm.Program{
+		Hints: map[uint][]parser.HintParams{0: {{Code: "a"}}, 4: {{Code: "b"}, {Code: "c"}}},
+	}.Shared()
+	processor := &compileCountingHintProcessor{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		shared := program
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hintDataMap, err := shared.HintDataMap(processor)
+			if err != nil {
+				t.Errorf("HintDataMap failed with error: %s", err)
+			}
+			if !reflect.DeepEqual(hintDataMap, map[uint][]any{0: {"a"}, 4: {"b", "c"}}) {
+				t.Errorf("Wrong hint data: %v", hintDataMap)
+			}
+		}()
+	}
+	wg.Wait()
+	if processor.compiled != 3 {
+		t.Errorf("Expected the hints to be compiled once, compiled %d hints", processor.compiled)
+	}
+}
+
+func TestProgramNotSharedCompilesHintData(t *testing.T) {
+	program := vm.Program{Hints: map[uint][]parser.HintParams{0: {{Code: "a"}}}}
+	processor := &compileCountingHintProcessor{}
+	program.HintDataMap(processor)
+	program.HintDataMap(processor)
+	if processor.compiled != 2 {
+		t.Errorf("Expected the hints to be compiled on every call, compiled %d hints", processor.compiled)
+	}
+}
+
+func TestProgramSharedConstants(t *testing.T) {
+	program := vm.Program{
+		Identifiers: map[string]vm.Identifier{
+			"__main__.SIZE": {Type: "const", Value: lambdaworks.FeltFromUint64(3)},
+		},
+	}.Shared()
+	constants := program.Constants()
+	shared := program
+	if !reflect.DeepEqual(constants, map[string]lambdaworks.Felt{"__main__.SIZE": lambdaworks.FeltFromUint64(3)}) {
+		t.Errorf("Wrong constants: %v", constants)
+	}
+	if reflect.ValueOf(shared.Constants()).Pointer() != reflect.ValueOf(constants).Pointer() {
+		t.Errorf("Expected the copies of a shared program to share its constants")
+	}
+}
+
+func TestDeserializeStreamedProgram(t *testing.T) {
+	programJson := `{
+		"builtins": ["output"],
+		"data": ["0x480680017fff8000", "0x3", "0x208b7fff7fff7ffe"],
+		"debug_info": {"instruction_locations": {"2": {"accessible_scopes": ["__main__"], "inst": {"start_line": 4}}}},
+		"hints": {"0": [{"code": "memory[ap] = 3", "accessible_scopes": ["__main__"]}]},
+		"identifiers": {
+			"__main__.main": {"decorators": [], "pc": 0, "type": "function"},
+			"__main__.SIZE": {"type": "const", "value": 3}
+		},
+		"main_scope": "__main__",
+		"prime": "0x800000000000011000000000000000000000000000000000000000000000001",
+		"reference_manager": {"references": []}
+	}`
+	compiledProgram, err := parser.ParseBytes([]byte(programJson))
+	if err != nil {
+		t.Fatalf("ParseBytes failed with error: %s", err)
+	}
+	streamedProgram, err := parser.ParseStream(strings.NewReader(programJson), parser.StreamOptions{DebugInfo: parser.DebugInfoInstructionLocations})
+	if err != nil {
+		t.Fatalf("ParseStream failed with error: %s", err)
+	}
+	expected := vm.DeserializeProgramJson(compiledProgram)
+	program := vm.DeserializeStreamedProgram(streamedProgram)
+	if !reflect.DeepEqual(program, expected) {
+		t.Errorf("Streamed program differs.\nExpected: %+v\nGot: %+v", expected, program)
+	}
+}
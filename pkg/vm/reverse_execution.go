@@ -0,0 +1,134 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrReverseExecutionDisabled = errors.New("Reverse execution is not enabled")
+var ErrStepNotRevertible = errors.New("Step can't be reverted to")
+
+// State of the VM at the start of a step that isn't kept in the trace or the memory's journal
+type stepCheckpoint struct {
+	journalLen     int
+	rcLimitsMin    *int
+	rcLimitsMax    *int
+	hintExecutions uint
+}
+
+// Default maximum number of steps that can be reverted, see SetMaxRevertibleSteps
+const DefaultMaxRevertibleSteps = 100_000
+
+// Checkpoints of the steps executed since reverse execution was enabled, or of the last maxSteps steps
+type reverseExecution struct {
+	firstStep   uint
+	checkpoints []stepCheckpoint
+	// 0 for no maximum
+	maxSteps uint
+}
+
+// Enables stepping backwards with StepBack and RewindToStep, to any step executed from now on.
+// The registers are restored from the trace, and the memory writes, accesses and segments are undone with the memory's
+// journal. The state kept outside of the VM and its memory, such as the execution scopes of the hints or the
+// signatures and output pages of the builtins, is not reverted, nor are the steps consumed from the RunResources.
+// Only the last DefaultMaxRevertibleSteps steps can be reverted, see SetMaxRevertibleSteps
+func (v *VirtualMachine) EnableReverseExecution() {
+	if v.reverse != nil {
+		return
+	}
+	v.Segments.Memory.EnableJournal()
+	v.reverse = &reverseExecution{firstStep: v.CurrentStep, maxSteps: DefaultMaxRevertibleSteps}
+}
+
+// Sets the maximum number of steps that can be reverted, 0 for no maximum. Once more steps are executed, the
+// checkpoints and journal entries of the oldest ones are discarded and FirstRevertibleStep moves forward, so that the
+// memory taken by reverse execution stays bounded
+func (v *VirtualMachine) SetMaxRevertibleSteps(maxSteps uint) error {
+	if v.reverse == nil {
+		return ErrReverseExecutionDisabled
+	}
+	v.reverse.maxSteps = maxSteps
+	v.discardOldCheckpoints()
+	return nil
+}
+
+// Returns the first step the VM can be rewound to, that is, the step at which reverse execution was enabled, or the
+// oldest step kept once more than the maximum number of revertible steps were executed
+func (v *VirtualMachine) FirstRevertibleStep() (uint, error) {
+	if v.reverse == nil {
+		return 0, ErrReverseExecutionDisabled
+	}
+	return v.reverse.firstStep, nil
+}
+
+// Reverts the last step executed
+func (v *VirtualMachine) StepBack() error {
+	if v.CurrentStep == 0 {
+		return fmt.Errorf("%w, current step: 0", ErrStepNotRevertible)
+	}
+	return v.RewindToStep(v.CurrentStep - 1)
+}
+
+// Restores the VM to the start of the given step, undoing the steps executed since then. If the current step failed
+// midway, rewinding to it undoes the hints and memory writes it made before failing
+func (v *VirtualMachine) RewindToStep(step uint) error {
+	if v.reverse == nil {
+		return ErrReverseExecutionDisabled
+	}
+	if step < v.reverse.firstStep || step > v.CurrentStep {
+		return fmt.Errorf("%w, step: %d, first revertible step: %d, current step: %d", ErrStepNotRevertible, step, v.reverse.firstStep, v.CurrentStep)
+	}
+	index := step - v.reverse.firstStep
+	if index >= uint(len(v.reverse.checkpoints)) {
+		// The current step hasn't started yet
+		return nil
+	}
+	checkpoint := v.reverse.checkpoints[index]
+	v.Segments.Memory.UndoJournal(checkpoint.journalLen)
+	v.RcLimitsMin = checkpoint.rcLimitsMin
+	v.RcLimitsMax = checkpoint.rcLimitsMax
+	v.HintExecutions = checkpoint.hintExecutions
+	if step < uint(len(v.Trace)) {
+		entry := v.Trace[step]
+		v.RunContext = RunContext{Pc: entry.Pc, Ap: entry.Ap, Fp: entry.Fp}
+		v.Trace = v.Trace[:step]
+	}
+	v.CurrentStep = step
+	v.reverse.checkpoints = v.reverse.checkpoints[:index]
+	return nil
+}
+
+// Records the state at the start of the current step, replacing the checkpoints of any later step
+func (v *VirtualMachine) saveStepCheckpoint() {
+	index := v.CurrentStep - v.reverse.firstStep
+	if index < uint(len(v.reverse.checkpoints)) {
+		v.reverse.checkpoints = v.reverse.checkpoints[:index]
+	}
+	v.reverse.checkpoints = append(v.reverse.checkpoints, stepCheckpoint{
+		journalLen:     v.Segments.Memory.JournalLen(),
+		rcLimitsMin:    copyIntPtr(v.RcLimitsMin),
+		rcLimitsMax:    copyIntPtr(v.RcLimitsMax),
+		hintExecutions: v.HintExecutions,
+	})
+	v.discardOldCheckpoints()
+}
+
+// Discards the checkpoints and journal entries of the steps beyond the maximum number of revertible steps
+func (v *VirtualMachine) discardOldCheckpoints() {
+	maxSteps := v.reverse.maxSteps
+	if maxSteps == 0 || uint(len(v.reverse.checkpoints)) <= maxSteps {
+		return
+	}
+	discarded := uint(len(v.reverse.checkpoints)) - maxSteps
+	v.reverse.checkpoints = v.reverse.checkpoints[discarded:]
+	v.reverse.firstStep += discarded
+	v.Segments.Memory.TruncateJournal(v.reverse.checkpoints[0].journalLen)
+}
+
+func copyIntPtr(ptr *int) *int {
+	if ptr == nil {
+		return nil
+	}
+	value := *ptr
+	return &value
+}
@@ -0,0 +1,186 @@
+package vm_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// State of the vm that stepping backwards restores
+type vmState struct {
+	runContext     vm.RunContext
+	currentStep    uint
+	trace          []vm.TraceEntry
+	data           map[memory.Relocatable]memory.MaybeRelocatable
+	accessed       map[memory.Relocatable]bool
+	numSegments    uint
+	hintExecutions uint
+}
+
+func stateOf(v *vm.VirtualMachine) vmState {
+	state := vmState{
+		runContext:     v.RunContext,
+		currentStep:    v.CurrentStep,
+		trace:          append([]vm.TraceEntry{}, v.Trace...),
+		data:           make(map[memory.Relocatable]memory.MaybeRelocatable),
+		accessed:       make(map[memory.Relocatable]bool),
+		numSegments:    v.Segments.Memory.NumSegments(),
+		hintExecutions: v.HintExecutions,
+	}
+	for addr, val := range v.Segments.Memory.Data {
+		state.data[addr] = val
+	}
+	for addr := range v.Segments.Memory.AccessedAddresses {
+		state.accessed[addr] = true
+	}
+	return state
+}
+
+// Returns a vm that loops forever writing 5 at ap: [ap] = 5, ap++; jmp rel -2, with a hint at the start of the loop
+// that adds a segment and writes to it
+func newLoopVm() (*vm.VirtualMachine, map[uint][]any) {
+	virtualMachine := vm.NewVirtualMachine()
+	program := virtualMachine.Segments.AddSegment()
+	execution := virtualMachine.Segments.AddSegment()
+	data := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480680017fff8000)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x10780017fff7fff)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero().Sub(lambdaworks.FeltFromUint64(2))),
+	}
+	virtualMachine.Segments.LoadData(program, &data)
+	frame, _ := virtualMachine.Segments.LoadData(execution, &[]memory.MaybeRelocatable{*memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero())})
+	virtualMachine.RunContext = vm.RunContext{Pc: program, Ap: frame, Fp: frame}
+	var writeToNewSegment hintFunc = func(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+		return v.Segments.Memory.Insert(v.Segments.AddSegment(), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)))
+	}
+	return virtualMachine, map[uint][]any{0: {writeToNewSegment}}
+}
+
+func runSteps(t *testing.T, v *vm.VirtualMachine, hintDataMap map[uint][]any, steps int) {
+	for i := 0; i < steps; i++ {
+		err := v.Step(&funcHintProcessor{}, &hintDataMap, nil, types.NewExecutionScopes())
+		if err != nil {
+			t.Fatalf("Step failed with error: %s", err)
+		}
+	}
+}
+
+func TestReverseExecutionRewind(t *testing.T) {
+	virtualMachine, hintDataMap := newLoopVm()
+	virtualMachine.EnableReverseExecution()
+	states := []vmState{stateOf(virtualMachine)}
+	for i := 0; i < 6; i++ {
+		runSteps(t, virtualMachine, hintDataMap, 1)
+		states = append(states, stateOf(virtualMachine))
+	}
+
+	err := virtualMachine.RewindToStep(3)
+	if err != nil {
+		t.Fatalf("RewindToStep failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(stateOf(virtualMachine), states[3]) {
+		t.Errorf("Wrong state after rewinding to step 3")
+	}
+	err = virtualMachine.StepBack()
+	if err != nil {
+		t.Fatalf("StepBack failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(stateOf(virtualMachine), states[2]) {
+		t.Errorf("Wrong state after stepping back to step 2")
+	}
+
+	// Running forward again reaches the same states
+	runSteps(t, virtualMachine, hintDataMap, 4)
+	if !reflect.DeepEqual(stateOf(virtualMachine), states[6]) {
+		t.Errorf("Wrong state after running forward again")
+	}
+	err = virtualMachine.RewindToStep(0)
+	if err != nil {
+		t.Fatalf("RewindToStep failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(stateOf(virtualMachine), states[0]) {
+		t.Errorf("Wrong state after rewinding to the first step")
+	}
+}
+
+func TestReverseExecutionFromLaterStep(t *testing.T) {
+	virtualMachine, hintDataMap := newLoopVm()
+	runSteps(t, virtualMachine, hintDataMap, 2)
+	virtualMachine.EnableReverseExecution()
+	runSteps(t, virtualMachine, hintDataMap, 2)
+
+	first, err := virtualMachine.FirstRevertibleStep()
+	if err != nil || first != 2 {
+		t.Errorf("Expected the first revertible step to be 2, got %d, err: %v", first, err)
+	}
+	err = virtualMachine.RewindToStep(1)
+	if !errors.Is(err, vm.ErrStepNotRevertible) {
+		t.Errorf("Expected ErrStepNotRevertible, got: %v", err)
+	}
+	err = virtualMachine.RewindToStep(5)
+	if !errors.Is(err, vm.ErrStepNotRevertible) {
+		t.Errorf("Expected ErrStepNotRevertible, got: %v", err)
+	}
+	err = virtualMachine.RewindToStep(2)
+	if err != nil || virtualMachine.CurrentStep != 2 {
+		t.Errorf("Expected to rewind to step 2, got step %d, err: %v", virtualMachine.CurrentStep, err)
+	}
+}
+
+func TestReverseExecutionMaxSteps(t *testing.T) {
+	virtualMachine, hintDataMap := newLoopVm()
+	virtualMachine.EnableReverseExecution()
+	err := virtualMachine.SetMaxRevertibleSteps(3)
+	if err != nil {
+		t.Fatalf("SetMaxRevertibleSteps failed with error: %s", err)
+	}
+	runSteps(t, virtualMachine, hintDataMap, 7)
+	state := stateOf(virtualMachine)
+	runSteps(t, virtualMachine, hintDataMap, 3)
+
+	first, err := virtualMachine.FirstRevertibleStep()
+	if err != nil || first != 7 {
+		t.Errorf("Expected the first revertible step to be 7, got %d, err: %v", first, err)
+	}
+	err = virtualMachine.RewindToStep(6)
+	if !errors.Is(err, vm.ErrStepNotRevertible) {
+		t.Errorf("Expected ErrStepNotRevertible, got: %v", err)
+	}
+	err = virtualMachine.RewindToStep(7)
+	if err != nil {
+		t.Fatalf("RewindToStep failed with error: %s", err)
+	}
+	if !reflect.DeepEqual(stateOf(virtualMachine), state) {
+		t.Errorf("Wrong state after rewinding to step 7")
+	}
+
+	// Lowering the maximum discards the oldest steps right away
+	runSteps(t, virtualMachine, hintDataMap, 3)
+	err = virtualMachine.SetMaxRevertibleSteps(1)
+	if err != nil {
+		t.Fatalf("SetMaxRevertibleSteps failed with error: %s", err)
+	}
+	first, err = virtualMachine.FirstRevertibleStep()
+	if err != nil || first != 9 {
+		t.Errorf("Expected the first revertible step to be 9, got %d, err: %v", first, err)
+	}
+}
+
+func TestReverseExecutionDisabled(t *testing.T) {
+	virtualMachine, hintDataMap := newLoopVm()
+	runSteps(t, virtualMachine, hintDataMap, 1)
+	err := virtualMachine.StepBack()
+	if !errors.Is(err, vm.ErrReverseExecutionDisabled) {
+		t.Errorf("Expected ErrReverseExecutionDisabled, got: %v", err)
+	}
+	err = virtualMachine.SetMaxRevertibleSteps(1)
+	if !errors.Is(err, vm.ErrReverseExecutionDisabled) {
+		t.Errorf("Expected ErrReverseExecutionDisabled, got: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// Limits on the resources a run can use besides its steps (see RunResources). Memory cells and segments are limited
+// as they are written and added, the rest is checked after each hint and each instruction.
+// A zero value means that the resource is not limited
+type RunLimits struct {
+	// Memory cells written, across all segments
+	MaxMemoryCells uint
+	// Segments created, including the ones added by the runner before the run
+	MaxSegments uint
+	// Instances used by each builtin, indexed by builtin name, such as "pedersen" or "range_check"
+	MaxBuiltinInstances map[string]uint
+	// Hints executed
+	MaxHintExecutions uint
+	// Entries of all the dictionaries created by the hints
+	MaxDictEntries uint
+}
+
+var ErrMemoryCellsLimit = memory.ErrMaxCellsExceeded
+var ErrSegmentsLimit = memory.ErrMaxSegmentsExceeded
+var ErrBuiltinInstancesLimit = errors.New("Builtin instances limit exceeded")
+var ErrHintExecutionsLimit = errors.New("Hint executions limit exceeded")
+var ErrDictEntriesLimit = errors.New("Dict entries limit exceeded")
+
+func limitExceededError(err error, used uint, limit uint) error {
+	return fmt.Errorf("%w, used: %d, limit: %d", err, used, limit)
+}
+
+// Implemented by the scope variables that hold dictionaries, such as the dict manager, so that their entries count
+// towards RunLimits.MaxDictEntries
+type DictEntriesCounter interface {
+	DictEntries() uint
+}
+
+// Counts the hint execution that is about to happen
+func (v *VirtualMachine) consumeHintExecution() error {
+	v.HintExecutions++
+	if v.RunLimits == nil || v.RunLimits.MaxHintExecutions == 0 || v.HintExecutions <= v.RunLimits.MaxHintExecutions {
+		return nil
+	}
+	return limitExceededError(ErrHintExecutionsLimit, v.HintExecutions, v.RunLimits.MaxHintExecutions)
+}
+
+// Makes the memory enforce the memory cells and segments limits, which can be changed between steps
+func (v *VirtualMachine) applyMemoryLimits() {
+	if v.RunLimits == nil {
+		v.Segments.Memory.Limits = memory.MemoryLimits{}
+		return
+	}
+	v.Segments.Memory.Limits = memory.MemoryLimits{MaxCells: v.RunLimits.MaxMemoryCells, MaxSegments: v.RunLimits.MaxSegments}
+}
+
+// Checks the memory, segments and builtin instances used so far, including the writes and segments refused by the
+// memory's limits, whose errors may have been ignored by hints
+func (v *VirtualMachine) checkRunLimits() error {
+	if v.RunLimits == nil {
+		return nil
+	}
+	err := v.Segments.Memory.LimitError()
+	if err != nil {
+		return err
+	}
+	limits := v.RunLimits
+	if cells := uint(len(v.Segments.Memory.Data)); limits.MaxMemoryCells != 0 && cells > limits.MaxMemoryCells {
+		return limitExceededError(ErrMemoryCellsLimit, cells, limits.MaxMemoryCells)
+	}
+	if segments := v.Segments.Memory.NumSegments(); limits.MaxSegments != 0 && segments > limits.MaxSegments {
+		return limitExceededError(ErrSegmentsLimit, segments, limits.MaxSegments)
+	}
+	for _, builtin := range v.BuiltinRunners {
+		limit, ok := limits.MaxBuiltinInstances[builtin.Name()]
+		if !ok || limit == 0 {
+			continue
+		}
+		base := builtin.Base()
+		if base.SegmentIndex < 0 {
+			continue
+		}
+		cellsPerInstance := builtin.CellsPerInstance()
+		instances := (v.Segments.Memory.SegmentWrittenSize(uint(base.SegmentIndex)) + cellsPerInstance - 1) / cellsPerInstance
+		if instances > limit {
+			return fmt.Errorf("%w, builtin: %s, used: %d, limit: %d", ErrBuiltinInstancesLimit, builtin.Name(), instances, limit)
+		}
+	}
+	return nil
+}
+
+// Checks the entries of the dictionaries held by every scope. A dictionary held by several scopes is counted once
+func (v *VirtualMachine) checkDictEntriesLimit(execScopes *types.ExecutionScopes) error {
+	if v.RunLimits == nil || v.RunLimits.MaxDictEntries == 0 || execScopes == nil {
+		return nil
+	}
+	entries := uint(0)
+	counted := make(map[any]bool)
+	for _, scope := range execScopes.Scopes() {
+		for _, value := range scope {
+			counter, ok := value.(DictEntriesCounter)
+			if !ok {
+				continue
+			}
+			if reflect.TypeOf(counter).Comparable() {
+				if counted[counter] {
+					continue
+				}
+				counted[counter] = true
+			}
+			entries += counter.DictEntries()
+		}
+	}
+	if entries > v.RunLimits.MaxDictEntries {
+		return limitExceededError(ErrDictEntriesLimit, entries, v.RunLimits.MaxDictEntries)
+	}
+	return nil
+}
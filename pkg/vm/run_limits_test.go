@@ -0,0 +1,201 @@
+package vm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+// Hint processor whose hints are go functions
+type funcHintProcessor struct{}
+
+type hintFunc func(*vm.VirtualMachine, *types.ExecutionScopes) error
+
+func (p *funcHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	return nil, nil
+}
+
+func (p *funcHintProcessor) ExecuteHint(v *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	return (*hintData).(hintFunc)(v, execScopes)
+}
+
+type dictEntries uint
+
+func (d dictEntries) DictEntries() uint {
+	return uint(d)
+}
+
+// Returns a vm that runs [ap] = 5; ap++ at its pc, along with the data of the given hints
+func newStepVm(hints ...hintFunc) (*vm.VirtualMachine, map[uint][]any) {
+	virtualMachine := vm.NewVirtualMachine()
+	program := virtualMachine.Segments.AddSegment()
+	execution := virtualMachine.Segments.AddSegment()
+	data := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480680017fff8000)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)),
+	}
+	virtualMachine.Segments.LoadData(program, &data)
+	// The instruction's op0 is [fp - 1]
+	frame, _ := virtualMachine.Segments.LoadData(execution, &[]memory.MaybeRelocatable{*memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero())})
+	virtualMachine.RunContext = vm.RunContext{Pc: program, Ap: frame, Fp: frame}
+	hintDatas := make([]any, 0, len(hints))
+	for _, hint := range hints {
+		hintDatas = append(hintDatas, hint)
+	}
+	return virtualMachine, map[uint][]any{0: hintDatas}
+}
+
+// Runs a step of [ap] = 5; ap++ with the given hints and limits
+func stepWithLimits(limits vm.RunLimits, hints ...hintFunc) error {
+	virtualMachine, hintDataMap := newStepVm(hints...)
+	virtualMachine.RunLimits = &limits
+	return virtualMachine.Step(&funcHintProcessor{}, &hintDataMap, nil, types.NewExecutionScopes())
+}
+
+func noopHint(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+	return nil
+}
+
+func TestRunLimitsNotExceeded(t *testing.T) {
+	limits := vm.RunLimits{MaxMemoryCells: 4, MaxSegments: 2, MaxHintExecutions: 1, MaxDictEntries: 1}
+	err := stepWithLimits(limits, noopHint)
+	if err != nil {
+		t.Errorf("Step failed with error: %s", err)
+	}
+}
+
+func TestRunLimitsMemoryCellsExceededByInstruction(t *testing.T) {
+	err := stepWithLimits(vm.RunLimits{MaxMemoryCells: 3})
+	if !errors.Is(err, vm.ErrMemoryCellsLimit) {
+		t.Errorf("Expected ErrMemoryCellsLimit, got: %v", err)
+	}
+}
+
+func TestRunLimitsSegmentsExceededByHint(t *testing.T) {
+	addSegments := func(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+		v.Segments.AddSegment()
+		return nil
+	}
+	err := stepWithLimits(vm.RunLimits{MaxSegments: 3}, addSegments, addSegments)
+	if !errors.Is(err, vm.ErrSegmentsLimit) {
+		t.Errorf("Expected ErrSegmentsLimit, got: %v", err)
+	}
+}
+
+func TestRunLimitsHintExecutionsExceeded(t *testing.T) {
+	executed := 0
+	countHint := func(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+		executed++
+		return nil
+	}
+	err := stepWithLimits(vm.RunLimits{MaxHintExecutions: 2}, countHint, countHint, countHint)
+	if !errors.Is(err, vm.ErrHintExecutionsLimit) {
+		t.Errorf("Expected ErrHintExecutionsLimit, got: %v", err)
+	}
+	if executed != 2 {
+		t.Errorf("Expected the hints within the limit to be executed, executed %d", executed)
+	}
+}
+
+func TestRunLimitsDictEntriesExceeded(t *testing.T) {
+	addDict := func(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+		execScopes.AssignOrUpdateVariable("__dict_manager", dictEntries(3))
+		return nil
+	}
+	err := stepWithLimits(vm.RunLimits{MaxDictEntries: 2}, addDict)
+	if !errors.Is(err, vm.ErrDictEntriesLimit) {
+		t.Errorf("Expected ErrDictEntriesLimit, got: %v", err)
+	}
+}
+
+func TestRunLimitsBuiltinInstancesExceeded(t *testing.T) {
+	rangeCheck := builtins.NewRangeCheckBuiltinRunner(8)
+	writeRangeChecks := func(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+		rangeCheck.InitializeSegments(&v.Segments)
+		v.BuiltinRunners = append(v.BuiltinRunners, rangeCheck)
+		base := rangeCheck.Base()
+		for i := uint(0); i < 3; i++ {
+			err := v.Segments.Memory.Insert(base.AddUint(i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	limits := vm.RunLimits{MaxBuiltinInstances: map[string]uint{builtins.RANGE_CHECK_BUILTIN_NAME: 2}}
+	err := stepWithLimits(limits, writeRangeChecks)
+	if !errors.Is(err, vm.ErrBuiltinInstancesLimit) {
+		t.Errorf("Expected ErrBuiltinInstancesLimit, got: %v", err)
+	}
+}
+
+func TestRunLimitsMemoryCellsEnforcedDuringHint(t *testing.T) {
+	written := uint(0)
+	fillMemory := func(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+		segment := v.Segments.AddSegment()
+		for i := uint(0); i < 1000; i++ {
+			err := v.Segments.Memory.Insert(segment.AddUint(i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne()))
+			if err != nil {
+				return err
+			}
+			written++
+		}
+		return nil
+	}
+	err := stepWithLimits(vm.RunLimits{MaxMemoryCells: 10}, fillMemory)
+	if !errors.Is(err, vm.ErrMemoryCellsLimit) {
+		t.Errorf("Expected ErrMemoryCellsLimit, got: %v", err)
+	}
+	// The vm starts with 3 cells
+	if written != 7 {
+		t.Errorf("Expected the hint to stop at the limit, wrote %d cells", written)
+	}
+}
+
+func TestRunLimitsSegmentsEnforcedDuringHint(t *testing.T) {
+	var virtualMachine *vm.VirtualMachine
+	addSegments := func(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+		virtualMachine = v
+		for i := 0; i < 1000; i++ {
+			v.Segments.AddSegment()
+		}
+		return nil
+	}
+	err := stepWithLimits(vm.RunLimits{MaxSegments: 5}, addSegments)
+	if !errors.Is(err, vm.ErrSegmentsLimit) {
+		t.Errorf("Expected ErrSegmentsLimit, got: %v", err)
+	}
+	if segments := virtualMachine.Segments.Memory.NumSegments(); segments != 5 {
+		t.Errorf("Expected the segments over the limit not to be added, got %d segments", segments)
+	}
+}
+
+func TestRunLimitsIgnoredLimitErrorFailsStep(t *testing.T) {
+	writeIgnoringErrors := func(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+		segment := v.Segments.AddSegment()
+		v.Segments.Memory.Insert(segment, memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne()))
+		return nil
+	}
+	err := stepWithLimits(vm.RunLimits{MaxSegments: 2}, writeIgnoringErrors)
+	if !errors.Is(err, vm.ErrSegmentsLimit) {
+		t.Errorf("Expected ErrSegmentsLimit, got: %v", err)
+	}
+}
+
+func TestRunLimitsDictEntriesInOuterScopes(t *testing.T) {
+	addDicts := func(v *vm.VirtualMachine, execScopes *types.ExecutionScopes) error {
+		execScopes.AssignOrUpdateVariable("__dict_manager", dictEntries(2))
+		execScopes.EnterScope(map[string]interface{}{"__dict_manager": dictEntries(1)})
+		return nil
+	}
+	err := stepWithLimits(vm.RunLimits{MaxDictEntries: 2}, addDicts)
+	if !errors.Is(err, vm.ErrDictEntriesLimit) {
+		t.Errorf("Expected ErrDictEntriesLimit, got: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package vm
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
@@ -36,6 +37,20 @@ type VirtualMachine struct {
 	RcLimitsMin     *int
 	RcLimitsMax     *int
 	RunResources    *RunResources
+	// Limits on the resources used by the run besides its steps, nil if there are none
+	RunLimits *RunLimits
+	// Amount of hints executed so far
+	HintExecutions uint
+	// Segment of the program being run, whose hints are the ones of the hint data map given to Step. Set by the runner
+	ProgramSegmentIndex int
+	// Programs loaded into memory during the run (such as bootloader tasks), indexed by the segment they were loaded into
+	LoadedPrograms map[int]*LoadedProgram
+	// Hooks called during the run, see AddHooks
+	hooks []Hooks
+	// First error returned by the hooks of the segments added and the memory writes since it was last checked
+	hooksErr error
+	// Checkpoints used to step backwards, nil unless reverse execution is enabled (see EnableReverseExecution)
+	reverse *reverseExecution
 }
 
 func NewVirtualMachine() *VirtualMachine {
@@ -47,14 +62,63 @@ func NewVirtualMachine() *VirtualMachine {
 }
 
 func (v *VirtualMachine) Step(hintProcessor HintProcessor, hintDataMap *map[uint][]any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	return v.StepWithContext(context.Background(), hintProcessor, hintDataMap, constants, execScopes)
+}
+
+// Same as Step, passing ctx to the hints of processors that implement ContextHintProcessor
+func (v *VirtualMachine) StepWithContext(ctx context.Context, hintProcessor HintProcessor, hintDataMap *map[uint][]any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	v.applyMemoryLimits()
+	if v.reverse != nil {
+		v.saveStepCheckpoint()
+	}
+	if len(v.hooks) != 0 {
+		err := v.preStepHooks()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Run Hint
-	hintDatas, ok := (*hintDataMap)[v.RunContext.Pc.Offset]
+	var hintDatas []any
+	var ok bool
+	if loadedProgram, isLoaded := v.LoadedPrograms[v.RunContext.Pc.SegmentIndex]; isLoaded {
+		// The pc is inside a program loaded during the run, so its own hints and constants are used instead
+		hintDatas, ok = loadedProgram.HintsAt(v.RunContext.Pc)
+		constants = &loadedProgram.Constants
+	} else if v.RunContext.Pc.SegmentIndex == v.ProgramSegmentIndex {
+		// Hints of the main program. Code in other segments, such as the one of cairo pie tasks, runs without hints
+		hintDatas, ok = (*hintDataMap)[v.RunContext.Pc.Offset]
+	}
 	if ok {
 		for i := 0; i < len(hintDatas); i++ {
-			err := hintProcessor.ExecuteHint(v, &hintDatas[i], constants, execScopes)
+			err := v.consumeHintExecution()
 			if err != nil {
 				return err
 			}
+			if len(v.hooks) != 0 {
+				err = v.hintHooks(hintDatas[i])
+				if err != nil {
+					return err
+				}
+			}
+			err = executeHint(ctx, hintProcessor, v, &hintDatas[i], constants, execScopes)
+			if err != nil {
+				return err
+			}
+			if len(v.hooks) != 0 {
+				err = v.hooksError()
+				if err != nil {
+					return err
+				}
+			}
+		}
+		err := v.checkRunLimits()
+		if err != nil {
+			return err
+		}
+		err = v.checkDictEntriesLimit(execScopes)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -79,7 +143,15 @@ func (v *VirtualMachine) Step(hintProcessor HintProcessor, hintDataMap *map[uint
 		return err
 	}
 
-	return v.RunInstruction(&instruction)
+	err = v.RunInstruction(&instruction)
+	if err != nil {
+		return err
+	}
+	err = v.checkRunLimits()
+	if err != nil || len(v.hooks) == 0 {
+		return err
+	}
+	return v.postStepHooks()
 }
 
 func (v *VirtualMachine) RunInstruction(instruction *Instruction) error {
@@ -87,6 +159,13 @@ func (v *VirtualMachine) RunInstruction(instruction *Instruction) error {
 	if err != nil {
 		return err
 	}
+	// The deductions ignore the errors of their memory writes, so the ones raised by the hooks are checked here
+	if len(v.hooks) != 0 {
+		err = v.hooksError()
+		if err != nil {
+			return err
+		}
+	}
 
 	err = v.OpcodeAssertions(*instruction, operands)
 	if err != nil {
@@ -630,6 +709,18 @@ func (vm *VirtualMachine) GetBuiltinRunner(builtinName string) (*builtins.Builti
 	return nil, &VirtualMachineError{"BuiltinNotFound"}
 }
 
+func (vm *VirtualMachine) GetOutputBuiltin() (*builtins.OutputBuiltinRunner, error) {
+	builtin, err := vm.GetBuiltinRunner(builtins.OUTPUT_BUILTIN_NAME)
+	if err != nil {
+		return nil, errors.New("The program doesn't use the output builtin")
+	}
+	outputBuiltin, ok := (*builtin).(*builtins.OutputBuiltinRunner)
+	if !ok {
+		return nil, errors.New("Could not cast to OutputBuiltinRunner")
+	}
+	return outputBuiltin, nil
+}
+
 func (vm *VirtualMachine) GetRangeCheckBound() (lambdaworks.Felt, error) {
 	builtin, err := vm.GetBuiltinRunner("range_check")
 	if err != nil {
@@ -652,3 +743,10 @@ func (vm *VirtualMachine) GetReturnValues(nRet uint) ([]memory.MaybeRelocatable,
 	}
 	return vm.Segments.Memory.GetRange(ptr, nRet)
 }
+
+func executeHint(ctx context.Context, hintProcessor HintProcessor, v *VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	if processor, ok := hintProcessor.(ContextHintProcessor); ok && ctx.Done() != nil {
+		return processor.ExecuteHintWithContext(ctx, v, hintData, constants, execScopes)
+	}
+	return hintProcessor.ExecuteHint(v, hintData, constants, execScopes)
+}
@@ -0,0 +1,286 @@
+#!/usr/bin/env python3
+"""
+Reference worker for the external hint protocol described in docs/external_hints.md.
+
+Executes the hints the Go VM doesn't implement with python's exec, exposing ids, memory, segments,
+ap, fp, pc, PRIME and the scope variables to them. Run it with:
+
+    cairo-vm-cli --hint_worker "python3 scripts/hint_worker.py" program.json
+"""
+import json
+import sys
+
+
+class RelocatableValue:
+    def __init__(self, segment_index, offset):
+        self.segment_index = segment_index
+        self.offset = offset
+
+    def __add__(self, other):
+        assert isinstance(other, int), f"Can't add {type(other)} to a relocatable"
+        return RelocatableValue(self.segment_index, self.offset + other)
+
+    __radd__ = __add__
+
+    def __sub__(self, other):
+        if isinstance(other, RelocatableValue):
+            assert self.segment_index == other.segment_index, "Can't subtract relocatables of different segments"
+            return self.offset - other.offset
+        return RelocatableValue(self.segment_index, self.offset - other)
+
+    def __eq__(self, other):
+        return (
+            isinstance(other, RelocatableValue)
+            and self.segment_index == other.segment_index
+            and self.offset == other.offset
+        )
+
+    def __lt__(self, other):
+        assert self.segment_index == other.segment_index, "Can't compare relocatables of different segments"
+        return self.offset < other.offset
+
+    def __hash__(self):
+        return hash((self.segment_index, self.offset))
+
+    def __str__(self):
+        return f"{self.segment_index}:{self.offset}"
+
+    __repr__ = __str__
+
+
+def decode(value):
+    if isinstance(value, dict):
+        return RelocatableValue(value["segment_index"], value["offset"])
+    if isinstance(value, list):
+        return [decode(elem) for elem in value]
+    return value
+
+
+def encode(value):
+    if isinstance(value, RelocatableValue):
+        return {"segment_index": value.segment_index, "offset": value.offset}
+    if isinstance(value, (list, tuple)):
+        return [encode(elem) for elem in value]
+    return value
+
+
+def is_encodable(value):
+    if isinstance(value, (list, tuple)):
+        return all(is_encodable(elem) for elem in value)
+    return value is None or isinstance(value, (bool, int, str, RelocatableValue))
+
+
+class Connection:
+    def __init__(self):
+        self.next_id = 0
+
+    def send(self, message):
+        message["jsonrpc"] = "2.0"
+        sys.stdout.write(json.dumps(message) + "\n")
+        sys.stdout.flush()
+
+    def call(self, method, params):
+        """Sends a request to the VM. The VM doesn't send requests while it waits for our answer"""
+        self.next_id += 1
+        self.send({"id": self.next_id, "method": method, "params": params})
+        response = json.loads(sys.stdin.readline())
+        if "error" in response:
+            raise RuntimeError(response["error"]["message"])
+        return response["result"]
+
+
+class Memory:
+    def __init__(self, connection, prime):
+        self.connection = connection
+        self.prime = prime
+        self.cache = {}
+        self.writes = {}
+
+    def __getitem__(self, addr):
+        if addr in self.writes:
+            return self.writes[addr]
+        if addr not in self.cache:
+            result = self.connection.call("read_memory", {"address": encode(addr), "size": 1})
+            self.cache[addr] = decode(result["values"][0])
+        value = self.cache[addr]
+        if value is None:
+            raise KeyError(f"Unknown value for memory cell at address {addr}")
+        return value
+
+    def __setitem__(self, addr, value):
+        if isinstance(value, int):
+            value %= self.prime
+        previous = self.writes.get(addr, self.cache.get(addr))
+        if previous is not None and previous != value:
+            raise AssertionError(f"Inconsistent memory assignment at address {addr}: {previous} != {value}")
+        self.writes[addr] = value
+
+    def get(self, addr, default=None):
+        try:
+            return self[addr]
+        except KeyError:
+            return default
+
+
+class Segments:
+    def __init__(self, num_segments):
+        self.num_segments = num_segments
+        self.new_segments = 0
+
+    def add(self):
+        segment = RelocatableValue(self.num_segments + self.new_segments, 0)
+        self.new_segments += 1
+        return segment
+
+
+class StructProxy:
+    """Struct located at address_, whose members are read and written in memory, like cairo-lang's ids structs"""
+
+    def __init__(self, ids, struct_type, address):
+        object.__setattr__(self, "_ids", ids)
+        object.__setattr__(self, "_type", struct_type)
+        object.__setattr__(self, "address_", address)
+
+    def _member(self, name):
+        member = self._ids._structs[self._type].get(name)
+        if member is None:
+            raise AttributeError(f"Struct {self._type} has no member {name}")
+        return self.address_ + member["offset"], member["type"]
+
+    def __getattr__(self, name):
+        addr, member_type = self._member(name)
+        return self._ids._load(member_type, addr)
+
+    def __setattr__(self, name, value):
+        addr, member_type = self._member(name)
+        self._ids._store(member_type, addr, value, f"{self._type}.{name}")
+
+
+class Ids:
+    def __init__(self, entries, constants, structs, memory):
+        object.__setattr__(self, "_entries", entries)
+        object.__setattr__(self, "_constants", constants)
+        object.__setattr__(self, "_structs", structs)
+        object.__setattr__(self, "_memory", memory)
+
+    def _is_struct(self, cairo_type):
+        return cairo_type in self._structs
+
+    def _proxy(self, cairo_type, value):
+        """Structs and pointers to structs are accessed through proxies, other values are returned as is"""
+        if self._is_struct(cairo_type):
+            return StructProxy(self, cairo_type, value)
+        if cairo_type.endswith("*") and self._is_struct(cairo_type[:-1]):
+            return StructProxy(self, cairo_type[:-1], value)
+        return value
+
+    def _load(self, cairo_type, addr):
+        if self._is_struct(cairo_type):
+            return self._proxy(cairo_type, addr)
+        return self._proxy(cairo_type, self._memory[addr])
+
+    def _store(self, cairo_type, addr, value, name):
+        if self._is_struct(cairo_type):
+            raise AttributeError(f"Can't assign to {name}, which is a struct")
+        if isinstance(value, StructProxy):
+            value = value.address_
+        self._memory[addr] = value
+
+    def __getattr__(self, name):
+        entry = self._entries.get(name)
+        if entry is None:
+            if name in self._constants:
+                return self._constants[name]
+            raise AttributeError(f"Unknown identifier {name}")
+        if self._is_struct(entry["type"]):
+            if entry["address"] is None:
+                raise AttributeError(f"Unknown address for ids.{name}")
+            return self._proxy(entry["type"], entry["address"])
+        if entry["address"] is not None and entry["address"] in self._memory.writes:
+            return self._proxy(entry["type"], self._memory.writes[entry["address"]])
+        if entry["value"] is None:
+            raise AttributeError(f"Unknown value for ids.{name}")
+        return self._proxy(entry["type"], entry["value"])
+
+    def __setattr__(self, name, value):
+        entry = self._entries.get(name)
+        if entry is None or entry["address"] is None:
+            raise AttributeError(f"Can't assign to ids.{name}")
+        self._store(entry["type"], entry["address"], value, f"ids.{name}")
+
+
+def execute_hint(connection, params, scopes):
+    prime = params["prime"]
+    memory = Memory(connection, prime)
+    for entry in params["ids"].values():
+        entry["address"] = decode(entry["address"])
+        entry["value"] = decode(entry["value"])
+    segments = Segments(params["num_segments"])
+    scope = {name: decode(value) for name, value in params["scope"].items()}
+    scope_changes = {"exit": False, "enter": None}
+
+    def vm_enter_scope(new_scope_locals=None):
+        scope_changes["enter"] = dict(new_scope_locals or {})
+
+    def vm_exit_scope():
+        scope_changes["exit"] = True
+
+    hint_globals = {
+        "ids": Ids(params["ids"], params["constants"], params["structs"], memory),
+        "memory": memory,
+        "segments": segments,
+        "ap": decode(params["ap"]),
+        "fp": decode(params["fp"]),
+        "pc": decode(params["pc"]),
+        "PRIME": prime,
+        "vm_enter_scope": vm_enter_scope,
+        "vm_exit_scope": vm_exit_scope,
+        "to_felt_or_relocatable": lambda value: value % prime if isinstance(value, int) else value,
+    }
+    # The variables that can't be sent to the VM are kept here from one hint to the next
+    exec_globals = {**scopes[-1], **scope, **hint_globals}
+    exec(params["code"], exec_globals)
+
+    scope_updates = {}
+    for name, value in exec_globals.items():
+        if name in hint_globals or name == "__builtins__":
+            continue
+        if not is_encodable(value):
+            scopes[-1][name] = value
+        elif name not in scope or scope[name] != value:
+            scope_updates[name] = encode(value)
+    if scope_changes["exit"]:
+        scopes.pop()
+    enter_scope = None
+    if scope_changes["enter"] is not None:
+        enter_scope = {name: encode(value) for name, value in scope_changes["enter"].items() if is_encodable(value)}
+        scopes.append({name: value for name, value in scope_changes["enter"].items() if not is_encodable(value)})
+    return {
+        "new_segments": segments.new_segments,
+        "memory_writes": [{"address": encode(addr), "value": encode(value)} for addr, value in memory.writes.items()],
+        "scope_updates": scope_updates,
+        "exit_scope": scope_changes["exit"],
+        "enter_scope": enter_scope,
+    }
+
+
+def main():
+    connection = Connection()
+    # Python-only scope variables, mirroring the VM's execution scopes
+    scopes = [{}]
+    for line in sys.stdin:
+        if not line.strip():
+            continue
+        request = json.loads(line)
+        if request.get("method") != "execute_hint":
+            connection.send({"id": request.get("id"), "error": {"code": -32601, "message": "Method not found"}})
+            continue
+        try:
+            result = execute_hint(connection, request["params"], scopes)
+            connection.send({"id": request["id"], "result": result})
+        except Exception as e:
+            connection.send({"id": request["id"], "error": {"code": -32603, "message": f"{type(e).__name__}: {e}"}})
+
+
+if __name__ == "__main__":
+    main()